@@ -2,21 +2,277 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/go-faster/errors"
 	"github.com/jolfzverb/codegen/internal/generator"
 	"github.com/jolfzverb/codegen/internal/generator/options"
 )
 
+const (
+	exitOK       = 0
+	exitProblems = 1
+	exitUsage    = 2
+)
+
+const usage = `usage: generate [command] [flags] [spec files]
+
+commands:
+  generate  generate the code of the spec files (default)
+  validate  check that the code of the spec files can be generated, nothing is written
+  lint      report the constructs of the spec files which the generator does not handle
+  routes    list the method, path, operation id and handler of every operation
+  diff      compare an old and a new version of a spec file, fails on breaking changes
+  watch     generate the spec files again whenever they change
+
+Run "generate <command> -h" for the flags of a command.
+`
+
+var commands = map[string]func(args []string) int{
+	"generate": generate,
+	"validate": validate,
+	"lint":     lint,
+	"routes":   routes,
+	"diff":     diff,
+	"watch":    watch,
+}
+
 func main() {
-	opts, err := options.GetOptions()
+	args := os.Args[1:]
+	command := generate
+	if len(args) > 0 {
+		if args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(exitOK)
+		}
+		// without a command the arguments are the ones of generate
+		if c, ok := commands[args[0]]; ok {
+			command = c
+			args = args[1:]
+		}
+	}
+	os.Exit(command(args))
+}
+
+func parseOptions(args []string) (*options.Options, int) {
+	return parseCommandOptions(args, nil)
+}
+
+func parseCommandOptions(args []string, register func(flags *flag.FlagSet)) (*options.Options, int) {
+	opts, err := options.ParseCommandOptions(args, register)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil, exitOK
+	}
 	if err != nil {
-		panic(err)
+		fmt.Fprintln(os.Stderr, err)
+
+		return nil, exitUsage
+	}
+
+	return opts, exitOK
+}
+
+// report prints the problems of a generation and returns the exit code.
+func report(err error) int {
+	var stale *generator.StaleFilesError
+	var diagnostics generator.Diagnostics
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &stale):
+		fmt.Print(stale.Diff)
+		fmt.Fprintln(os.Stderr, stale.Error())
+	case errors.As(err, &diagnostics):
+		fmt.Fprintln(os.Stderr, diagnostics.Error())
+	default:
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	return exitProblems
+}
+
+func generate(args []string) int {
+	opts, code := parseOptions(args)
+	if opts == nil {
+		return code
 	}
 
-	ctx := context.Background()
 	gen := generator.NewGenerator(opts)
-	err = gen.Generate(ctx)
+
+	return report(gen.Generate(context.Background()))
+}
+
+// inMemory generates the code of the spec files without writing it.
+func inMemory(opts *options.Options) (*generator.Generator, error) {
+	gen := generator.NewGenerator(opts)
+	gen.Output = make(map[string][]byte)
+
+	return gen, gen.Generate(context.Background())
+}
+
+func validate(args []string) int {
+	opts, code := parseOptions(args)
+	if opts == nil {
+		return code
+	}
+
+	_, err := inMemory(opts)
+
+	return report(err)
+}
+
+func lint(args []string) int {
+	var format string
+	opts, code := parseCommandOptions(args, func(flags *flag.FlagSet) {
+		flags.StringVar(&format, "format", "text", "Output format of the findings: text or json")
+	})
+	if opts == nil {
+		return code
+	}
+	if format != "text" && format != "json" {
+		fmt.Fprintf(os.Stderr, "unsupported format %q, supported formats: text, json\n", format)
+
+		return exitUsage
+	}
+
+	findings, err := generator.NewGenerator(opts).Lint(context.Background())
+	if err != nil {
+		return report(err)
+	}
+	if format == "json" {
+		if findings == nil {
+			findings = []generator.Finding{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(findings)
+		if err != nil {
+			return report(err)
+		}
+	} else {
+		for _, finding := range findings {
+			fmt.Println(finding.String())
+		}
+	}
+	for _, finding := range findings {
+		if finding.Severity == generator.SeverityError {
+			return exitProblems
+		}
+	}
+
+	return exitOK
+}
+
+func diff(args []string) int {
+	var format string
+	opts, code := parseCommandOptions(args, func(flags *flag.FlagSet) {
+		flags.StringVar(&format, "format", "text", "Output format of the changes: text or json")
+	})
+	if opts == nil {
+		return code
+	}
+	if format != "text" && format != "json" {
+		fmt.Fprintf(os.Stderr, "unsupported format %q, supported formats: text, json\n", format)
+
+		return exitUsage
+	}
+	if len(opts.YAMLFiles) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: generate diff [flags] old.yaml new.yaml")
+
+		return exitUsage
+	}
+
+	specDiff, err := generator.DiffSpecs(opts, opts.YAMLFiles[0], opts.YAMLFiles[1])
 	if err != nil {
-		panic(err)
+		return report(err)
+	}
+	if format == "json" {
+		if specDiff.Changes == nil {
+			specDiff.Changes = []generator.Change{}
+		}
+		if specDiff.Symbols == nil {
+			specDiff.Symbols = []generator.SymbolChange{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(specDiff)
+		if err != nil {
+			return report(err)
+		}
+	} else {
+		for _, change := range specDiff.Changes {
+			fmt.Println(change.String())
+		}
+		if len(specDiff.Symbols) > 0 {
+			fmt.Println("Go symbols:")
+		}
+		for _, symbol := range specDiff.Symbols {
+			if symbol.Breaking {
+				fmt.Printf("  %s %s (breaking)\n", symbol.Change, symbol.Symbol)
+			} else {
+				fmt.Printf("  %s %s\n", symbol.Change, symbol.Symbol)
+			}
+		}
+	}
+	if specDiff.Breaking() {
+		return exitProblems
 	}
+
+	return exitOK
+}
+
+func routes(args []string) int {
+	opts, code := parseOptions(args)
+	if opts == nil {
+		return code
+	}
+
+	gen, err := inMemory(opts)
+	if err != nil {
+		return report(err)
+	}
+	printRoutes(os.Stdout, gen.Routes)
+
+	return exitOK
+}
+
+func printRoutes(output io.Writer, routes []generator.Route) {
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "METHOD\tPATH\tOPERATION ID\tHANDLER")
+	for _, route := range routes {
+		operationID := route.OperationID
+		if operationID == "" {
+			operationID = "-"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", route.Method, route.Path, operationID, route.Handler)
+	}
+	writer.Flush()
+}
+
+// watch generates the spec files again whenever they or the files they
+// reference change, until it is interrupted.
+func watch(args []string) int {
+	opts, code := parseOptions(args)
+	if opts == nil {
+		return code
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	watcher := generator.NewWatcher(opts)
+	watcher.Report = func(files []string, err error) {
+		if report(err) == exitOK {
+			slog.Info("Generated", "files", strings.Join(files, ", "))
+		}
+	}
+
+	return report(watcher.Watch(ctx))
 }
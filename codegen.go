@@ -0,0 +1,211 @@
+// Package codegen generates chi handlers and models from OpenAPI specifications.
+//
+// The generated code of a spec file is a handlers package and a models package,
+// by default <OutputDir>/generated/<name> and
+// <OutputDir>/generated/<name>/<name>models, where the name is the spec file
+// name in lower case without '_' and '-'.
+package codegen
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"path"
+
+	"github.com/go-faster/errors"
+	"github.com/jolfzverb/codegen/internal/generator"
+	"github.com/jolfzverb/codegen/internal/generator/options"
+	"github.com/jolfzverb/codegen/plugin"
+)
+
+// ErrNoSpecs is returned when no spec file is given.
+var ErrNoSpecs = errors.New("at least one spec file must be provided")
+
+// Error is returned when the code of a spec file cannot be generated.
+type Error struct {
+	// Spec is the spec file which failed, it may be a file referenced by one of
+	// the given spec files.
+	Spec string
+	// Diagnostics are all the problems found in the spec file, they are empty
+	// when the spec file could not be read.
+	Diagnostics []Diagnostic
+	Err         error
+}
+
+// Diagnostic is a problem of a spec file.
+type Diagnostic struct {
+	File string
+	// Pointer is the JSON pointer of the spec element, like
+	// #/paths/~1users/post/requestBody.
+	Pointer string
+	// Line and Column are the 1-based position of the element in the spec file,
+	// they are zero when it is unknown.
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	if len(e.Diagnostics) == 0 {
+		return e.Spec + ": " + e.Err.Error()
+	}
+
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Options configure the generated code.
+type Options struct {
+	// OutputDir is the directory of the generated packages, "internal" when empty.
+	OutputDir string
+	// PackagePrefix is the import path of OutputDir.
+	PackagePrefix string
+	// RequiredFieldsArePointers generates required fields as pointers.
+	RequiredFieldsArePointers bool
+	// DefaultFieldsAreValues generates optional fields with a default value as
+	// values set to the default when absent.
+	DefaultFieldsAreValues bool
+	// NullableType generates nullable fields as Nullable[T], which tells absent
+	// and null values apart.
+	NullableType bool
+	// AllowDeleteWithBody allows DELETE operations with a request body.
+	AllowDeleteWithBody bool
+	// AllowRemoteAddrParam fills Remote-Addr header parameters with the
+	// remote-addr format from the address of the client.
+	AllowRemoteAddrParam bool
+	// Initialisms are kept upper case in Go names in addition to the common ones.
+	Initialisms []string
+	// MaxValidationDepth is the maximum nesting depth of request bodies, 64 when
+	// zero.
+	MaxValidationDepth int
+	// Specs override the layout of single spec files, keyed by the spec file.
+	Specs map[string]SpecOptions
+	// Plugins generate extra files for every spec file.
+	Plugins []plugin.Plugin
+}
+
+// SpecOptions override the generated code of a single spec file.
+type SpecOptions struct {
+	// Package is the name of the handlers package.
+	Package string
+	// ModelsPackage is the name of the models package, Package + "models" by default.
+	ModelsPackage string
+	// HandlersPath and ModelsPath are the directories of the packages relative
+	// to OutputDir and PackagePrefix.
+	HandlersPath string
+	ModelsPath   string
+	// RequiredFieldsArePointers overrides Options.RequiredFieldsArePointers.
+	RequiredFieldsArePointers *bool
+}
+
+func (o Options) internal(specs []string) (*options.Options, error) {
+	opts := &options.Options{
+		DirPrefix:                 o.OutputDir,
+		PackagePrefix:             o.PackagePrefix,
+		YAMLFiles:                 specs,
+		RequiredFieldsArePointers: o.RequiredFieldsArePointers,
+		AllowDeleteWithBody:       o.AllowDeleteWithBody,
+		AllowRemoteAddrParam:      o.AllowRemoteAddrParam,
+		DefaultFieldsAreValues:    o.DefaultFieldsAreValues,
+		NullableType:              o.NullableType,
+		Initialisms:               o.Initialisms,
+		MaxValidationDepth:        o.MaxValidationDepth,
+		Router:                    options.RouterChi,
+		Generators:                []string{options.GeneratorServer},
+		Specs:                     make(map[string]options.Settings, len(o.Specs)),
+	}
+	if opts.DirPrefix == "" {
+		opts.DirPrefix = "internal"
+	}
+	for spec, specOptions := range o.Specs {
+		settings := options.Settings{
+			Package:       specOptions.Package,
+			ModelsPackage: specOptions.ModelsPackage,
+			HandlersPath:  specOptions.HandlersPath,
+			ModelsPath:    specOptions.ModelsPath,
+			Pointers:      specOptions.RequiredFieldsArePointers,
+		}
+		err := settings.Validate()
+		if err != nil {
+			return nil, &Error{Spec: spec, Err: err}
+		}
+		opts.Specs[path.Clean(spec)] = settings
+	}
+
+	return opts, nil
+}
+
+// Generate generates the code of the spec files read from fsys and returns the
+// generated files keyed by their path. The files referenced by the spec files
+// are generated as well.
+func Generate(ctx context.Context, fsys fs.FS, specs []string, opts Options) (map[string][]byte, error) {
+	return generate(ctx, specs, opts, func(name string) ([]byte, error) {
+		return fs.ReadFile(fsys, name)
+	})
+}
+
+// GenerateReader generates the code of the spec file read from r, name is the
+// path of the spec file. The files it references are read from fsys.
+func GenerateReader(ctx context.Context, r io.Reader, name string, fsys fs.FS, opts Options,
+) (map[string][]byte, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &Error{Spec: name, Err: err}
+	}
+
+	return generate(ctx, []string{name}, opts, func(fileName string) ([]byte, error) {
+		if path.Clean(fileName) == path.Clean(name) {
+			return data, nil
+		}
+		if fsys == nil {
+			return nil, &fs.PathError{Op: "open", Path: fileName, Err: fs.ErrNotExist}
+		}
+
+		return fs.ReadFile(fsys, fileName)
+	})
+}
+
+func generate(ctx context.Context, specs []string, opts Options, readFile func(string) ([]byte, error),
+) (map[string][]byte, error) {
+	if len(specs) == 0 {
+		return nil, ErrNoSpecs
+	}
+	internalOpts, err := opts.internal(specs)
+	if err != nil {
+		return nil, err
+	}
+
+	gen := generator.NewGenerator(internalOpts)
+	gen.ReadFile = readFile
+	gen.Plugins = opts.Plugins
+	gen.Output = make(map[string][]byte)
+	err = gen.Generate(ctx)
+	if err != nil {
+		return nil, newError(gen.CurrentYAMLFile, err)
+	}
+
+	return gen.Output, nil
+}
+
+func newError(spec string, err error) *Error {
+	var diagnostics generator.Diagnostics
+	if !errors.As(err, &diagnostics) {
+		return &Error{Spec: spec, Err: err}
+	}
+
+	result := &Error{Spec: spec, Err: diagnostics}
+	for _, diagnostic := range diagnostics {
+		result.Diagnostics = append(result.Diagnostics, Diagnostic{
+			File:    diagnostic.File,
+			Pointer: diagnostic.Pointer,
+			Line:    diagnostic.Line,
+			Column:  diagnostic.Column,
+			Message: diagnostic.Message(),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,238 @@
+package codegen_test
+
+import (
+	"context"
+	"errors"
+	"go/ast"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jolfzverb/codegen"
+	"github.com/jolfzverb/codegen/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const apiSpec = `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        tag:
+          $ref: 'common/def.yml#/components/schemas/Tag'
+`
+
+const defSpec = `openapi: 3.0.0
+info:
+  title: Definitions
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Tag:
+      type: string
+`
+
+func TestGenerate(t *testing.T) {
+	fsys := fstest.MapFS{
+		"specs/api.yaml":        {Data: []byte(apiSpec)},
+		"specs/common/def.yml":  {Data: []byte(defSpec)},
+		"specs/common/unused.y": {Data: []byte("not a spec")},
+	}
+	pointers := true
+	files, err := codegen.Generate(context.Background(), fsys, []string{"specs/api.yaml"}, codegen.Options{
+		PackagePrefix: "example.com/service/internal",
+		Specs: map[string]codegen.SpecOptions{
+			"specs/common/def.yml": {Package: "common", HandlersPath: "shared", RequiredFieldsArePointers: &pointers},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"internal/generated/api/apimodels/models.go",
+		"internal/generated/api/handlers.go",
+		"internal/shared/commonmodels/models.go",
+		"internal/shared/handlers.go",
+	}, keys(files))
+	models := string(files["internal/generated/api/apimodels/models.go"])
+	assert.Contains(t, models, `import "example.com/service/internal/shared/commonmodels"`)
+	assert.Contains(t, models, "Tag *commonmodels.Tag")
+	assert.True(t, strings.HasPrefix(string(files["internal/shared/handlers.go"]),
+		"// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.\n\npackage common\n"))
+}
+
+func TestGenerateReader(t *testing.T) {
+	files, err := codegen.GenerateReader(context.Background(), strings.NewReader(defSpec), "def.yml", nil,
+		codegen.Options{PackagePrefix: "example.com/service/internal", OutputDir: "out"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"out/generated/def/defmodels/models.go", "out/generated/def/handlers.go"}, keys(files))
+	assert.Contains(t, string(files["out/generated/def/defmodels/models.go"]), "type Tag string")
+
+	_, err = codegen.GenerateReader(context.Background(), strings.NewReader(apiSpec), "api.yaml", nil,
+		codegen.Options{PackagePrefix: "example.com/service/internal"})
+	var genErr *codegen.Error
+	require.ErrorAs(t, err, &genErr)
+	assert.Equal(t, "api.yaml", genErr.Spec)
+}
+
+func TestGenerateConcurrent(t *testing.T) {
+	fsys := fstest.MapFS{"specs/common/def.yml": {Data: []byte(defSpec)}}
+	var specs []string
+	for i := range 8 {
+		name := "specs/api" + strconv.Itoa(i) + ".yaml"
+		fsys[name] = &fstest.MapFile{Data: []byte(apiSpec)}
+		specs = append(specs, name)
+	}
+	opts := codegen.Options{PackagePrefix: "example.com/service/internal"}
+
+	expected, err := codegen.Generate(context.Background(), fsys, specs, opts)
+	require.NoError(t, err)
+	assert.Len(t, expected, 2*len(specs)+2)
+	assert.Contains(t, string(expected["internal/generated/api7/api7models/models.go"]), "Tag *defmodels.Tag")
+	for range 4 {
+		files, err := codegen.Generate(context.Background(), fsys, specs, opts)
+		require.NoError(t, err)
+		assert.Equal(t, expected, files)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	_, err := codegen.Generate(context.Background(), fstest.MapFS{}, nil, codegen.Options{})
+	require.ErrorIs(t, err, codegen.ErrNoSpecs)
+
+	fsys := fstest.MapFS{
+		"api.yaml": {Data: []byte(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: string
+      responses:
+        '200':
+          description: OK
+`)},
+	}
+	_, err = codegen.Generate(context.Background(), fsys, []string{"api.yaml"}, codegen.Options{})
+	var genErr *codegen.Error
+	require.ErrorAs(t, err, &genErr)
+	assert.Equal(t, "api.yaml", genErr.Spec)
+	assert.Equal(t, []codegen.Diagnostic{{
+		File:    "api.yaml",
+		Pointer: "#/paths/~1pets/get/requestBody",
+		Line:    8,
+		Column:  7,
+		Message: "GET method should not have request body",
+	}}, genErr.Diagnostics)
+	assert.EqualError(t, err, "api.yaml:8:7: #/paths/~1pets/get/requestBody: GET method should not have request body")
+
+	_, err = codegen.Generate(context.Background(), fsys, []string{"api.yaml"}, codegen.Options{
+		Specs: map[string]codegen.SpecOptions{"api.yaml": {Package: "my-api"}},
+	})
+	require.ErrorAs(t, err, &genErr)
+	assert.ErrorContains(t, err, `api.yaml: package "my-api" is not a valid Go package name`)
+}
+
+func keys(files map[string][]byte) []string {
+	result := make([]string, 0, len(files))
+	for name := range files {
+		result = append(result, name)
+	}
+
+	return result
+}
+
+type permissionsPlugin struct{}
+
+func (permissionsPlugin) Name() string {
+	return "permissions"
+}
+
+// Generate lists the x-permission of every operation by the name of its handler.
+func (permissionsPlugin) Generate(spec *plugin.Spec) ([]plugin.File, error) {
+	var elements []ast.Expr
+	for _, operation := range spec.Operations {
+		permission, ok := operation.Operation.Extensions["x-permission"].(string)
+		if !ok {
+			return nil, errors.New("operation " + operation.Method + " " + operation.Path + " has no x-permission")
+		}
+		elements = append(elements, &ast.KeyValueExpr{
+			Key:   &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(operation.BaseName)},
+			Value: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(permission)},
+		})
+	}
+
+	return []plugin.File{{
+		Path: path.Join(spec.HandlersDir, "permissions.go"),
+		File: &ast.File{
+			Name: ast.NewIdent(spec.Package),
+			Decls: []ast.Decl{&ast.GenDecl{
+				Doc: &ast.CommentGroup{List: []*ast.Comment{{Text: "// Permissions of the " +
+					spec.Models["pet"] + " handlers."}}},
+				Tok: token.VAR,
+				Specs: []ast.Spec{&ast.ValueSpec{
+					Names: []*ast.Ident{ast.NewIdent("Permissions")},
+					Values: []ast.Expr{&ast.CompositeLit{
+						Type: &ast.MapType{Key: ast.NewIdent("string"), Value: ast.NewIdent("string")},
+						Elts: elements,
+					}},
+				}},
+			}},
+		},
+	}}, nil
+}
+
+func TestGeneratePlugins(t *testing.T) {
+	spec := `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: list_pets
+      x-permission: pets.read
+      responses:
+        '200':
+          description: OK
+    post:
+      x-permission: pets.write
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    pet:
+      type: string
+      x-go-name: Pet
+`
+	files, err := codegen.GenerateReader(context.Background(), strings.NewReader(spec), "api.yaml", nil,
+		codegen.Options{Plugins: []plugin.Plugin{permissionsPlugin{}}})
+	require.NoError(t, err)
+	assert.Equal(t, `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package api
+
+// Permissions of the Pet handlers.
+var Permissions = map[string]string{"ListPets": "pets.read", "PostPets": "pets.write"}
+`, string(files["internal/generated/api/permissions.go"]))
+
+	_, err = codegen.GenerateReader(context.Background(), strings.NewReader(strings.ReplaceAll(spec,
+		"      x-permission: pets.write\n", "")), "api.yaml", nil,
+		codegen.Options{Plugins: []plugin.Plugin{permissionsPlugin{}}})
+	assert.ErrorContains(t, err, "plugin permissions: operation POST /pets has no x-permission")
+}
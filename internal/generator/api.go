@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"log/slog"
@@ -12,34 +13,84 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-faster/errors"
 	"github.com/jolfzverb/codegen/internal/generator/options"
+	"github.com/jolfzverb/codegen/plugin"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
-const directoryPermissions = 0o755
+const (
+	directoryPermissions = 0o755
+	filePermissions      = 0o644
+
+	generatedHeader = "// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.\n\n"
+)
 
 type Generator struct {
-	Opts *options.Options
+	// Opts are the options of the current spec file, baseOpts the ones of the run.
+	Opts     *options.Options
+	baseOpts *options.Options
 
 	SchemasFile  *SchemasFile
 	HandlersFile *HandlersFile
 	yaml         *openapi3.T
 
 	// strings
-	PackageName      string
-	ImportPrefix     string
-	ModelsImportPath string
-	CurrentYAMLFile  string
+	PackageName       string
+	ModelsPackageName string
+	ImportPrefix      string
+	ModelsImportPath  string
+	CurrentYAMLFile   string
+	layout            Layout
+	// ReadFile reads the spec files, they are read from disk when it is nil.
+	ReadFile func(name string) ([]byte, error)
+	// Output receives the generated files instead of the disk when it is not nil.
+	Output map[string][]byte
+	// Plugins generate extra files for every spec file.
+	Plugins []plugin.Plugin
+	// Routes are the operations of the spec files generated by Generate, in the
+	// order of the spec files and of the routes.
+	Routes []Route
+
+	registry   *registry
+	staleFiles StaleFilesError
+	// linting collects the findings of the spec files instead of failing on
+	// their diagnostics.
+	linting  bool
+	findings []Finding
+	// cache is nil when the generated files are not written to disk.
+	cache    *cache
+	cacheKey string
+	// source is the content of the current spec file.
+	source      []byte
+	diagnostics Diagnostics
 
 	YAMLFilesToProcess []string
 	YAMLFilesProcessed map[string]bool
+
+	initialisms map[string]bool
 }
 
 func NewGenerator(opts *options.Options) *Generator {
 	return &Generator{
 		Opts:               opts,
+		baseOpts:           opts,
 		YAMLFilesToProcess: opts.YAMLFiles,
 		YAMLFilesProcessed: make(map[string]bool),
+		registry:           newRegistry(),
+	}
+}
+
+// newFileGenerator returns the generation context of a spec file, it shares
+// the options and the registry of the run.
+func (g *Generator) newFileGenerator(yamlFilePath string) *Generator {
+	return &Generator{
+		Opts:               g.baseOpts.ForFile(yamlFilePath),
+		baseOpts:           g.baseOpts,
+		CurrentYAMLFile:    yamlFilePath,
+		ReadFile:           g.ReadFile,
+		Plugins:            g.Plugins,
+		YAMLFilesProcessed: make(map[string]bool),
+		registry:           g.registry,
 	}
 }
 
@@ -57,25 +108,26 @@ func (g *Generator) WriteToOutput(modelsOutput io.Writer, handlersOutput io.Writ
 	return nil
 }
 
-func (g *Generator) Gen() {
+func (g *Generator) Gen() error {
 	const op = "generator.Generate"
 
 	// one time
 	g.InitHandlerFields(g.PackageName)
 
 	if g.yaml.Paths != nil && len(g.yaml.Paths.Map()) > 0 {
-		err := g.ProcessPaths(g.yaml.Paths)
-		if err != nil {
-			panic(errors.Wrap(err, op))
-		}
+		g.ProcessPaths(g.yaml.Paths)
 	}
 
 	if g.yaml.Components != nil && g.yaml.Components.Schemas != nil {
-		err := g.ProcessSchemas(g.yaml.Components.Schemas)
-		if err != nil {
-			panic(errors.Wrap(err, op))
-		}
+		g.ProcessSchemas(g.yaml.Components.Schemas)
 	}
+	g.checkHelperTypeNames()
+
+	if len(g.diagnostics) > 0 {
+		return errors.Wrap(g.diagnostics, op)
+	}
+
+	return nil
 
 }
 
@@ -98,24 +150,52 @@ func (g *Generator) GetModelName(yamlFilePath string) string {
 func (g *Generator) PrepareAndRead(reader io.Reader) error {
 	const op = "generator.PrepareAndRead"
 	ctx := context.Background()
-	loader := &openapi3.Loader{Context: ctx, IsExternalRefsAllowed: true}
 	var err error
 	data, err := io.ReadAll(reader)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
+	g.source = data
+	g.diagnostics = nil
 	url, err := url.Parse(g.CurrentYAMLFile)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
-	g.yaml, err = loader.LoadFromDataWithPath(data, url)
+	swagger, err := ReadSwagger2(data)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
+	if swagger != nil {
+		g.checkFormData(swagger)
+		if len(g.diagnostics) > 0 {
+			return errors.Wrap(g.diagnostics, op)
+		}
+		g.yaml, err = ConvertSwagger2(g.registry.loader(false, g.readFromURI), swagger, url)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+	} else {
+		var converted bool
+		data, converted, err = ConvertOpenAPI31(data)
+		var located *pointerError
+		if errors.As(err, &located) {
+			g.addDiagnostic(err)
+
+			return errors.Wrap(g.diagnostics, op)
+		}
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		g.yaml, err = g.registry.loader(converted, g.readFromURI).LoadFromDataWithPath(data, url)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
 	err = g.yaml.Validate(ctx)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
+	g.FlattenAllOf()
 
 	g.NewSchemasFile()
 	g.NewHandlersFile()
@@ -126,26 +206,22 @@ func (g *Generator) PrepareAndRead(reader io.Reader) error {
 func (g *Generator) PrepareFiles() error {
 	const op = "generator.PrepareFiles"
 
-	file, err := os.Open(g.CurrentYAMLFile)
+	data, err := g.readFile(g.CurrentYAMLFile)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
-	defer file.Close()
-
-	reader := io.Reader(file)
-
-	g.PackageName = g.GetModelName(g.CurrentYAMLFile)
 
-	handlersPath := path.Join(g.Opts.DirPrefix, "generated", g.PackageName)
-	schemasPath := path.Join(handlersPath, g.GetCurrentModelsPackage())
-	err = os.MkdirAll(schemasPath, directoryPermissions)
+	g.layout = g.GetLayout(g.CurrentYAMLFile)
+	err = g.registry.claimLayout(g.CurrentYAMLFile, g.layout)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
+	g.PackageName = g.layout.Package
+	g.ModelsPackageName = g.layout.ModelsPackage
 
-	g.ImportPrefix = path.Join(g.Opts.PackagePrefix, "generated", g.PackageName)
-	g.ModelsImportPath = path.Join(g.ImportPrefix, g.GetCurrentModelsPackage())
-	err = g.PrepareAndRead(reader)
+	g.ImportPrefix = g.layout.HandlersImport
+	g.ModelsImportPath = g.layout.ModelsImport
+	err = g.PrepareAndRead(bytes.NewReader(data))
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
@@ -153,61 +229,214 @@ func (g *Generator) PrepareFiles() error {
 	return nil
 }
 
+func (g *Generator) readFile(name string) ([]byte, error) {
+	if g.ReadFile == nil {
+		return os.ReadFile(name)
+	}
+
+	return g.ReadFile(name)
+}
+
+// readFromURI reads the local files referenced by the spec files like the spec
+// files. The default reader of the loader caches the files for the life of the
+// process, so they would not be read again when they change.
+func (g *Generator) readFromURI(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	if location.Scheme != "" || location.Host != "" {
+		return openapi3.DefaultReadFromURI(loader, location)
+	}
+
+	return g.readFile(location.Path)
+}
+
 func (g *Generator) GenerateFiles() error {
-	g.Gen()
+	const op = "generator.GenerateFiles"
+	err := g.Gen()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
 	return nil
 }
+
+// RenderFiles renders the files of the current spec in memory, they are keyed
+// by their path.
+func (g *Generator) RenderFiles() (map[string][]byte, error) {
+	const op = "generator.RenderFiles"
+
+	schemasOutput := &bytes.Buffer{}
+	handlersOutput := &bytes.Buffer{}
+	err := g.WriteToOutput(schemasOutput, handlersOutput)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	files := map[string][]byte{
+		path.Join(g.layout.ModelsDir, "models.go"):     schemasOutput.Bytes(),
+		path.Join(g.layout.HandlersDir, "handlers.go"): handlersOutput.Bytes(),
+	}
+	err = g.RenderPluginFiles(files)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return files, nil
+}
+
 func (g *Generator) WriteOutFiles() error {
 	const op = "generator.WriteOutFiles"
 
-	handlersPath := path.Join(g.Opts.DirPrefix, "generated", g.PackageName)
-	schemasPath := path.Join(handlersPath, g.GetCurrentModelsPackage())
-	schemasOutput, err := os.Create(path.Join(schemasPath, "models.go"))
+	files, err := g.RenderFiles()
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
-	defer schemasOutput.Close()
-
-	handlersOutput, err := os.Create(path.Join(handlersPath, "handlers.go"))
+	err = g.writeFiles(files)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
-	defer handlersOutput.Close()
 
-	err = g.WriteToOutput(schemasOutput, handlersOutput)
-	if err != nil {
-		return errors.Wrap(err, op)
+	return nil
+}
+
+func (g *Generator) writeFiles(files map[string][]byte) error {
+	for fileName, content := range files {
+		if g.Output != nil {
+			g.Output[fileName] = content
+
+			continue
+		}
+		err := os.MkdirAll(path.Dir(fileName), directoryPermissions)
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(fileName, content, filePermissions)
+		if err != nil {
+			return err
+		}
 	}
+
 	return nil
 }
 
+// Generate generates the spec files in waves. The files of a wave are loaded
+// one after another with the shared loaders and then generated concurrently,
+// the files they reference make up the next wave. The generated files are
+// written in the order of the spec files, so the output does not depend on the
+// scheduling.
 func (g *Generator) Generate(ctx context.Context) error {
 	const op = "generator.Generate"
 
+	if g.Output == nil && !g.baseOpts.Check {
+		g.cache = g.readCache()
+	}
 	for len(g.YAMLFilesToProcess) > 0 {
-		g.CurrentYAMLFile = g.YAMLFilesToProcess[0]
-
-		if g.YAMLFilesProcessed[g.CurrentYAMLFile] {
-			g.YAMLFilesToProcess = g.YAMLFilesToProcess[1:]
-			continue
-		}
-		slog.Info("Processing file", "file", g.CurrentYAMLFile)
-
-		err := g.PrepareFiles()
+		fileGens, err := g.loadFiles()
 		if err != nil {
 			return errors.Wrap(err, op)
 		}
-		err = g.GenerateFiles()
+
+		outputs := make([]map[string][]byte, len(fileGens))
+		errs := make([]error, len(fileGens))
+		parallel(ctx, len(fileGens), func(i int) {
+			outputs[i], errs[i] = fileGens[i].generateFile()
+		})
+		err = ctx.Err()
 		if err != nil {
 			return errors.Wrap(err, op)
 		}
-		err = g.WriteOutFiles()
+
+		for i, fileGen := range fileGens {
+			g.CurrentYAMLFile = fileGen.CurrentYAMLFile
+			var diagnostics Diagnostics
+			if g.linting && (errs[i] == nil || errors.As(errs[i], &diagnostics)) {
+				g.findings = append(g.findings, fileGen.lintFile(diagnostics)...)
+				errs[i] = nil
+			}
+			if errs[i] != nil {
+				return errors.Wrap(errs[i], op)
+			}
+			if g.baseOpts.Check {
+				err = g.checkFiles(outputs[i])
+			} else {
+				err = g.writeFiles(outputs[i])
+			}
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if g.cache != nil {
+				g.cache.record(fileGen.CurrentYAMLFile, fileGen.cacheKey, outputs[i], fileGen.YAMLFilesToProcess)
+			}
+			g.Routes = append(g.Routes, fileGen.routes()...)
+			g.YAMLFilesToProcess = append(g.YAMLFilesToProcess, fileGen.YAMLFilesToProcess...)
+		}
+	}
+	if g.cache != nil {
+		err := g.writeCache()
 		if err != nil {
 			return errors.Wrap(err, op)
 		}
-		g.YAMLFilesProcessed[g.CurrentYAMLFile] = true
-		g.YAMLFilesToProcess = g.YAMLFilesToProcess[1:]
+	}
+	if len(g.staleFiles.Files) > 0 {
+		stale := g.staleFiles
+
+		return errors.Wrap(&stale, op)
 	}
 
 	return nil
 }
+
+// loadFiles takes the spec files to process off the queue and loads them, each
+// into a generation context of its own.
+func (g *Generator) loadFiles() ([]*Generator, error) {
+	const op = "generator.loadFiles"
+
+	var fileGens []*Generator
+	queue := g.YAMLFilesToProcess
+	g.YAMLFilesToProcess = nil
+	for _, yamlFilePath := range queue {
+		if g.YAMLFilesProcessed[yamlFilePath] {
+			continue
+		}
+		g.YAMLFilesProcessed[yamlFilePath] = true
+		g.CurrentYAMLFile = yamlFilePath
+
+		fileGen := g.newFileGenerator(yamlFilePath)
+		if g.cache != nil {
+			fileGen.cacheKey = fileGen.inputsKey()
+			entry, ok := g.cache.upToDate(yamlFilePath, fileGen.cacheKey)
+			if ok && !g.baseOpts.Force {
+				slog.Info("Skipping unchanged file", "file", yamlFilePath)
+				err := g.registry.claimLayout(yamlFilePath, g.GetLayout(yamlFilePath))
+				if err != nil {
+					return nil, errors.Wrap(err, op)
+				}
+				g.YAMLFilesToProcess = append(g.YAMLFilesToProcess, entry.References...)
+
+				continue
+			}
+		}
+		slog.Info("Processing file", "file", yamlFilePath)
+		err := fileGen.PrepareFiles()
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		fileGens = append(fileGens, fileGen)
+	}
+
+	return fileGens, nil
+}
+
+// generateFile generates the code of the loaded spec file, it only reads the
+// shared documents so the spec files of a wave are generated concurrently.
+func (g *Generator) generateFile() (map[string][]byte, error) {
+	const op = "generator.generateFile"
+	err := g.GenerateFiles()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	files, err := g.RenderFiles()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,256 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"path"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	cacheFileName = ".codegen-cache"
+	cacheVersion  = 1
+
+	modulePath = "github.com/jolfzverb/codegen"
+)
+
+// cache records the inputs of the spec files generated to disk, a spec file is
+// skipped when its inputs and its generated files did not change since.
+type cache struct {
+	Version int                   `json:"version"`
+	Specs   map[string]cacheEntry `json:"specs"`
+}
+
+type cacheEntry struct {
+	// Key is the hash of the spec file, the files it references and their
+	// layouts, the generator version and the options.
+	Key string `json:"key"`
+	// Files are the hashes of the generated files, keyed by their path.
+	Files map[string]string `json:"files"`
+	// References are the spec files queued for generation by the spec file.
+	References []string `json:"references,omitempty"`
+}
+
+func (g *Generator) cachePath() string {
+	return path.Join(g.baseOpts.DirPrefix, cacheFileName)
+}
+
+// readCache reads the cache of the output directory, a missing or unreadable
+// cache is empty.
+func (g *Generator) readCache() *cache {
+	result := &cache{Version: cacheVersion, Specs: make(map[string]cacheEntry)}
+	data, err := os.ReadFile(g.cachePath())
+	if err != nil {
+		return result
+	}
+	var stored cache
+	err = json.Unmarshal(data, &stored)
+	if err != nil || stored.Version != cacheVersion || stored.Specs == nil {
+		slog.Warn("Ignoring invalid cache", "file", g.cachePath())
+
+		return result
+	}
+
+	return &stored
+}
+
+func (g *Generator) writeCache() error {
+	data, err := json.MarshalIndent(g.cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(g.baseOpts.DirPrefix, directoryPermissions)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(g.cachePath(), append(data, '\n'), filePermissions)
+}
+
+// upToDate reports whether the spec file was generated with the same key and
+// its generated files are unchanged on disk.
+func (c *cache) upToDate(yamlFilePath string, key string) (cacheEntry, bool) {
+	entry, ok := c.Specs[yamlFilePath]
+	if !ok || entry.Key != key || len(entry.Files) == 0 {
+		return entry, false
+	}
+	for fileName, hash := range entry.Files {
+		content, err := os.ReadFile(fileName)
+		if err != nil || hashBytes(content) != hash {
+			return entry, false
+		}
+	}
+
+	return entry, true
+}
+
+func (c *cache) record(yamlFilePath string, key string, files map[string][]byte, references []string) {
+	if key == "" {
+		delete(c.Specs, yamlFilePath)
+
+		return
+	}
+	entry := cacheEntry{Key: key, Files: make(map[string]string, len(files)), References: references}
+	for fileName, content := range files {
+		entry.Files[fileName] = hashBytes(content)
+	}
+	c.Specs[yamlFilePath] = entry
+}
+
+// inputsKey hashes the inputs of the current spec file. It is empty when the
+// spec file cannot be cached: when a file cannot be read, when a remote file is
+// referenced or when the generator version is unknown.
+func (g *Generator) inputsKey() string {
+	version := generatorVersion()
+	if version == "" {
+		return ""
+	}
+	opts := *g.Opts
+	opts.YAMLFiles = nil
+	opts.Specs = nil
+	opts.Check = false
+	opts.Force = false
+	optsJSON, err := json.Marshal(opts)
+	if err != nil {
+		return ""
+	}
+
+	hash := sha256.New()
+	writeHashField(hash, version)
+	writeHashField(hash, string(optsJSON))
+	for _, p := range g.Plugins {
+		writeHashField(hash, p.Name())
+	}
+
+	inputs, ok := g.specInputs()
+	if !ok {
+		return ""
+	}
+	fileNames := make([]string, 0, len(inputs))
+	for fileName := range inputs {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		// the layout of a referenced spec file decides how its models are imported
+		layoutJSON, err := json.Marshal(g.GetLayout(fileName))
+		if err != nil {
+			return ""
+		}
+		writeHashField(hash, fileName)
+		writeHashField(hash, string(inputs[fileName]))
+		writeHashField(hash, string(layoutJSON))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// specInputs reads the current spec file and the files it references,
+// transitively.
+func (g *Generator) specInputs() (map[string][]byte, bool) {
+	inputs := make(map[string][]byte)
+	queue := []string{g.CurrentYAMLFile}
+	for len(queue) > 0 {
+		fileName := queue[0]
+		queue = queue[1:]
+		if _, ok := inputs[fileName]; ok {
+			continue
+		}
+		data, err := g.readFile(fileName)
+		if err != nil {
+			return nil, false
+		}
+		inputs[fileName] = data
+
+		var document yaml.Node
+		err = yaml.Unmarshal(data, &document)
+		if err != nil {
+			return nil, false
+		}
+		for _, ref := range collectRefs(&document, nil) {
+			refURL, err := url.Parse(ref)
+			if err != nil || refURL.Scheme != "" || refURL.Host != "" {
+				return nil, false
+			}
+			if refURL.Path != "" {
+				queue = append(queue, path.Join(path.Dir(fileName), refURL.Path))
+			}
+		}
+	}
+
+	return inputs, true
+}
+
+func collectRefs(node *yaml.Node, refs []string) []string {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "$ref" && value.Kind == yaml.ScalarNode {
+				refs = append(refs, value.Value)
+			}
+		}
+	}
+	for _, child := range node.Content {
+		refs = collectRefs(child, refs)
+	}
+
+	return refs
+}
+
+// generatorVersion identifies the build of the generator: the version of the
+// module, or the hash of the executable for development builds.
+var generatorVersion = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if ok {
+		modules := append([]*debug.Module{&info.Main}, info.Deps...)
+		for _, module := range modules {
+			if module.Path == modulePath && module.Replace == nil && isReleaseVersion(module.Version) {
+				return module.Version
+			}
+		}
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	file, err := os.Open(executable)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return ""
+	}
+
+	return "exe:" + hex.EncodeToString(hash.Sum(nil))
+})
+
+func isReleaseVersion(version string) bool {
+	return version != "" && version != "(devel)" && !strings.HasSuffix(version, "+dirty")
+}
+
+// writeHashField writes value with its length, so that the fields of a hash
+// cannot run into each other.
+func writeHashField(w io.Writer, value string) {
+	_, _ = fmt.Fprintf(w, "%d:%s", len(value), value)
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
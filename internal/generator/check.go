@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-faster/errors"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// StaleFilesError is returned in check mode when the generated code differs from
+// the files on disk.
+type StaleFilesError struct {
+	Files []string
+	// Diff is the unified diff from the files on disk to the generated code.
+	Diff string
+}
+
+func (e *StaleFilesError) Error() string {
+	return "generated files are out of date: " + strings.Join(e.Files, ", ")
+}
+
+// CheckOutFiles renders the files of the current spec in memory and records
+// the ones which differ from the files on disk, nothing is written.
+func (g *Generator) CheckOutFiles() error {
+	const op = "generator.CheckOutFiles"
+
+	files, err := g.RenderFiles()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	err = g.checkFiles(files)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	return nil
+}
+
+// checkFiles records the rendered files which differ from the files on disk.
+func (g *Generator) checkFiles(files map[string][]byte) error {
+	fileNames := make([]string, 0, len(files))
+	for fileName := range files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		err := g.checkFile(fileName, files[fileName])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *Generator) checkFile(fileName string, content []byte) error {
+	current, err := os.ReadFile(fileName)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if bytes.Equal(current, content) {
+		return nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(current),
+		B:        splitLines(content),
+		FromFile: "a/" + fileName,
+		ToFile:   "b/" + fileName,
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+	g.staleFiles.Files = append(g.staleFiles.Files, fileName)
+	g.staleFiles.Diff += diff
+
+	return nil
+}
+
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
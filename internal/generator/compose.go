@@ -0,0 +1,233 @@
+package generator
+
+import (
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+// FlattenAllOf merges the allOf compositions of the loaded specification into
+// plain schemas, so the rest of the generator only deals with objects, arrays,
+// maps and scalars.
+func (g *Generator) FlattenAllOf() {
+	f := &allOfFlattener{g: g, visited: make(map[*openapi3.Schema]bool)}
+	flatten := func(schema *openapi3.SchemaRef, segments ...string) {
+		f.flatten(schema, true, segments)
+	}
+
+	if g.yaml.Components != nil {
+		for name, schema := range g.yaml.Components.Schemas {
+			// components keep their own types, so they are merged even for a single part
+			f.flatten(schema, false, []string{"components", "schemas", name})
+		}
+		for name, param := range g.yaml.Components.Parameters {
+			flattenParameter(param, flatten, "components", "parameters", name)
+		}
+		for name, body := range g.yaml.Components.RequestBodies {
+			flattenRequestBody(body, flatten, "components", "requestBodies", name)
+		}
+		for name, response := range g.yaml.Components.Responses {
+			flattenResponse(response, flatten, "components", "responses", name)
+		}
+	}
+	if g.yaml.Paths == nil {
+		return
+	}
+	for pathName, pathItem := range g.yaml.Paths.Map() {
+		for i, param := range pathItem.Parameters {
+			flattenParameter(param, flatten, "paths", pathName, "parameters", strconv.Itoa(i))
+		}
+		for method, operation := range pathItem.Operations() {
+			method = strings.ToLower(method)
+			for i, param := range operation.Parameters {
+				flattenParameter(param, flatten, "paths", pathName, method, "parameters", strconv.Itoa(i))
+			}
+			flattenRequestBody(operation.RequestBody, flatten, "paths", pathName, method, "requestBody")
+			if operation.Responses != nil {
+				for code, response := range operation.Responses.Map() {
+					flattenResponse(response, flatten, "paths", pathName, method, "responses", code)
+				}
+			}
+		}
+	}
+}
+
+type flattenFunc func(schema *openapi3.SchemaRef, segments ...string)
+
+func flattenParameter(param *openapi3.ParameterRef, flatten flattenFunc, segments ...string) {
+	if param == nil || param.Value == nil {
+		return
+	}
+	flatten(param.Value.Schema, append(segments, "schema")...)
+}
+
+func flattenRequestBody(body *openapi3.RequestBodyRef, flatten flattenFunc, segments ...string) {
+	if body == nil || body.Value == nil {
+		return
+	}
+	for contentType, mediaType := range body.Value.Content {
+		flatten(mediaType.Schema, append(segments, "content", contentType, "schema")...)
+	}
+}
+
+func flattenResponse(response *openapi3.ResponseRef, flatten flattenFunc, segments ...string) {
+	if response == nil || response.Value == nil {
+		return
+	}
+	for contentType, mediaType := range response.Value.Content {
+		flatten(mediaType.Schema, append(segments, "content", contentType, "schema")...)
+	}
+	for name, header := range response.Value.Headers {
+		if header.Value != nil {
+			flatten(header.Value.Schema, append(segments, "headers", name, "schema")...)
+		}
+	}
+}
+
+// allOfFlattener merges the allOf parts of every schema once, the conflicts of
+// the parts are reported at the first location of the schema.
+type allOfFlattener struct {
+	g       *Generator
+	visited map[*openapi3.Schema]bool
+}
+
+func (f *allOfFlattener) flatten(schema *openapi3.SchemaRef, inline bool, segments []string) {
+	if schema == nil || schema.Value == nil {
+		return
+	}
+	if name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/"); ok {
+		// problems of a referenced component are reported at the component
+		segments = []string{"components", "schemas", name}
+	}
+	value := schema.Value
+	if len(value.AllOf) == 1 && value.AllOf[0].Ref != "" && len(value.Properties) == 0 && inline {
+		// allOf with a single reference is the usual way to add nullable or a
+		// description to a $ref, it stays a reference to the same type
+		part := value.AllOf[0]
+		f.flatten(part, false, childSegments(segments, "allOf", "0"))
+		merged := *part.Value
+		merged.Nullable = merged.Nullable || value.Nullable
+		merged.Deprecated = merged.Deprecated || value.Deprecated
+		merged.ReadOnly = merged.ReadOnly || value.ReadOnly
+		merged.WriteOnly = merged.WriteOnly || value.WriteOnly
+		if value.Description != "" {
+			merged.Description = value.Description
+		}
+		if value.Default != nil {
+			merged.Default = value.Default
+		}
+		if value.Example != nil {
+			merged.Example = value.Example
+		}
+		schema.Ref = part.Ref
+		schema.Value = &merged
+
+		return
+	}
+	if f.visited[value] {
+		return
+	}
+	f.visited[value] = true
+
+	for name, property := range value.Properties {
+		f.flatten(property, true, childSegments(segments, "properties", name))
+	}
+	f.flatten(value.Items, true, childSegments(segments, "items"))
+	f.flatten(value.AdditionalProperties.Schema, true, childSegments(segments, "additionalProperties"))
+	for i, part := range value.AllOf {
+		f.flatten(part, false, childSegments(segments, "allOf", strconv.Itoa(i)))
+	}
+	if len(value.AllOf) == 0 {
+		return
+	}
+
+	for i, part := range value.AllOf {
+		if part.Value == nil {
+			continue
+		}
+		err := mergeSchema(value, part.Value)
+		if err != nil {
+			f.g.addDiagnostic(err, childSegments(segments, "allOf", strconv.Itoa(i))...)
+		}
+	}
+	value.AllOf = nil
+}
+
+func childSegments(segments []string, children ...string) []string {
+	return append(slices.Clone(segments), children...)
+}
+
+// mergeSchema adds the properties and the constraints of the allOf part to the
+// schema, the values set on the schema itself take precedence. It fails for
+// patterns, as a value has to match all of them and only one is validated.
+func mergeSchema(value *openapi3.Schema, part *openapi3.Schema) error {
+	if value.Type == nil {
+		value.Type = part.Type
+	}
+	if len(part.Properties) > 0 {
+		if value.Properties == nil {
+			value.Properties = make(openapi3.Schemas, len(part.Properties))
+		}
+		for name, property := range part.Properties {
+			if _, ok := value.Properties[name]; ok {
+				slog.Warn("property is defined in several allOf parts, the first one is used", slog.String("property", name))
+
+				continue
+			}
+			value.Properties[name] = property
+		}
+	}
+	for _, name := range part.Required {
+		if !slices.Contains(value.Required, name) {
+			value.Required = append(value.Required, name)
+		}
+	}
+	if value.AdditionalProperties.Schema == nil && value.AdditionalProperties.Has == nil {
+		value.AdditionalProperties = part.AdditionalProperties
+	}
+	if value.Format == "" {
+		value.Format = part.Format
+	}
+	switch {
+	case value.Pattern == "":
+		value.Pattern = part.Pattern
+	case part.Pattern != "" && part.Pattern != value.Pattern:
+		return errors.Errorf("pattern %q conflicts with the pattern %q of the schema or of another allOf part, only one pattern is validated",
+			part.Pattern, value.Pattern)
+	}
+	if value.MinLength == 0 {
+		value.MinLength = part.MinLength
+	}
+	if value.MaxLength == nil {
+		value.MaxLength = part.MaxLength
+	}
+	if value.Min == nil {
+		value.Min = part.Min
+		value.ExclusiveMin = part.ExclusiveMin
+	}
+	if value.Max == nil {
+		value.Max = part.Max
+		value.ExclusiveMax = part.ExclusiveMax
+	}
+	if value.MultipleOf == nil {
+		value.MultipleOf = part.MultipleOf
+	}
+	if len(value.Enum) == 0 {
+		value.Enum = part.Enum
+	}
+	if value.Items == nil {
+		value.Items = part.Items
+	}
+	if value.MinItems == 0 {
+		value.MinItems = part.MinItems
+	}
+	if value.MaxItems == nil {
+		value.MaxItems = part.MaxItems
+	}
+
+	return nil
+}
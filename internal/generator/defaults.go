@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+	"log/slog"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+type defaultField struct {
+	Name  string
+	Type  string
+	Value ast.Expr
+	// IsValue is true when the field is generated without a pointer
+	IsValue bool
+}
+
+// GetDefaultValueExpr returns a Go literal for the schema default or nil if the
+// schema has no default or it cannot be expressed as a constant.
+func GetDefaultValueExpr(schema *openapi3.SchemaRef) ast.Expr {
+	if schema == nil || schema.Value == nil || schema.Value.Default == nil {
+		return nil
+	}
+	if GetGoTypeOverride(schema) != "" {
+		slog.Warn("default value is not supported for x-go-type", slog.String("type", GetGoTypeOverride(schema)))
+
+		return nil
+	}
+	value := schema.Value.Default
+	switch {
+	case schema.Value.Type.Is(openapi3.TypeString):
+		if !stringFormatIsPlain(schema.Value.Format) {
+			slog.Warn("default value is not supported for format", slog.String("format", schema.Value.Format))
+
+			return nil
+		}
+		strValue, ok := value.(string)
+		if !ok {
+			slog.Warn("default value is not a string", slog.Any("value", value))
+
+			return nil
+		}
+
+		return Str(strValue)
+	case schema.Value.Type.Is(openapi3.TypeInteger):
+		floatValue, ok := value.(float64)
+		if !ok {
+			slog.Warn("default value is not a number", slog.Any("value", value))
+
+			return nil
+		}
+
+		return &ast.BasicLit{Kind: token.INT, Value: strconv.FormatInt(int64(floatValue), 10)}
+	case schema.Value.Type.Is(openapi3.TypeNumber):
+		floatValue, ok := value.(float64)
+		if !ok {
+			slog.Warn("default value is not a number", slog.Any("value", value))
+
+			return nil
+		}
+
+		return &ast.BasicLit{Kind: token.FLOAT, Value: strconv.FormatFloat(floatValue, 'f', -1, 64)}
+	case schema.Value.Type.Is(openapi3.TypeBoolean):
+		boolValue, ok := value.(bool)
+		if !ok {
+			slog.Warn("default value is not a boolean", slog.Any("value", value))
+
+			return nil
+		}
+
+		return I(strconv.FormatBool(boolValue))
+	}
+	slog.Warn("default value is supported only for primitive types", slog.Any("type", schema.Value.Type))
+
+	return nil
+}
+
+// hasValueDefault reports whether an optional field with the schema is generated
+// as a value instead of a pointer because its default guarantees it is set.
+func (g *Generator) hasValueDefault(schema *openapi3.SchemaRef) bool {
+	if !g.Opts.DefaultFieldsAreValues {
+		return false
+	}
+	if schema == nil || schema.Value == nil || schema.Value.Default == nil {
+		return false
+	}
+
+	return GetDefaultValueExpr(schema) != nil
+}
+
+func (g *Generator) AddUnmarshalWithDefaults(modelName string, fields []defaultField) {
+	g.AddSchemasImport("encoding/json")
+
+	elts := make([]ast.Expr, 0, len(fields))
+	for _, field := range fields {
+		if field.IsValue {
+			elts = append(elts, &ast.KeyValueExpr{
+				Key:   I(field.Name),
+				Value: field.Value,
+			})
+		}
+	}
+
+	body := []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.TYPE,
+				Specs: []ast.Spec{
+					&ast.TypeSpec{
+						Name: I("plain"),
+						Type: I(modelName),
+					},
+				},
+			},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("value")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CompositeLit{Type: I("plain"), Elts: elts}},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{I("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun:  Sel(I("json"), "Unmarshal"),
+					Args: []ast.Expr{I("data"), Amp(I("value"))},
+				},
+			},
+		},
+		&ast.IfStmt{
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(I("err"))}},
+		},
+	}
+
+	for _, field := range fields {
+		if field.IsValue {
+			continue
+		}
+		varName := "default" + field.Name
+		body = append(body, &ast.IfStmt{
+			Cond: Eq(Sel(I("value"), field.Name), I("nil")),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{I(varName)},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{&ast.CallExpr{
+							Fun:  I(field.Type),
+							Args: []ast.Expr{field.Value},
+						}},
+					},
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{Sel(I("value"), field.Name)},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{Amp(I(varName))},
+					},
+				},
+			},
+		})
+	}
+
+	body = append(body,
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{Star(I("m"))},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{&ast.CallExpr{Fun: I(modelName), Args: []ast.Expr{I("value")}}},
+		},
+		Ret1(I("nil")),
+	)
+
+	g.SchemasFile.decls = append(g.SchemasFile.decls, Func(
+		"UnmarshalJSON",
+		Field("m", Star(I(modelName)), ""),
+		FieldA(Field("data", &ast.ArrayType{Elt: I("byte")}, "")),
+		FieldA(Field("", I("error"), "")),
+		body,
+	))
+}
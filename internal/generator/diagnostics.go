@@ -0,0 +1,165 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-faster/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Diagnostic is a problem of a spec file at the location of a JSON pointer.
+type Diagnostic struct {
+	File    string
+	Pointer string
+	// Line and Column are 1-based, they are zero when the pointer is not found
+	// in the spec file, as for documents converted from Swagger 2.0.
+	Line   int
+	Column int
+	Err    error
+}
+
+func (d *Diagnostic) Error() string {
+	location := d.File
+	if d.Line > 0 {
+		location += ":" + strconv.Itoa(d.Line) + ":" + strconv.Itoa(d.Column)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", location, d.Pointer, d.Message())
+}
+
+// Message describes the problem without its location.
+func (d *Diagnostic) Message() string {
+	return rootCause(d.Err)
+}
+
+func (d *Diagnostic) Unwrap() error {
+	return d.Err
+}
+
+// Diagnostics are all the problems found in a spec file.
+type Diagnostics []*Diagnostic
+
+func (d Diagnostics) Error() string {
+	messages := make([]string, 0, len(d))
+	for _, diagnostic := range d {
+		messages = append(messages, diagnostic.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+func (d Diagnostics) Unwrap() []error {
+	result := make([]error, 0, len(d))
+	for _, diagnostic := range d {
+		result = append(result, diagnostic)
+	}
+
+	return result
+}
+
+// rootCause is the message of the innermost error, without the names of the
+// functions it passed through.
+func rootCause(err error) string {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err.Error()
+		}
+		err = next
+	}
+}
+
+// pointerError carries the path of the spec element which caused err, relative
+// to the element of the caller.
+type pointerError struct {
+	segments []string
+	err      error
+}
+
+func (e *pointerError) Error() string {
+	return e.err.Error()
+}
+
+func (e *pointerError) Unwrap() error {
+	return e.err
+}
+
+// withPointer prepends the segments to the pointer of err.
+func withPointer(err error, segments ...string) error {
+	var inner *pointerError
+	if errors.As(err, &inner) {
+		return &pointerError{segments: append(segments, inner.segments...), err: inner.err}
+	}
+
+	return &pointerError{segments: segments, err: err}
+}
+
+// addDiagnostic records the problem err of the element at segments of the
+// current spec file, generation goes on to find the other problems.
+func (g *Generator) addDiagnostic(err error, segments ...string) {
+	g.diagnostics = append(g.diagnostics, newDiagnostic(g.CurrentYAMLFile, g.source, withPointer(err, segments...)))
+}
+
+// newDiagnostic locates err, which carries the pointer of the problem, in the
+// source of the spec file.
+func newDiagnostic(file string, source []byte, err error) *Diagnostic {
+	located := &pointerError{err: err}
+	errors.As(err, &located)
+
+	pointer := "#"
+	for _, segment := range located.segments {
+		pointer += "/" + escapePointer(segment)
+	}
+	diagnostic := &Diagnostic{
+		File:    file,
+		Pointer: pointer,
+		Err:     located.err,
+	}
+	diagnostic.Line, diagnostic.Column = findPosition(source, located.segments)
+
+	return diagnostic
+}
+
+// findPosition returns the position of the element at segments in the YAML
+// or JSON document, or of its closest ancestor in the document.
+func findPosition(source []byte, segments []string) (int, int) {
+	var document yaml.Node
+	err := yaml.Unmarshal(source, &document)
+	if err != nil || len(document.Content) == 0 {
+		return 0, 0
+	}
+
+	node := document.Content[0]
+	position := node
+	for _, segment := range segments {
+		key, value := childNode(node, segment)
+		if value == nil {
+			break
+		}
+		position, node = key, value
+	}
+
+	return position.Line, position.Column
+}
+
+// childNode returns the key and the value of the child of a mapping or a
+// sequence node at segment, the key of a sequence item is the item itself.
+func childNode(node *yaml.Node, segment string) (*yaml.Node, *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment {
+				return node.Content[i], node.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		index, err := strconv.Atoi(segment)
+		if err == nil && index >= 0 && index < len(node.Content) {
+			return node.Content[index], node.Content[index]
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,749 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+	"github.com/jolfzverb/codegen/internal/generator/options"
+)
+
+const (
+	ChangeRemovedOperation     = "removed-operation"
+	ChangeAddedOperation       = "added-operation"
+	ChangeNewRequiredParameter = "new-required-parameter"
+	ChangeAddedParameter       = "added-parameter"
+	ChangeRemovedParameter     = "removed-parameter"
+	ChangeNewRequiredBody      = "new-required-body"
+	ChangeRemovedBody          = "removed-body"
+	ChangeRemovedContent       = "removed-content"
+	ChangeRemovedResponse      = "removed-response"
+	ChangeAddedResponse        = "added-response"
+	ChangeRemovedSchema        = "removed-schema"
+	ChangeAddedSchema          = "added-schema"
+	ChangeNewlyRequiredField   = "newly-required-field"
+	ChangeOptionalField        = "optional-field"
+	ChangeRemovedField         = "removed-field"
+	ChangeAddedField           = "added-field"
+	ChangeNarrowedEnum         = "narrowed-enum"
+	ChangeWidenedEnum          = "widened-enum"
+	ChangeType                 = "type-change"
+)
+
+// Change is a difference between two versions of a spec file.
+type Change struct {
+	Kind     string `json:"kind"`
+	Breaking bool   `json:"breaking"`
+	// Pointer is the location of the change in the new version, or in the old
+	// one for the removed elements.
+	Pointer string `json:"pointer"`
+	Message string `json:"message"`
+}
+
+func (c Change) String() string {
+	severity := "non-breaking"
+	if c.Breaking {
+		severity = "breaking"
+	}
+
+	return fmt.Sprintf("%s: %s [%s] (%s)", severity, c.Message, c.Kind, c.Pointer)
+}
+
+// SymbolChange is an exported Go symbol of the generated code which is added,
+// removed or changed, like apimodels.Pet.Name. The removed symbols break the
+// servers using them.
+type SymbolChange struct {
+	Symbol   string `json:"symbol"`
+	Change   string `json:"change"`
+	Breaking bool   `json:"breaking"`
+}
+
+type SpecDiff struct {
+	Changes []Change       `json:"changes"`
+	Symbols []SymbolChange `json:"symbols"`
+}
+
+func (d *SpecDiff) Breaking() bool {
+	for _, change := range d.Changes {
+		if change.Breaking {
+			return true
+		}
+	}
+	for _, symbol := range d.Symbols {
+		if symbol.Breaking {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DiffSpecs compares two versions of a spec file. Both versions are generated
+// in memory with the package name of the new version to compare the generated
+// Go symbols.
+func DiffSpecs(opts *options.Options, oldFile string, newFile string) (*SpecDiff, error) {
+	const op = "generator.DiffSpecs"
+	packageName := opts.ForFile(newFile).PackageName
+	if packageName == "" {
+		packageName = (&Generator{}).GetModelName(newFile)
+	}
+
+	oldDoc, oldFiles, err := loadVersion(opts, oldFile, packageName)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	newDoc, newFiles, err := loadVersion(opts, newFile, packageName)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	d := &differ{usage: schemaUsage(oldDoc)}
+	for name, usage := range schemaUsage(newDoc) {
+		d.usage[name] |= usage
+	}
+	d.comparePaths(oldDoc.Paths, newDoc.Paths)
+	d.compareComponents(oldDoc.Components, newDoc.Components)
+
+	symbols, err := diffSymbols(oldFiles, newFiles)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &SpecDiff{Changes: d.changes, Symbols: symbols}, nil
+}
+
+// loadVersion loads and generates a version of a spec file, the files it
+// references are not generated.
+func loadVersion(opts *options.Options, yamlFilePath string, packageName string,
+) (*openapi3.T, map[string][]byte, error) {
+	versionOpts := *opts
+	versionOpts.YAMLFiles = []string{yamlFilePath}
+	versionOpts.Specs = make(map[string]options.Settings, len(opts.Specs)+1)
+	for file, settings := range opts.Specs {
+		versionOpts.Specs[file] = settings
+	}
+	settings := opts.Specs[filepath.Clean(yamlFilePath)]
+	settings.Package = packageName
+	versionOpts.Specs[filepath.Clean(yamlFilePath)] = settings
+
+	gen := NewGenerator(&versionOpts)
+	gen.Output = make(map[string][]byte)
+	fileGen := gen.newFileGenerator(yamlFilePath)
+	err := fileGen.PrepareFiles()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, yamlFilePath)
+	}
+	files, err := fileGen.generateFile()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, yamlFilePath)
+	}
+
+	return fileGen.yaml, files, nil
+}
+
+// direction tells whether a schema is sent by the clients, by the servers or
+// by both, which decides whether a change breaks them.
+type direction int
+
+const (
+	inRequest direction = 1 << iota
+	inResponse
+)
+
+type differ struct {
+	// usage is the direction of the component schemas, the ones which are not
+	// used by the operations go both ways.
+	usage   map[string]direction
+	changes []Change
+}
+
+func (d *differ) add(kind string, breaking bool, segments []string, format string, args ...any) {
+	pointer := "#"
+	for _, segment := range segments {
+		pointer += "/" + escapePointer(segment)
+	}
+	d.changes = append(d.changes, Change{
+		Kind:     kind,
+		Breaking: breaking,
+		Pointer:  pointer,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+var pathParamPattern = regexp.MustCompile(`\{[^}]*\}`)
+
+// operationKeys returns the operations keyed by method and path, the path
+// parameters may be renamed.
+func operationKeys(paths *openapi3.Paths) (map[string][]string, []string) {
+	segments := make(map[string][]string)
+	var keys []string
+	if paths == nil {
+		return segments, keys
+	}
+	for _, pathName := range paths.InMatchingOrder() {
+		for _, method := range lintMethods {
+			if paths.Value(pathName).GetOperation(strings.ToUpper(method)) == nil {
+				continue
+			}
+			key := strings.ToUpper(method) + " " + pathParamPattern.ReplaceAllString(pathName, "{}")
+			segments[key] = []string{"paths", pathName, method}
+			keys = append(keys, key)
+		}
+	}
+
+	return segments, keys
+}
+
+func getOperation(doc *openapi3.Paths, segments []string) *openapi3.Operation {
+	return doc.Value(segments[1]).GetOperation(strings.ToUpper(segments[2]))
+}
+
+func (d *differ) comparePaths(oldPaths *openapi3.Paths, newPaths *openapi3.Paths) {
+	oldOperations, oldKeys := operationKeys(oldPaths)
+	newOperations, newKeys := operationKeys(newPaths)
+	for _, key := range oldKeys {
+		oldSegments := oldOperations[key]
+		newSegments, ok := newOperations[key]
+		if !ok {
+			d.add(ChangeRemovedOperation, true, oldSegments, "operation %s %s is removed",
+				strings.ToUpper(oldSegments[2]), oldSegments[1])
+
+			continue
+		}
+		d.compareOperation(getOperation(oldPaths, oldSegments), getOperation(newPaths, newSegments),
+			oldSegments, newSegments)
+	}
+	for _, key := range newKeys {
+		if _, ok := oldOperations[key]; !ok {
+			segments := newOperations[key]
+			d.add(ChangeAddedOperation, false, segments, "operation %s %s is added",
+				strings.ToUpper(segments[2]), segments[1])
+		}
+	}
+}
+
+func (d *differ) compareOperation(oldOperation *openapi3.Operation, newOperation *openapi3.Operation,
+	oldSegments []string, newSegments []string,
+) {
+	d.compareParameters(oldOperation.Parameters, newOperation.Parameters, oldSegments, newSegments)
+	d.compareRequestBody(oldOperation.RequestBody, newOperation.RequestBody, oldSegments, newSegments)
+
+	oldResponses := oldOperation.Responses.Map()
+	newResponses := newOperation.Responses.Map()
+	for _, code := range sortedKeys(oldResponses) {
+		if _, ok := newResponses[code]; !ok {
+			d.add(ChangeRemovedResponse, true, child(oldSegments, "responses", code), "response %s is removed", code)
+		}
+	}
+	for _, code := range sortedKeys(newResponses) {
+		oldResponse, ok := oldResponses[code]
+		segments := child(newSegments, "responses", code)
+		if !ok {
+			d.add(ChangeAddedResponse, false, segments, "response %s is added", code)
+
+			continue
+		}
+		if oldResponse.Value == nil || newResponses[code].Value == nil {
+			continue
+		}
+		d.compareContent(oldResponse.Value.Content, newResponses[code].Value.Content,
+			child(oldSegments, "responses", code), segments, inResponse)
+	}
+}
+
+func (d *differ) compareParameters(oldParams openapi3.Parameters, newParams openapi3.Parameters,
+	oldSegments []string, newSegments []string,
+) {
+	type indexed struct {
+		index int
+		param *openapi3.Parameter
+	}
+	params := func(list openapi3.Parameters) (map[string]indexed, []string) {
+		result := make(map[string]indexed, len(list))
+		keys := make([]string, 0, len(list))
+		for i, param := range list {
+			if param == nil || param.Value == nil {
+				continue
+			}
+			key := param.Value.In + " " + param.Value.Name
+			result[key] = indexed{index: i, param: param.Value}
+			keys = append(keys, key)
+		}
+
+		return result, keys
+	}
+	oldByKey, oldKeys := params(oldParams)
+	newByKey, newKeys := params(newParams)
+
+	for _, key := range oldKeys {
+		old := oldByKey[key]
+		if _, ok := newByKey[key]; !ok && old.param.In != openapi3.ParameterInPath {
+			d.add(ChangeRemovedParameter, false, child(oldSegments, "parameters", fmt.Sprint(old.index)),
+				"%s parameter %s is removed", old.param.In, old.param.Name)
+		}
+	}
+	for _, key := range newKeys {
+		param := newByKey[key]
+		segments := child(newSegments, "parameters", fmt.Sprint(param.index))
+		old, ok := oldByKey[key]
+		switch {
+		case !ok && param.param.In == openapi3.ParameterInPath:
+			// the path parameters are renamed with the path
+			continue
+		case !ok && param.param.Required:
+			d.add(ChangeNewRequiredParameter, true, segments, "required %s parameter %s is added",
+				param.param.In, param.param.Name)
+
+			continue
+		case !ok:
+			d.add(ChangeAddedParameter, false, segments, "%s parameter %s is added", param.param.In, param.param.Name)
+
+			continue
+		case param.param.Required && !old.param.Required:
+			d.add(ChangeNewRequiredParameter, true, segments, "%s parameter %s becomes required",
+				param.param.In, param.param.Name)
+		}
+		d.compareSchema(old.param.Schema, param.param.Schema, child(segments, "schema"), inRequest)
+	}
+}
+
+func (d *differ) compareRequestBody(oldBody *openapi3.RequestBodyRef, newBody *openapi3.RequestBodyRef,
+	oldSegments []string, newSegments []string,
+) {
+	oldSegments = child(oldSegments, "requestBody")
+	newSegments = child(newSegments, "requestBody")
+	hasOld := oldBody != nil && oldBody.Value != nil
+	if newBody == nil || newBody.Value == nil {
+		if hasOld {
+			// the servers ignore the bodies the clients keep sending
+			d.add(ChangeRemovedBody, true, oldSegments, "request body is removed")
+		}
+
+		return
+	}
+	if newBody.Value.Required && (!hasOld || !oldBody.Value.Required) {
+		d.add(ChangeNewRequiredBody, true, newSegments, "request body becomes required")
+	}
+	if !hasOld {
+		return
+	}
+	d.compareContent(oldBody.Value.Content, newBody.Value.Content, oldSegments, newSegments, inRequest)
+}
+
+func (d *differ) compareContent(oldContent openapi3.Content, newContent openapi3.Content,
+	oldSegments []string, newSegments []string, dir direction,
+) {
+	oldMediaType := oldContent.Get(applicationJSONCT)
+	newMediaType := newContent.Get(applicationJSONCT)
+	if oldMediaType == nil {
+		return
+	}
+	if newMediaType == nil {
+		d.add(ChangeRemovedContent, true, child(oldSegments, "content", applicationJSONCT),
+			"%s content is removed", applicationJSONCT)
+
+		return
+	}
+	d.compareSchema(oldMediaType.Schema, newMediaType.Schema,
+		child(newSegments, "content", applicationJSONCT, "schema"), dir)
+}
+
+func (d *differ) compareComponents(oldComponents *openapi3.Components, newComponents *openapi3.Components) {
+	var oldSchemas, newSchemas openapi3.Schemas
+	if oldComponents != nil {
+		oldSchemas = oldComponents.Schemas
+	}
+	if newComponents != nil {
+		newSchemas = newComponents.Schemas
+	}
+	for _, name := range sortedKeys(oldSchemas) {
+		if _, ok := newSchemas[name]; !ok {
+			d.add(ChangeRemovedSchema, true, []string{"components", "schemas", name}, "schema %s is removed", name)
+		}
+	}
+	for _, name := range sortedKeys(newSchemas) {
+		segments := []string{"components", "schemas", name}
+		oldSchema, ok := oldSchemas[name]
+		if !ok {
+			d.add(ChangeAddedSchema, false, segments, "schema %s is added", name)
+
+			continue
+		}
+		dir := d.usage[name]
+		if dir == 0 {
+			dir = inRequest | inResponse
+		}
+		newSchema := newSchemas[name]
+		if oldSchema.Ref != "" || newSchema.Ref != "" {
+			d.compareSchema(oldSchema, newSchema, segments, dir)
+
+			continue
+		}
+		d.compareSchemaValue(oldSchema.Value, newSchema.Value, segments, dir)
+	}
+}
+
+// compareSchema compares inline schemas, the referenced schemas are compared
+// with the components they are defined in.
+func (d *differ) compareSchema(oldSchema *openapi3.SchemaRef, newSchema *openapi3.SchemaRef, segments []string,
+	dir direction,
+) {
+	if oldSchema == nil || newSchema == nil || oldSchema.Value == nil || newSchema.Value == nil {
+		return
+	}
+	if oldSchema.Ref != "" || newSchema.Ref != "" {
+		if oldSchema.Ref != newSchema.Ref {
+			d.add(ChangeType, true, segments, "type changes from %s to %s",
+				schemaDisplayName(oldSchema), schemaDisplayName(newSchema))
+		}
+
+		return
+	}
+	d.compareSchemaValue(oldSchema.Value, newSchema.Value, segments, dir)
+}
+
+func (d *differ) compareSchemaValue(oldSchema *openapi3.Schema, newSchema *openapi3.Schema, segments []string,
+	dir direction,
+) {
+	if oldSchema == nil || newSchema == nil {
+		return
+	}
+	oldType, newType := typeName(oldSchema), typeName(newSchema)
+	if oldSchema.Format != "" {
+		oldType += " (" + oldSchema.Format + ")"
+	}
+	if newSchema.Format != "" {
+		newType += " (" + newSchema.Format + ")"
+	}
+	if oldType != newType {
+		d.add(ChangeType, true, segments, "type changes from %s to %s", oldType, newType)
+
+		return
+	}
+
+	removed, added := enumChanges(oldSchema.Enum, newSchema.Enum)
+	if len(removed) > 0 {
+		d.add(ChangeNarrowedEnum, dir&inRequest != 0, child(segments, "enum"),
+			"enum values %s are removed", strings.Join(removed, ", "))
+	}
+	if len(added) > 0 {
+		d.add(ChangeWidenedEnum, dir&inResponse != 0, child(segments, "enum"),
+			"enum values %s are added", strings.Join(added, ", "))
+	}
+
+	for _, name := range sortedKeys(oldSchema.Properties) {
+		if _, ok := newSchema.Properties[name]; !ok {
+			d.add(ChangeRemovedField, dir&inResponse != 0, child(segments, "properties", name),
+				"field %s is removed", name)
+		}
+	}
+	for _, name := range sortedKeys(newSchema.Properties) {
+		propertySegments := child(segments, "properties", name)
+		required := slices.Contains(newSchema.Required, name)
+		wasRequired := slices.Contains(oldSchema.Required, name)
+		oldProperty, ok := oldSchema.Properties[name]
+		switch {
+		case !ok && required:
+			d.add(ChangeNewlyRequiredField, dir&inRequest != 0, propertySegments, "required field %s is added", name)
+		case !ok:
+			d.add(ChangeAddedField, false, propertySegments, "field %s is added", name)
+		case required && !wasRequired:
+			d.add(ChangeNewlyRequiredField, dir&inRequest != 0, propertySegments, "field %s becomes required", name)
+		case !required && wasRequired:
+			d.add(ChangeOptionalField, dir&inResponse != 0, propertySegments, "field %s becomes optional", name)
+		}
+		if ok {
+			d.compareSchema(oldProperty, newSchema.Properties[name], propertySegments, dir)
+		}
+	}
+	d.compareSchema(oldSchema.Items, newSchema.Items, child(segments, "items"), dir)
+	d.compareSchema(oldSchema.AdditionalProperties.Schema, newSchema.AdditionalProperties.Schema,
+		child(segments, "additionalProperties"), dir)
+}
+
+func enumChanges(oldEnum []any, newEnum []any) ([]string, []string) {
+	if len(oldEnum) == 0 || len(newEnum) == 0 {
+		// a schema without an enum takes any value
+		if len(oldEnum) == 0 && len(newEnum) > 0 {
+			return []string{"outside of the enum"}, nil
+		}
+
+		return nil, nil
+	}
+	values := func(enum []any) []string {
+		result := make([]string, 0, len(enum))
+		for _, value := range enum {
+			result = append(result, fmt.Sprint(value))
+		}
+
+		return result
+	}
+	oldValues, newValues := values(oldEnum), values(newEnum)
+	var removed, added []string
+	for _, value := range oldValues {
+		if !slices.Contains(newValues, value) {
+			removed = append(removed, value)
+		}
+	}
+	for _, value := range newValues {
+		if !slices.Contains(oldValues, value) {
+			added = append(added, value)
+		}
+	}
+
+	return removed, added
+}
+
+func schemaDisplayName(schema *openapi3.SchemaRef) string {
+	if schema.Ref == "" {
+		return "an inline " + typeName(schema.Value)
+	}
+	if name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/"); ok {
+		return name
+	}
+
+	return schema.Ref
+}
+
+// schemaUsage returns the directions the component schemas are used in by the
+// operations.
+func schemaUsage(doc *openapi3.T) map[string]direction {
+	usage := make(map[string]direction)
+	var visit func(schema *openapi3.SchemaRef, dir direction)
+	visit = func(schema *openapi3.SchemaRef, dir direction) {
+		if schema == nil || schema.Value == nil {
+			return
+		}
+		if name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/"); ok {
+			if usage[name]&dir == dir {
+				return
+			}
+			usage[name] |= dir
+		}
+		value := schema.Value
+		for _, property := range value.Properties {
+			visit(property, dir)
+		}
+		visit(value.Items, dir)
+		visit(value.AdditionalProperties.Schema, dir)
+		for _, parts := range []openapi3.SchemaRefs{value.AllOf, value.OneOf, value.AnyOf} {
+			for _, part := range parts {
+				visit(part, dir)
+			}
+		}
+		visit(value.Not, dir)
+	}
+
+	if doc.Paths == nil {
+		return usage
+	}
+	for _, pathItem := range doc.Paths.Map() {
+		for _, operation := range pathItem.Operations() {
+			for _, param := range operation.Parameters {
+				if param.Value != nil {
+					visit(param.Value.Schema, inRequest)
+				}
+			}
+			if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+				for _, mediaType := range operation.RequestBody.Value.Content {
+					visit(mediaType.Schema, inRequest)
+				}
+			}
+			for _, response := range operation.Responses.Map() {
+				if response.Value == nil {
+					continue
+				}
+				for _, mediaType := range response.Value.Content {
+					visit(mediaType.Schema, inResponse)
+				}
+				for _, header := range response.Value.Headers {
+					if header.Value != nil {
+						visit(header.Value.Schema, inResponse)
+					}
+				}
+			}
+		}
+	}
+
+	return usage
+}
+
+// diffSymbols compares the exported Go symbols of two versions of the
+// generated files.
+func diffSymbols(oldFiles map[string][]byte, newFiles map[string][]byte) ([]SymbolChange, error) {
+	oldSymbols, err := goSymbols(oldFiles)
+	if err != nil {
+		return nil, err
+	}
+	newSymbols, err := goSymbols(newFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []SymbolChange
+	for _, symbol := range sortedKeys(oldSymbols) {
+		newSymbol, ok := newSymbols[symbol]
+		switch {
+		case !ok:
+			result = append(result, SymbolChange{Symbol: symbol, Change: "removed", Breaking: true})
+		case newSymbol != oldSymbols[symbol]:
+			result = append(result, SymbolChange{Symbol: symbol, Change: "changed"})
+		}
+	}
+	for _, symbol := range sortedKeys(newSymbols) {
+		if _, ok := oldSymbols[symbol]; !ok {
+			result = append(result, SymbolChange{Symbol: symbol, Change: "added"})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Symbol < result[j].Symbol
+	})
+
+	return result, nil
+}
+
+// goSymbols returns the declarations of the exported symbols of the files,
+// keyed by the symbol qualified with the package name. The fields and the
+// methods are symbols of their own, the bodies of the functions are left out.
+func goSymbols(files map[string][]byte) (map[string]string, error) {
+	symbols := make(map[string]string)
+	for _, fileName := range sortedKeys(files) {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, fileName, files[fileName], parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		source := func(node any) string {
+			var buf bytes.Buffer
+			_ = printer.Fprint(&buf, fset, node)
+
+			return buf.String()
+		}
+		prefix := file.Name.Name + "."
+
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				name := decl.Name.Name
+				if decl.Recv != nil && len(decl.Recv.List) > 0 {
+					name = receiverName(decl.Recv.List[0].Type) + "." + name
+				}
+				if isExportedSymbol(name) {
+					symbols[prefix+name] = signature(decl.Type, source)
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					addSpecSymbols(symbols, prefix, spec, source)
+				}
+			}
+		}
+	}
+
+	return symbols, nil
+}
+
+func addSpecSymbols(symbols map[string]string, prefix string, spec ast.Spec, source func(any) string) {
+	switch spec := spec.(type) {
+	case *ast.ValueSpec:
+		for _, name := range spec.Names {
+			if name.IsExported() {
+				symbols[prefix+name.Name] = source(spec)
+			}
+		}
+	case *ast.TypeSpec:
+		if !spec.Name.IsExported() {
+			return
+		}
+		name := prefix + spec.Name.Name
+		var members *ast.FieldList
+		switch typ := spec.Type.(type) {
+		case *ast.StructType:
+			symbols[name] = "struct"
+			members = typ.Fields
+		case *ast.InterfaceType:
+			symbols[name] = "interface"
+			members = typ.Methods
+		default:
+			symbols[name] = source(spec.Type)
+			if spec.Assign.IsValid() {
+				symbols[name] = "= " + symbols[name]
+			}
+		}
+		if members == nil {
+			return
+		}
+		for _, member := range members.List {
+			text := source(member.Type)
+			if fn, ok := member.Type.(*ast.FuncType); ok {
+				text = signature(fn, source)
+			}
+			if member.Tag != nil {
+				text += " " + member.Tag.Value
+			}
+			if len(member.Names) == 0 {
+				// embedded fields are named by their type
+				symbols[name+"."+source(member.Type)] = text
+			}
+			for _, memberName := range member.Names {
+				if memberName.IsExported() {
+					symbols[name+"."+memberName.Name] = text
+				}
+			}
+		}
+	}
+}
+
+// signature is the function type without the names of the parameters, which
+// do not change the API.
+func signature(fn *ast.FuncType, source func(any) string) string {
+	types := func(fields *ast.FieldList) string {
+		if fields == nil {
+			return "()"
+		}
+		var result []string
+		for _, field := range fields.List {
+			for range max(len(field.Names), 1) {
+				result = append(result, source(field.Type))
+			}
+		}
+
+		return "(" + strings.Join(result, ", ") + ")"
+	}
+
+	return types(fn.Params) + " " + types(fn.Results)
+}
+
+func receiverName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(expr.X)
+	case *ast.IndexExpr:
+		return receiverName(expr.X)
+	case *ast.IndexListExpr:
+		return receiverName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	}
+
+	return ""
+}
+
+func isExportedSymbol(name string) bool {
+	for _, part := range strings.Split(name, ".") {
+		if !ast.IsExported(part) {
+			return false
+		}
+	}
+
+	return true
+}
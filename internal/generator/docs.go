@@ -0,0 +1,227 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const deprecatedDoc = "Deprecated: marked as deprecated in the OpenAPI specification."
+
+// Doc builds a doc comment from text lines, empty lines separate paragraphs.
+func Doc(lines []string) *ast.CommentGroup {
+	if len(lines) == 0 {
+		return nil
+	}
+	comments := make([]*ast.Comment, 0, len(lines))
+	for _, line := range lines {
+		text := "//"
+		if line != "" {
+			text += " " + line
+		}
+		comments = append(comments, &ast.Comment{Text: text})
+	}
+
+	return &ast.CommentGroup{List: comments}
+}
+
+func textLines(text string) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+
+	return lines
+}
+
+func appendParagraph(lines []string, paragraph ...string) []string {
+	if len(paragraph) == 0 {
+		return lines
+	}
+	if len(lines) > 0 {
+		lines = append(lines, "")
+	}
+
+	return append(lines, paragraph...)
+}
+
+// DocLines joins the description, the constraints, the example and the
+// deprecation notice of an OpenAPI object into doc comment lines.
+func DocLines(description string, schema *openapi3.SchemaRef, deprecated bool) []string {
+	lines := textLines(description)
+	if schema != nil && schema.Value != nil {
+		if description == "" {
+			lines = textLines(schema.Value.Description)
+		}
+		var details []string
+		if constraints := schemaConstraints(schema.Value); len(constraints) > 0 {
+			details = append(details, "Constraints: "+strings.Join(constraints, ", ")+".")
+		}
+		if schema.Value.Default != nil {
+			details = append(details, "Default: "+docValue(schema.Value.Default)+".")
+		}
+		if schema.Value.Example != nil {
+			details = append(details, "Example: "+docValue(schema.Value.Example)+".")
+		}
+		if schema.Value.ReadOnly {
+			details = append(details, "Read-only: rejected in requests.")
+		}
+		if schema.Value.WriteOnly {
+			details = append(details, "Write-only: not expected in responses.")
+		}
+		lines = appendParagraph(lines, details...)
+		deprecated = deprecated || schema.Value.Deprecated
+	}
+	if deprecated {
+		lines = appendParagraph(lines, deprecatedDoc)
+	}
+
+	return lines
+}
+
+func schemaConstraints(schema *openapi3.Schema) []string {
+	var constraints []string
+	if schema.Format != "" {
+		constraints = append(constraints, "format "+schema.Format)
+	}
+	if schema.Min != nil {
+		name := "minimum "
+		if schema.ExclusiveMin {
+			name = "exclusive minimum "
+		}
+		constraints = append(constraints, name+fmt.Sprint(*schema.Min))
+	}
+	if schema.Max != nil {
+		name := "maximum "
+		if schema.ExclusiveMax {
+			name = "exclusive maximum "
+		}
+		constraints = append(constraints, name+fmt.Sprint(*schema.Max))
+	}
+	if schema.MultipleOf != nil {
+		constraints = append(constraints, "multiple of "+fmt.Sprint(*schema.MultipleOf))
+	}
+	if schema.MinLength > 0 {
+		constraints = append(constraints, "min length "+strconv.FormatUint(schema.MinLength, 10))
+	}
+	if schema.MaxLength != nil {
+		constraints = append(constraints, "max length "+strconv.FormatUint(*schema.MaxLength, 10))
+	}
+	if schema.Pattern != "" {
+		constraints = append(constraints, "pattern "+schema.Pattern)
+	}
+	if schema.MinItems > 0 {
+		constraints = append(constraints, "min items "+strconv.FormatUint(schema.MinItems, 10))
+	}
+	if schema.MaxItems != nil {
+		constraints = append(constraints, "max items "+strconv.FormatUint(*schema.MaxItems, 10))
+	}
+	if schema.UniqueItems {
+		constraints = append(constraints, "unique items")
+	}
+
+	return constraints
+}
+
+func docValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
+
+// OperationDocLines returns the summary, the description and the deprecation
+// notice of an operation.
+func OperationDocLines(operation *openapi3.Operation) []string {
+	lines := textLines(operation.Summary)
+	lines = appendParagraph(lines, textLines(operation.Description)...)
+	if operation.Deprecated {
+		lines = appendParagraph(lines, deprecatedDoc)
+	}
+
+	return lines
+}
+
+// SetTypeDoc sets the doc comment of the generated model type.
+func (g *Generator) SetTypeDoc(modelName string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	for i := len(g.SchemasFile.decls) - 1; i >= 0; i-- {
+		decl, ok := g.SchemasFile.decls[i].(*ast.GenDecl)
+		if !ok || decl.Tok != token.TYPE || len(decl.Specs) != 1 {
+			continue
+		}
+		spec, ok := decl.Specs[0].(*ast.TypeSpec)
+		if ok && spec.Name.Name == modelName {
+			decl.Doc = Doc(lines)
+
+			return
+		}
+	}
+}
+
+// PositionDocComments places the doc comments right before the nodes they
+// document. go/printer needs positions to print comments, so the comments and
+// the first tokens of documented nodes get one line each in a fake file.
+func PositionDocComments(file *ast.File) *token.FileSet {
+	fset := token.NewFileSet()
+	var docs []*ast.CommentGroup
+	var starts []*token.Pos
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.GenDecl:
+			if node.Doc != nil {
+				docs = append(docs, node.Doc)
+				starts = append(starts, &node.TokPos)
+			}
+		case *ast.FuncDecl:
+			if node.Doc != nil {
+				docs = append(docs, node.Doc)
+				starts = append(starts, &node.Type.Func)
+			}
+		case *ast.Field:
+			if node.Doc != nil && len(node.Names) > 0 {
+				docs = append(docs, node.Doc)
+				starts = append(starts, &node.Names[0].NamePos)
+			}
+		}
+
+		return true
+	})
+	if len(docs) == 0 {
+		return fset
+	}
+
+	size := len(docs)
+	for _, doc := range docs {
+		size += len(doc.List)
+	}
+	tokenFile := fset.AddFile("", -1, size+1)
+	tokenFile.SetLinesForContent([]byte(strings.Repeat("\n", size+1)))
+	offset := 0
+	next := func() token.Pos {
+		offset++
+
+		return tokenFile.Pos(offset)
+	}
+	for i, doc := range docs {
+		for _, comment := range doc.List {
+			comment.Slash = next()
+		}
+		*starts[i] = next()
+	}
+
+	return fset
+}
@@ -0,0 +1,198 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+const enumVarNamesExtension = "x-enum-varnames"
+
+type enumConst struct {
+	Name  string
+	Value ast.Expr
+}
+
+func isEnumSchema(schema *openapi3.SchemaRef) bool {
+	if schema == nil || schema.Value == nil || len(schema.Value.Enum) == 0 {
+		return false
+	}
+	types := schema.Value.Type
+
+	return types != nil && (types.Includes(openapi3.TypeString) ||
+		types.Includes(openapi3.TypeInteger) ||
+		types.Includes(openapi3.TypeNumber))
+}
+
+func enumValueIdentifier(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' {
+			return r
+		}
+
+		return '_'
+	}, value)
+	name := FormatGoLikeIdentifier(value)
+	if name == "" {
+		return "Empty"
+	}
+
+	return name
+}
+
+func getEnumVarNames(schema *openapi3.SchemaRef) ([]string, error) {
+	raw, ok := schema.Value.Extensions[enumVarNamesExtension]
+	if !ok {
+		return nil, nil
+	}
+	items, ok := raw.([]any)
+	if !ok {
+		return nil, errors.New(enumVarNamesExtension + " must be an array of strings")
+	}
+	if len(items) != len(schema.Value.Enum) {
+		return nil, errors.Errorf("%s has %d items, enum has %d values",
+			enumVarNamesExtension, len(items), len(schema.Value.Enum))
+	}
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		name, ok := item.(string)
+		if !ok {
+			return nil, errors.New(enumVarNamesExtension + " must be an array of strings")
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+func (g *Generator) getEnumConsts(typeName string, schema *openapi3.SchemaRef) ([]enumConst, error) {
+	varNames, err := getEnumVarNames(schema)
+	if err != nil {
+		return nil, err
+	}
+	isString := !schema.Value.Type.Permits(openapi3.TypeInteger) && !schema.Value.Type.Permits(openapi3.TypeNumber)
+
+	consts := make([]enumConst, 0, len(schema.Value.Enum))
+	seen := make(map[string]bool, len(schema.Value.Enum))
+	for i, enumValue := range schema.Value.Enum {
+		if enumValue == nil {
+			// null is allowed by nullable enums but has no Go constant
+			continue
+		}
+		var value ast.Expr
+		var valueName string
+		if isString {
+			strValue := enumValueString(enumValue)
+			value = Str(strValue)
+			valueName = strValue
+		} else {
+			strValue := enumValueString(enumValue)
+			kind := token.INT
+			if strings.Contains(strValue, ".") {
+				kind = token.FLOAT
+			}
+			value = &ast.BasicLit{Kind: kind, Value: strValue}
+			valueName = strings.NewReplacer("-", "Minus", ".", "Dot").Replace(strValue)
+		}
+		name := typeName + enumValueIdentifier(valueName)
+		if varNames != nil {
+			name = typeName + g.GoName(varNames[i])
+		}
+		if seen[name] {
+			return nil, errors.Errorf("enum constant %s of type %s is not unique, use %s to name it explicitly",
+				name, typeName, enumVarNamesExtension)
+		}
+		seen[name] = true
+		consts = append(consts, enumConst{Name: name, Value: value})
+	}
+
+	return consts, nil
+}
+
+func (g *Generator) ProcessEnumSchema(modelName string, schema *openapi3.SchemaRef) error {
+	const op = "generator.ProcessEnumSchema"
+	typeName, err := g.GetDerefFieldTypeFromSchema(modelName, "", schema)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	consts, err := g.getEnumConsts(modelName, schema)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	g.AddTypeAlias(modelName, typeName)
+	g.AddEnumConsts(modelName, consts)
+
+	return nil
+}
+
+func (g *Generator) AddEnumConsts(typeName string, consts []enumConst) {
+	specs := make([]ast.Spec, 0, len(consts))
+	names := make([]ast.Expr, 0, len(consts))
+	for _, c := range consts {
+		specs = append(specs, &ast.ValueSpec{
+			Names:  []*ast.Ident{I(c.Name)},
+			Type:   I(typeName),
+			Values: []ast.Expr{c.Value},
+		})
+		names = append(names, I(c.Name))
+	}
+	if len(specs) > 0 {
+		g.SchemasFile.decls = append(g.SchemasFile.decls, &ast.GenDecl{
+			Tok:   token.CONST,
+			Specs: specs,
+		})
+	}
+
+	isValidBody := []ast.Stmt{Ret1(I("false"))}
+	if len(names) > 0 {
+		isValidBody = []ast.Stmt{
+			&ast.SwitchStmt{
+				Tag: I("v"),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.CaseClause{
+							List: names,
+							Body: []ast.Stmt{Ret1(I("true"))},
+						},
+					},
+				},
+			},
+			Ret1(I("false")),
+		}
+	}
+	g.SchemasFile.decls = append(g.SchemasFile.decls, Func(
+		"IsValid",
+		Field("v", I(typeName), ""),
+		nil,
+		FieldA(Field("", I("bool"), "")),
+		isValidBody,
+	))
+
+	g.SchemasFile.decls = append(g.SchemasFile.decls, Func(
+		"All"+typeName+"Values",
+		nil,
+		nil,
+		FieldA(Field("", &ast.ArrayType{Elt: I(typeName)}, "")),
+		[]ast.Stmt{Ret1(&ast.CompositeLit{
+			Type: &ast.ArrayType{Elt: I(typeName)},
+			Elts: names,
+		})},
+	))
+}
+
+func enumValueString(value any) string {
+	if strValue, ok := value.(string); ok {
+		return strValue
+	}
+	if floatValue, ok := value.(float64); ok {
+		return strconv.FormatFloat(floatValue, 'f', -1, 64)
+	}
+
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,311 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/go-faster/errors"
+)
+
+const (
+	dateTypeName = "Date"
+	urlTypeName  = "URL"
+	// durationPattern matches ISO 8601 durations like P1Y2M3DT4H5M6.5S or P2W
+	durationPattern = `^P(\d+W|(\d+Y)?(\d+M)?(\d+D)?(T(\d+H)?(\d+M)?(\d+(\.\d+)?S)?)?)$`
+)
+
+func stringFormatIsPlain(format string) bool {
+	switch format {
+	case "date-time", "date", "decimal", "uuid", "uri", "byte":
+		return false
+	}
+
+	return true
+}
+
+func (g *Generator) GetStringType(format string) string {
+	switch format {
+	case "date-time":
+		g.AddSchemasImport("time")
+
+		return "time.Time"
+	case "date":
+		g.AddDateTypeIfNeeded()
+
+		return dateTypeName
+	case "decimal":
+		g.AddSchemasImport("github.com/shopspring/decimal")
+
+		return "decimal.Decimal"
+	case "uuid":
+		g.AddSchemasImport("github.com/google/uuid")
+
+		return "uuid.UUID"
+	case "uri":
+		g.AddURLTypeIfNeeded()
+
+		return urlTypeName
+	case "byte":
+		// encoding/json marshals []byte as a base64 string
+		return "[]byte"
+	}
+
+	return "string"
+}
+
+// GetStringParseExpr returns a call converting the string variable to the Go
+// type of the format, returning the value and an error, or nil for plain strings.
+func (g *Generator) GetStringParseExpr(format string, varName string) ast.Expr {
+	switch format {
+	case "date-time":
+		g.AddHandlersImport("time")
+
+		return &ast.CallExpr{Fun: Sel(I("time"), "Parse"), Args: []ast.Expr{Sel(I("time"), "RFC3339"), I(varName)}}
+	case "date":
+		return &ast.CallExpr{Fun: Sel(I(g.GetCurrentModelsPackage()), "Parse"+dateTypeName), Args: []ast.Expr{I(varName)}}
+	case "decimal":
+		g.AddHandlersImport("github.com/shopspring/decimal")
+
+		return &ast.CallExpr{Fun: Sel(I("decimal"), "NewFromString"), Args: []ast.Expr{I(varName)}}
+	case "uuid":
+		g.AddHandlersImport("github.com/google/uuid")
+
+		return &ast.CallExpr{Fun: Sel(I("uuid"), "Parse"), Args: []ast.Expr{I(varName)}}
+	case "uri":
+		return &ast.CallExpr{Fun: Sel(I(g.GetCurrentModelsPackage()), "Parse"+urlTypeName), Args: []ast.Expr{I(varName)}}
+	case "byte":
+		g.AddHandlersImport("encoding/base64")
+
+		return &ast.CallExpr{Fun: Sel(Sel(I("base64"), "StdEncoding"), "DecodeString"), Args: []ast.Expr{I(varName)}}
+	}
+
+	return nil
+}
+
+func GetStringFormatValidators(format string) []string {
+	switch format {
+	case "ip", "ipv4", "ipv6", "email":
+		return []string{format}
+	case "hostname":
+		return []string{"hostname_rfc1123"}
+	case "duration":
+		return []string{patternValidatorTag + "=" + EscapeValidatorParam(durationPattern)}
+	}
+
+	return nil
+}
+
+func textMarshalerFuncs(typeName string, recv string) []ast.Decl {
+	marshal := Func("MarshalText", Field(recv, I(typeName), ""), nil,
+		[]*ast.Field{Field("", &ast.ArrayType{Elt: I("byte")}, ""), Field("", I("error"), "")},
+		[]ast.Stmt{Ret2(
+			&ast.CallExpr{
+				Fun:  &ast.ArrayType{Elt: I("byte")},
+				Args: []ast.Expr{&ast.CallExpr{Fun: Sel(I(recv), "String")}},
+			},
+			I("nil"),
+		)},
+	)
+	unmarshal := Func("UnmarshalText", Field(recv, Star(I(typeName)), ""),
+		FieldA(Field("data", &ast.ArrayType{Elt: I("byte")}, "")),
+		FieldA(Field("", I("error"), "")),
+		[]ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("parsed"), I("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{
+					Fun:  I("Parse" + typeName),
+					Args: []ast.Expr{&ast.CallExpr{Fun: I("string"), Args: []ast.Expr{I("data")}}},
+				}},
+			},
+			&ast.IfStmt{
+				Cond: Ne(I("err"), I("nil")),
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(I("err"))}},
+			},
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{Star(I(recv))},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{I("parsed")},
+			},
+			Ret1(I("nil")),
+		},
+	)
+
+	return []ast.Decl{marshal, unmarshal}
+}
+
+// AddDateTypeIfNeeded adds a civil date type for the "date" format, so dates do
+// not carry a time and a location around.
+func (g *Generator) AddDateTypeIfNeeded() {
+	if g.SchemasFile.hasDateType {
+		return
+	}
+	g.SchemasFile.hasDateType = true
+	g.AddSchemasImport("time")
+
+	g.SchemasFile.decls = append(g.SchemasFile.decls, &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: I(dateTypeName),
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						List: []*ast.Field{
+							Field("Year", I("int"), ""),
+							Field("Month", Sel(I("time"), "Month"), ""),
+							Field("Day", I("int"), ""),
+						},
+					},
+				},
+			},
+		},
+	})
+
+	parse := Func("Parse"+dateTypeName, nil,
+		FieldA(Field("value", I("string"), "")),
+		[]*ast.Field{Field("", I(dateTypeName), ""), Field("", I("error"), "")},
+		[]ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("parsed"), I("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{
+					Fun:  Sel(I("time"), "Parse"),
+					Args: []ast.Expr{Sel(I("time"), "DateOnly"), I("value")},
+				}},
+			},
+			&ast.IfStmt{
+				Cond: Ne(I("err"), I("nil")),
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(&ast.CompositeLit{Type: I(dateTypeName)}, I("err"))}},
+			},
+			Ret2(&ast.CompositeLit{
+				Type: I(dateTypeName),
+				Elts: []ast.Expr{
+					&ast.KeyValueExpr{Key: I("Year"), Value: &ast.CallExpr{Fun: Sel(I("parsed"), "Year")}},
+					&ast.KeyValueExpr{Key: I("Month"), Value: &ast.CallExpr{Fun: Sel(I("parsed"), "Month")}},
+					&ast.KeyValueExpr{Key: I("Day"), Value: &ast.CallExpr{Fun: Sel(I("parsed"), "Day")}},
+				},
+			}, I("nil")),
+		},
+	)
+
+	toTime := Func("Time", Field("d", I(dateTypeName), ""), nil,
+		FieldA(Field("", Sel(I("time"), "Time"), "")),
+		[]ast.Stmt{Ret1(&ast.CallExpr{
+			Fun: Sel(I("time"), "Date"),
+			Args: []ast.Expr{
+				Sel(I("d"), "Year"), Sel(I("d"), "Month"), Sel(I("d"), "Day"),
+				&ast.BasicLit{Kind: token.INT, Value: "0"},
+				&ast.BasicLit{Kind: token.INT, Value: "0"},
+				&ast.BasicLit{Kind: token.INT, Value: "0"},
+				&ast.BasicLit{Kind: token.INT, Value: "0"},
+				Sel(I("time"), "UTC"),
+			},
+		})},
+	)
+
+	toString := Func("String", Field("d", I(dateTypeName), ""), nil,
+		FieldA(Field("", I("string"), "")),
+		[]ast.Stmt{Ret1(&ast.CallExpr{
+			Fun:  Sel(&ast.CallExpr{Fun: Sel(I("d"), "Time")}, "Format"),
+			Args: []ast.Expr{Sel(I("time"), "DateOnly")},
+		})},
+	)
+
+	g.SchemasFile.decls = append(g.SchemasFile.decls, parse, toTime, toString)
+	g.SchemasFile.decls = append(g.SchemasFile.decls, textMarshalerFuncs(dateTypeName, "d")...)
+}
+
+// AddURLTypeIfNeeded adds a url.URL wrapper for the "uri" format that is
+// (un)marshaled as a string and accepts absolute URIs only.
+func (g *Generator) AddURLTypeIfNeeded() {
+	if g.SchemasFile.hasURLType {
+		return
+	}
+	g.SchemasFile.hasURLType = true
+	g.AddSchemasImport("net/url")
+	g.AddSchemasImport("github.com/go-faster/errors")
+
+	g.SchemasFile.decls = append(g.SchemasFile.decls, &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: I(urlTypeName),
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						List: []*ast.Field{{Type: Sel(I("url"), "URL")}},
+					},
+				},
+			},
+		},
+	})
+
+	parse := Func("Parse"+urlTypeName, nil,
+		FieldA(Field("value", I("string"), "")),
+		[]*ast.Field{Field("", I(urlTypeName), ""), Field("", I("error"), "")},
+		[]ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("parsed"), I("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{Fun: Sel(I("url"), "Parse"), Args: []ast.Expr{I("value")}}},
+			},
+			&ast.IfStmt{
+				Cond: Ne(I("err"), I("nil")),
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(&ast.CompositeLit{Type: I(urlTypeName)}, I("err"))}},
+			},
+			&ast.IfStmt{
+				Cond: &ast.UnaryExpr{Op: token.NOT, X: &ast.CallExpr{Fun: Sel(I("parsed"), "IsAbs")}},
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(
+					&ast.CompositeLit{Type: I(urlTypeName)},
+					&ast.CallExpr{Fun: Sel(I("errors"), "New"), Args: []ast.Expr{Str("uri must be absolute")}},
+				)}},
+			},
+			Ret2(&ast.CompositeLit{
+				Type: I(urlTypeName),
+				Elts: []ast.Expr{&ast.KeyValueExpr{Key: I("URL"), Value: Star(I("parsed"))}},
+			}, I("nil")),
+		},
+	)
+
+	g.SchemasFile.decls = append(g.SchemasFile.decls, parse)
+	g.SchemasFile.decls = append(g.SchemasFile.decls, textMarshalerFuncs(urlTypeName, "u")...)
+}
+
+// helperTypes returns the helper types added to the models of the current spec
+// file, with what they are added for.
+func (g *Generator) helperTypes() map[string]string {
+	result := make(map[string]string)
+	if g.SchemasFile.hasDateType {
+		result[dateTypeName] = "the date format"
+	}
+	if g.SchemasFile.hasURLType {
+		result[urlTypeName] = "the uri format"
+	}
+	if g.SchemasFile.hasNullableType {
+		result[nullableTypeName] = "nullable fields"
+	}
+
+	return result
+}
+
+// checkHelperTypeNames records a diagnostic for every schema generating a Go
+// type with the name of a helper type of the models.
+func (g *Generator) checkHelperTypeNames() {
+	helpers := g.helperTypes()
+	for _, name := range sortedKeys(helpers) {
+		if _, ok := g.SchemasFile.generatedModels[name]; !ok {
+			continue
+		}
+		var segments []string
+		if g.yaml.Components != nil {
+			for _, schemaName := range sortedKeys(g.yaml.Components.Schemas) {
+				if GetSchemaGoName(schemaName, g.yaml.Components.Schemas[schemaName]) == name {
+					segments = []string{"components", "schemas", schemaName}
+
+					break
+				}
+			}
+		}
+		g.addDiagnostic(errors.Errorf("the Go type %s collides with the helper type generated for %s, rename it with %s",
+			name, helpers[name], goNameExtension), segments...)
+	}
+}
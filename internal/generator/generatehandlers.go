@@ -2,19 +2,29 @@ package generator
 
 import (
 	"sort"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-faster/errors"
+	"github.com/jolfzverb/codegen/plugin"
 )
 
 const applicationJSONCT = "application/json"
 
-func (g *Generator) AddInterface(baseName string) {
+func (g *Generator) AddInterface(baseName string, method string, pathName string, operation *openapi3.Operation) {
 	interfaceName := baseName + "Handler"
 	methodName := "Handle" + baseName
 	requestName := baseName + "Request"
 	responseName := baseName + "Response"
-	g.AddHandlersInterface(interfaceName, methodName, requestName, responseName)
+	methodDoc := OperationDocLines(operation)
+	var interfaceDoc []string
+	if len(methodDoc) > 0 {
+		interfaceDoc = []string{interfaceName + " handles " + strings.ToUpper(method) + " " + pathName + "."}
+		if operation.Deprecated {
+			interfaceDoc = appendParagraph(interfaceDoc, deprecatedDoc)
+		}
+	}
+	g.AddHandlersInterface(interfaceName, methodName, requestName, responseName, interfaceDoc, methodDoc)
 }
 
 func (g *Generator) AddDependencyToHandler(baseName string) {
@@ -45,6 +55,10 @@ func (g *Generator) AddResponseCodeModels(baseName string, code string, response
 		Name:   baseName + "Response" + code,
 		Fields: []SchemaField{},
 	}
+	err := g.claimOperationModel(model.Name)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
 	for _, content := range response.Value.Content {
 		if content.Schema != nil {
 			if content.Schema.Ref == "" {
@@ -56,7 +70,7 @@ func (g *Generator) AddResponseCodeModels(baseName string, code string, response
 			typeName := baseName + "Response" + code + "Body"
 			if content.Schema.Ref != "" {
 				var importPath string
-				typeName, importPath = g.ParseRefTypeName(content.Schema.Ref)
+				typeName, importPath = g.ParseSchemaRefTypeName(content.Schema)
 				if importPath != "" {
 					g.AddSchemasImport(importPath)
 				}
@@ -82,7 +96,7 @@ func (g *Generator) AddResponseCodeModels(baseName string, code string, response
 		})
 	}
 	g.AddSchema(model)
-	err := g.AddCreateResponseModel(baseName, code, response)
+	err = g.AddCreateResponseModel(baseName, code, response)
 	if err != nil {
 		return errors.Wrapf(err, op)
 	}
@@ -124,16 +138,16 @@ func (g *Generator) AddWriteResponseMethod(baseName string, operation *openapi3.
 		response := operation.Responses.Value(code)
 		err = g.AddResponseCodeModels(baseName, code, response)
 		if err != nil {
-			return errors.Wrap(err, op)
+			return withPointer(errors.Wrap(err, op), "responses", code)
 		}
 		err = g.AddWriteResponseCode(baseName, code, response)
 		if err != nil {
-			return errors.Wrap(err, op)
+			return withPointer(errors.Wrap(err, op), "responses", code)
 		}
 		if len(response.Value.Headers) > 0 {
 			err = g.AddWriteHeadersForResponseCode(baseName, code, response)
 			if err != nil {
-				return errors.Wrap(err, op)
+				return withPointer(errors.Wrap(err, op), "responses", code, "headers")
 			}
 		}
 		codes = append(codes, code)
@@ -167,44 +181,44 @@ func (g *Generator) AddParseParamsMethods(baseName string, contentType string, o
 	if len(pathParams) > 0 {
 		err = g.AddParamsModel(baseName, "PathParams", pathParams)
 		if err != nil {
-			return errors.Wrap(err, op)
+			return withPointer(errors.Wrap(err, op), "parameters")
 		}
 		err = g.AddParsePathParamsMethod(baseName, pathParams)
 		if err != nil {
-			return errors.Wrap(err, op)
+			return withPointer(errors.Wrap(err, op), "parameters")
 		}
 	}
 	queryParams := g.GetOperationParamsByType(operation, openapi3.ParameterInQuery)
 	if len(queryParams) > 0 {
 		err = g.AddParamsModel(baseName, "QueryParams", queryParams)
 		if err != nil {
-			return errors.Wrap(err, op)
+			return withPointer(errors.Wrap(err, op), "parameters")
 		}
 		err = g.AddParseQueryParamsMethod(baseName, queryParams)
 		if err != nil {
-			return errors.Wrap(err, op)
+			return withPointer(errors.Wrap(err, op), "parameters")
 		}
 	}
 	headerParams := g.GetOperationParamsByType(operation, openapi3.ParameterInHeader)
 	if len(headerParams) > 0 {
 		err = g.AddParamsModel(baseName, "Headers", headerParams)
 		if err != nil {
-			return errors.Wrap(err, op)
+			return withPointer(errors.Wrap(err, op), "parameters")
 		}
 		err = g.AddParseHeadersMethod(baseName, headerParams)
 		if err != nil {
-			return errors.Wrap(err, op)
+			return withPointer(errors.Wrap(err, op), "parameters")
 		}
 	}
 	cookieParams := g.GetOperationParamsByType(operation, openapi3.ParameterInCookie)
 	if len(cookieParams) > 0 {
 		err = g.AddParamsModel(baseName, "Cookies", cookieParams)
 		if err != nil {
-			return errors.Wrap(err, op)
+			return withPointer(errors.Wrap(err, op), "parameters")
 		}
 		err = g.AddParseCookiesMethod(baseName, cookieParams)
 		if err != nil {
-			return errors.Wrap(err, op)
+			return withPointer(errors.Wrap(err, op), "parameters")
 		}
 	}
 	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
@@ -213,12 +227,12 @@ func (g *Generator) AddParseParamsMethods(baseName string, contentType string, o
 			if content.Schema.Ref == "" {
 				err = g.ProcessSchema(baseName+"RequestBody", content.Schema)
 				if err != nil {
-					return errors.Wrap(err, op)
+					return withPointer(errors.Wrap(err, op), "requestBody", "content", contentType, "schema")
 				}
 			}
 			err = g.AddParseRequestBodyMethod(baseName, contentType, operation.RequestBody)
 			if err != nil {
-				return errors.Wrap(err, op)
+				return withPointer(errors.Wrap(err, op), "requestBody")
 			}
 		}
 	}
@@ -239,12 +253,32 @@ func (g *Generator) ProcessApplicationJSONOperation(pathName string, method stri
 	if contentType == "" {
 		contentType = applicationJSONCT
 	}
-	handlerBaseName := FormatGoLikeIdentifier(method) + FormatGoLikeIdentifier(pathName)
+	handlerBaseName := g.GoName(method) + g.GoName(pathName)
 	if operation.OperationID != "" {
-		handlerBaseName = FormatGoLikeIdentifier(operation.OperationID)
+		handlerBaseName = g.GoName(operation.OperationID)
 	}
+	if goName, ok := operation.Extensions[goNameExtension].(string); ok && goName != "" {
+		handlerBaseName = goName
+	}
+	operationName := method + " " + pathName
+	if other, ok := g.HandlersFile.operationNames[handlerBaseName]; ok && other != operationName {
+		return errors.Errorf("operations %s and %s have the same Go name %s", other, operationName, handlerBaseName)
+	}
+	g.HandlersFile.operationNames[handlerBaseName] = operationName
+	for _, modelName := range []string{handlerBaseName + "Request", handlerBaseName + "Response"} {
+		err := g.claimOperationModel(modelName)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+	g.HandlersFile.operations = append(g.HandlersFile.operations, plugin.Operation{
+		Method:    strings.ToUpper(method),
+		Path:      pathName,
+		BaseName:  handlerBaseName,
+		Operation: operation,
+	})
 
-	g.AddInterface(handlerBaseName)
+	g.AddInterface(handlerBaseName, method, pathName, operation)
 	g.AddDependencyToHandler(handlerBaseName)
 	g.AddRoute(handlerBaseName, method, pathName)
 	err := g.AddParseParamsMethods(handlerBaseName, contentType, operation)
@@ -278,7 +312,8 @@ func (g *Generator) ProcessOperation(pathName string, method string, operation *
 					return errors.Wrap(err, op)
 				}
 			default:
-				return errors.New("unsupported content type")
+				return withPointer(errors.Errorf("unsupported content type %s", contentType),
+					"requestBody", "content", contentType)
 			}
 		}
 	} else {
@@ -291,50 +326,41 @@ func (g *Generator) ProcessOperation(pathName string, method string, operation *
 	return nil
 }
 
-func (g *Generator) ProcessPaths(paths *openapi3.Paths) error {
-	const op = "generator.ProcessPaths"
+// ProcessPaths generates the operations, the problems are recorded as
+// diagnostics.
+func (g *Generator) ProcessPaths(paths *openapi3.Paths) {
 	g.AddHandlersImport(g.ModelsImportPath)
 	g.AddHandlersImport("context")
 	g.AddHandlersImport("net/http")
 	for _, pathName := range paths.InMatchingOrder() {
 		pathItem := paths.Value(pathName)
-		if pathItem.Get != nil {
-			if pathItem.Get.RequestBody != nil {
-				return errors.New("GET method should not have request body")
-			}
-			err := g.ProcessOperation(pathName, "Get", pathItem.Get)
-			if err != nil {
-				return errors.Wrap(err, op)
-			}
-		}
-		if pathItem.Post != nil {
-			err := g.ProcessOperation(pathName, "Post", pathItem.Post)
-			if err != nil {
-				return errors.Wrap(err, op)
-			}
-		}
-		if pathItem.Delete != nil {
-			if !g.Opts.AllowDeleteWithBody && pathItem.Delete.RequestBody != nil {
-				return errors.New("DELETE method should not have request body")
-			}
-			err := g.ProcessOperation(pathName, "Delete", pathItem.Delete)
-			if err != nil {
-				return errors.Wrap(err, op)
+		for _, operation := range []struct {
+			method    string
+			operation *openapi3.Operation
+		}{
+			{method: "Get", operation: pathItem.Get},
+			{method: "Post", operation: pathItem.Post},
+			{method: "Delete", operation: pathItem.Delete},
+			{method: "Put", operation: pathItem.Put},
+			{method: "Patch", operation: pathItem.Patch},
+		} {
+			if operation.operation == nil {
+				continue
 			}
-		}
-		if pathItem.Put != nil {
-			err := g.ProcessOperation(pathName, "Put", pathItem.Put)
-			if err != nil {
-				return errors.Wrap(err, op)
-			}
-		}
-		if pathItem.Patch != nil {
-			err := g.ProcessOperation(pathName, "Patch", pathItem.Patch)
-			if err != nil {
-				return errors.Wrap(err, op)
+			method := strings.ToLower(operation.method)
+			switch {
+			case operation.method == "Get" && operation.operation.RequestBody != nil:
+				g.addDiagnostic(errors.New("GET method should not have request body"),
+					"paths", pathName, method, "requestBody")
+			case operation.method == "Delete" && !g.Opts.AllowDeleteWithBody && operation.operation.RequestBody != nil:
+				g.addDiagnostic(errors.New("DELETE method should not have request body"),
+					"paths", pathName, method, "requestBody")
+			default:
+				err := g.ProcessOperation(pathName, operation.method, operation.operation)
+				if err != nil {
+					g.addDiagnostic(err, "paths", pathName, method)
+				}
 			}
 		}
 	}
-
-	return nil
 }
@@ -7,21 +7,29 @@ import (
 	"github.com/go-faster/errors"
 )
 
-func (g *Generator) ProcessSchemas(schemas map[string]*openapi3.SchemaRef) error {
-	const op = "generator.ProcessSchemas"
+// ProcessSchemas generates the component schemas, the problems are recorded
+// as diagnostics.
+func (g *Generator) ProcessSchemas(schemas map[string]*openapi3.SchemaRef) {
 	modelKeys := make([]string, 0, len(schemas))
 	for modelName := range schemas {
 		modelKeys = append(modelKeys, modelName)
 	}
 	sort.Strings(modelKeys)
 
-	for _, modelName := range modelKeys {
-		schema := schemas[modelName]
+	goNames := make(map[string]string, len(modelKeys))
+	for _, name := range modelKeys {
+		schema := schemas[name]
+		modelName := GetSchemaGoName(name, schema)
+		if other, ok := goNames[modelName]; ok {
+			g.addDiagnostic(errors.Errorf("schemas %s and %s have the same Go name %s", other, name, modelName),
+				"components", "schemas", name)
+
+			continue
+		}
+		goNames[modelName] = name
 		err := g.ProcessSchema(modelName, schema)
 		if err != nil {
-			return errors.Wrap(err, op)
+			g.addDiagnostic(err, "components", "schemas", name)
 		}
 	}
-
-	return nil
 }
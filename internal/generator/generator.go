@@ -3,6 +3,8 @@ package generator
 import (
 	"path"
 	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
 )
 
 func refIsExternal(ref string) bool {
@@ -18,21 +20,53 @@ func parseFilenameFromRef(ref string) string {
 	return filename
 }
 
+// Layout is where the code of a spec file is written and how it is imported.
+type Layout struct {
+	Package        string
+	ModelsPackage  string
+	HandlersDir    string
+	ModelsDir      string
+	HandlersImport string
+	ModelsImport   string
+}
+
+// GetLayout returns the layout of a spec file, by default the handlers are
+// generated into generated/<name> and the models into
+// generated/<name>/<name>models, where the name is derived from the file name.
+func (g *Generator) GetLayout(yamlFilePath string) Layout {
+	opts := g.baseOpts.ForFile(yamlFilePath)
+	layout := Layout{
+		Package:       opts.PackageName,
+		ModelsPackage: opts.ModelsPackageName,
+	}
+	if layout.Package == "" {
+		layout.Package = g.GetModelName(yamlFilePath)
+	}
+	if layout.ModelsPackage == "" {
+		layout.ModelsPackage = layout.Package + "models"
+	}
+	handlersPath := opts.HandlersPath
+	if handlersPath == "" {
+		handlersPath = path.Join("generated", layout.Package)
+	}
+	modelsPath := opts.ModelsPath
+	if modelsPath == "" {
+		modelsPath = path.Join(handlersPath, layout.ModelsPackage)
+	}
+	layout.HandlersDir = path.Join(opts.DirPrefix, handlersPath)
+	layout.ModelsDir = path.Join(opts.DirPrefix, modelsPath)
+	layout.HandlersImport = path.Join(opts.PackagePrefix, handlersPath)
+	layout.ModelsImport = path.Join(opts.PackagePrefix, modelsPath)
+
+	return layout
+}
+
 func (g *Generator) GetModelsImportForFile(filename string) string {
-	return path.Join(
-		g.Opts.PackagePrefix,
-		"generated",
-		g.GetModelName(filename),
-		g.GetModelName(filename)+"models",
-	)
+	return g.GetLayout(g.GetYAMLFilePath(filename)).ModelsImport
 }
 
 func (g *Generator) GetHandlersImportForFile(filename string) string {
-	return path.Join(
-		g.Opts.PackagePrefix,
-		"generated",
-		g.GetModelName(filename),
-	)
+	return g.GetLayout(g.GetYAMLFilePath(filename)).HandlersImport
 }
 
 func (g *Generator) GetYAMLFilePath(filename string) string {
@@ -58,16 +92,37 @@ func (g *Generator) ParseRefTypeName(ref string) (string, string) {
 			return baseName, ""
 		}
 		g.YAMLFilesToProcess = append(g.YAMLFilesToProcess, g.GetYAMLFilePath(filename))
+		if g.SchemasFile != nil {
+			g.SchemasFile.hasExternalRefs = true
+		}
 
-		modelsImport := g.GetModelsImportForFile(filename)
-		modelName := g.GetModelName(filename) + "models"
+		layout := g.GetLayout(g.GetYAMLFilePath(filename))
 
-		return modelName + "." + baseName, modelsImport
+		return layout.ModelsPackage + "." + baseName, layout.ModelsImport
 	}
 
 	return baseName, ""
 }
 
+// ParseSchemaRefTypeName is ParseRefTypeName honouring x-go-name of the
+// referenced schema.
+func (g *Generator) ParseSchemaRefTypeName(schema *openapi3.SchemaRef) (string, string) {
+	typeName, importPath := g.ParseRefTypeName(schema.Ref)
+	goName := GetSchemaGoName("", schema)
+	if goName == "" {
+		return typeName, importPath
+	}
+	if pkg, _, ok := strings.Cut(typeName, "."); ok {
+		return pkg + "." + goName, importPath
+	}
+
+	return goName, importPath
+}
+
 func (g *Generator) GetCurrentModelsPackage() string {
+	if g.ModelsPackageName != "" {
+		return g.ModelsPackageName
+	}
+
 	return g.PackageName + "models"
 }
@@ -2,12 +2,18 @@ package generator_test
 
 import (
 	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jolfzverb/codegen/internal/generator"
 	"github.com/jolfzverb/codegen/internal/generator/options"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGenerateValidInput(t *testing.T) {
@@ -62,12 +68,14 @@ type ExampleModelField4 struct {
 	Field1 *string ` + "`json:\"field1,omitempty\" validate:\"omitempty\"`" + `
 }
 type ExampleModel struct {
-	Field4     *ExampleModelField4 ` + "`json:\"field4,omitempty\" validate:\"omitempty\"`" + `
-	Field5     *ObjectModel        ` + "`json:\"field5,omitempty\" validate:\"omitempty\"`" + `
-	Field6     float64             ` + "`json:\"field6\" validate:\"min=1.5,max=10.5\"`" + `
-	FieldOne   string              ` + "`json:\"field_one\" validate:\"min=3,max=10\"`" + `
-	FieldThree *StringModel        ` + "`json:\"field_three,omitempty\" validate:\"omitempty\"`" + `
-	FieldTwo   *int                ` + "`json:\"field_two,omitempty\" validate:\"omitempty\"`" + `
+	Field4 *ExampleModelField4 ` + "`json:\"field4,omitempty\" validate:\"omitempty\"`" + `
+	Field5 *ObjectModel        ` + "`json:\"field5,omitempty\" validate:\"omitempty\"`" + `
+	// Constraints: minimum 1.5, maximum 10.5.
+	Field6 float64 ` + "`json:\"field6\" validate:\"min=1.5,max=10.5\"`" + `
+	// Constraints: min length 3, max length 10.
+	FieldOne   string       ` + "`json:\"field_one\" validate:\"min=3,max=10\"`" + `
+	FieldThree *StringModel ` + "`json:\"field_three,omitempty\" validate:\"omitempty\"`" + `
+	FieldTwo   *int         ` + "`json:\"field_two,omitempty\" validate:\"omitempty\"`" + `
 }
 type IntModel int
 type ObjectModel struct {
@@ -244,6 +252,7 @@ components:
 package packagenamemodels
 
 type ObjectModel struct {
+	// Constraints: format email, min length 3, max length 10.
 	StringField *string ` + "`json:\"string_field,omitempty\" validate:\"omitempty,min=3,max=10,email\"`" + `
 }
 `,
@@ -274,6 +283,8 @@ package packagenamemodels
 type ObjectModel struct {
 	StringField *StringModel ` + "`json:\"string_field,omitempty\" validate:\"omitempty,min=3,max=10\"`" + `
 }
+
+// Constraints: min length 3, max length 10.
 type StringModel string
 `,
 		},
@@ -701,10 +712,243 @@ components:
 
 package packagenamemodels
 
+// Constraints: min items 1, max items 10, unique items.
 type ObjectModelArrayField []string
 type ObjectModel struct {
+	// Constraints: min items 1, max items 10, unique items.
 	ArrayField *ObjectModelArrayField ` + "`json:\"array_field,omitempty\" validate:\"omitempty,min=1,max=10,unique,dive,min=3,max=10\"`" + `
 }
+`,
+		},
+		{
+			name: "TestEnumSchema",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Status:
+      type: string
+      enum: [active, in-progress, "done"]
+    Priority:
+      type: integer
+      enum: [1, 2, 3]
+      x-enum-varnames: [Low, Medium, High]
+    ObjectModel:
+      type: object
+      properties:
+        kind:
+          type: string
+          enum: [a, b]
+`,
+			expected: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+type ObjectModelKind string
+
+const (
+	ObjectModelKindA ObjectModelKind = "a"
+	ObjectModelKindB ObjectModelKind = "b"
+)
+
+func (v ObjectModelKind) IsValid() bool {
+	switch v {
+	case ObjectModelKindA, ObjectModelKindB:
+		return true
+	}
+	return false
+}
+func AllObjectModelKindValues() []ObjectModelKind {
+	return []ObjectModelKind{ObjectModelKindA, ObjectModelKindB}
+}
+
+type ObjectModel struct {
+	Kind *ObjectModelKind ` + "`json:\"kind,omitempty\" validate:\"omitempty,oneof=a b\"`" + `
+}
+type Priority int
+
+const (
+	PriorityLow    Priority = 1
+	PriorityMedium Priority = 2
+	PriorityHigh   Priority = 3
+)
+
+func (v Priority) IsValid() bool {
+	switch v {
+	case PriorityLow, PriorityMedium, PriorityHigh:
+		return true
+	}
+	return false
+}
+func AllPriorityValues() []Priority {
+	return []Priority{PriorityLow, PriorityMedium, PriorityHigh}
+}
+
+type Status string
+
+const (
+	StatusActive     Status = "active"
+	StatusInProgress Status = "in-progress"
+	StatusDone       Status = "done"
+)
+
+func (v Status) IsValid() bool {
+	switch v {
+	case StatusActive, StatusInProgress, StatusDone:
+		return true
+	}
+	return false
+}
+func AllStatusValues() []Status {
+	return []Status{StatusActive, StatusInProgress, StatusDone}
+}
+`,
+		},
+		{
+			name: "TestDefaultValues",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    ObjectModel:
+      type: object
+      properties:
+        limit:
+          type: integer
+          default: 20
+        name:
+          type: string
+          default: unnamed
+        kind:
+          type: string
+          enum: [a, b]
+          default: b
+        ratio:
+          type: number
+          default: 0.5
+        enabled:
+          type: boolean
+          default: true
+        required_field:
+          type: string
+      required:
+        - required_field
+`,
+			expected: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+import "encoding/json"
+
+// Default: "b".
+type ObjectModelKind string
+
+const (
+	ObjectModelKindA ObjectModelKind = "a"
+	ObjectModelKindB ObjectModelKind = "b"
+)
+
+func (v ObjectModelKind) IsValid() bool {
+	switch v {
+	case ObjectModelKindA, ObjectModelKindB:
+		return true
+	}
+	return false
+}
+func AllObjectModelKindValues() []ObjectModelKind {
+	return []ObjectModelKind{ObjectModelKindA, ObjectModelKindB}
+}
+
+type ObjectModel struct {
+	// Default: true.
+	Enabled *bool ` + "`json:\"enabled,omitempty\" validate:\"omitempty\"`" + `
+	// Default: "b".
+	Kind *ObjectModelKind ` + "`json:\"kind,omitempty\" validate:\"omitempty,oneof=a b\"`" + `
+	// Default: 20.
+	Limit *int ` + "`json:\"limit,omitempty\" validate:\"omitempty\"`" + `
+	// Default: "unnamed".
+	Name *string ` + "`json:\"name,omitempty\" validate:\"omitempty\"`" + `
+	// Default: 0.5.
+	Ratio         *float64 ` + "`json:\"ratio,omitempty\" validate:\"omitempty\"`" + `
+	RequiredField string   ` + "`json:\"required_field\"`" + `
+}
+
+func (m *ObjectModel) UnmarshalJSON(data []byte) error {
+	type plain ObjectModel
+	value := plain{}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if value.Enabled == nil {
+		defaultEnabled := bool(true)
+		value.Enabled = &defaultEnabled
+	}
+	if value.Kind == nil {
+		defaultKind := ObjectModelKind("b")
+		value.Kind = &defaultKind
+	}
+	if value.Limit == nil {
+		defaultLimit := int(20)
+		value.Limit = &defaultLimit
+	}
+	if value.Name == nil {
+		defaultName := string("unnamed")
+		value.Name = &defaultName
+	}
+	if value.Ratio == nil {
+		defaultRatio := float64(0.5)
+		value.Ratio = &defaultRatio
+	}
+	*m = ObjectModel(value)
+	return nil
+}
+`,
+		},
+		{
+			name: "TestValidators",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    ValidatedModel:
+      type: object
+      properties:
+        code:
+          type: string
+          pattern: '^\d{2}[,|]\w+$'
+        step:
+          type: integer
+          multipleOf: 10
+        ratio:
+          type: number
+          minimum: 0
+          exclusiveMinimum: true
+          maximum: 100
+      required:
+        - code
+`,
+			expected: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+type ValidatedModel struct {
+	// Constraints: pattern ^\d{2}[,|]\w+$.
+	Code string ` + "`json:\"code\" validate:\"pattern=^\\\\d{2}[0x2C0x7C]\\\\w+$\"`" + `
+	// Constraints: exclusive minimum 0, maximum 100.
+	Ratio *float64 ` + "`json:\"ratio,omitempty\" validate:\"omitempty,gt=0,max=100\"`" + `
+	// Constraints: multiple of 10.
+	Step *int ` + "`json:\"step,omitempty\" validate:\"omitempty,multipleOf=10\"`" + `
+}
 `,
 		},
 	} {
@@ -821,6 +1065,7 @@ type PostExampleParamNameRequest struct {
 	Body    PostExampleParamNameRequestBody
 }
 type PostExampleParamNameResponse200Headers struct {
+	// X-Header
 	XHeader string ` + "`json:\"X-Header\" validate:\"required\"`" + `
 }
 type PostExampleParamNameResponse200 struct {
@@ -847,10 +1092,15 @@ import (
 	"packagename/imports/models"
 )
 
+// GetExample2Handler handles GET /example2.
 type GetExample2Handler interface {
+	// Example
 	HandleGetExample2(ctx context.Context, r packagenamemodels.GetExample2Request) (*packagenamemodels.GetExample2Response, error)
 }
+
+// PostExampleParamNameHandler handles POST /example/{param_name}.
 type PostExampleParamNameHandler interface {
+	// Example
 	HandlePostExampleParamName(ctx context.Context, r packagenamemodels.PostExampleParamNameRequest) (*packagenamemodels.PostExampleParamNameResponse, error)
 }
 type Handler struct {
@@ -963,6 +1213,9 @@ func containsNull(data json.RawMessage) bool {
 	return temp == nil
 }
 func ValidatePostExampleParamNameRequestBodyJSON(jsonData json.RawMessage) error {
+	return validatePostExampleParamNameRequestBodyJSON(jsonData, 0, false)
+}
+func validatePostExampleParamNameRequestBodyJSON(jsonData json.RawMessage, _ int, _ bool) error {
 	requiredFields := map[string]bool{"code": true}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
@@ -989,7 +1242,7 @@ func (h *Handler) parsePostExampleParamNameRequestBody(r *http.Request) (*packag
 	if err != nil {
 		return nil, err
 	}
-	err = ValidatePostExampleParamNameRequestBodyJSON(bodyJSON)
+	err = validatePostExampleParamNameRequestBodyJSON(bodyJSON, 0, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1086,6 +1339,199 @@ func (h *Handler) handlePostExampleParamName(w http.ResponseWriter, r *http.Requ
 		return
 	}
 }
+`,
+		},
+		{
+			name: "param defaults",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example:
+    get:
+      operationId: list
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: string
+            default: "20"
+        - name: X-Mode
+          in: header
+          schema:
+            type: string
+            default: fast
+        - name: session
+          in: cookie
+          schema:
+            type: string
+            default: anonymous
+      responses:
+        '200':
+          description: OK
+`,
+			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+type ListQueryParams struct {
+	// Default: "20".
+	Limit *string ` + "`json:\"limit,omitempty\" validate:\"omitempty\"`" + `
+}
+type ListHeaders struct {
+	// Default: "fast".
+	XMode *string ` + "`json:\"X-Mode,omitempty\" validate:\"omitempty\"`" + `
+}
+type ListCookies struct {
+	// Default: "anonymous".
+	Session *string ` + "`json:\"session,omitempty\" validate:\"omitempty\"`" + `
+}
+type ListRequest struct {
+	Query   ListQueryParams
+	Headers ListHeaders
+	Cookies ListCookies
+}
+type ListResponse200 struct {
+}
+type ListResponse struct {
+	StatusCode  int
+	Response200 *ListResponse200
+}
+`,
+			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagename
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-faster/errors"
+	"github.com/go-playground/validator/v10"
+	"packagename/imports/models"
+)
+
+type ListHandler interface {
+	HandleList(ctx context.Context, r packagenamemodels.ListRequest) (*packagenamemodels.ListResponse, error)
+}
+type Handler struct {
+	validator *validator.Validate
+	list      ListHandler
+}
+
+func NewHandler(list ListHandler) *Handler {
+	return &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), list: list}
+}
+func (h *Handler) AddRoutes(router chi.Router) {
+	router.Get("/example", h.handleList)
+}
+func (h *Handler) parseListQueryParams(r *http.Request) (*packagenamemodels.ListQueryParams, error) {
+	var queryParams packagenamemodels.ListQueryParams
+	limit := r.URL.Query().Get("limit")
+	if limit == "" {
+		limit = "20"
+	}
+	queryParams.Limit = &limit
+	err := h.validator.Struct(queryParams)
+	if err != nil {
+		return nil, err
+	}
+	return &queryParams, nil
+}
+func (h *Handler) parseListHeaders(r *http.Request) (*packagenamemodels.ListHeaders, error) {
+	var headers packagenamemodels.ListHeaders
+	xMode := r.Header.Get("X-Mode")
+	if xMode == "" {
+		xMode = "fast"
+	}
+	headers.XMode = &xMode
+	err := h.validator.Struct(headers)
+	if err != nil {
+		return nil, err
+	}
+	return &headers, nil
+}
+func (h *Handler) parseListCookies(r *http.Request) (*packagenamemodels.ListCookies, error) {
+	var cookies packagenamemodels.ListCookies
+	session, err := r.Cookie("session")
+	if err != nil && !errors.Is(err, http.ErrNoCookie) {
+		return nil, err
+	}
+	sessionValue := "anonymous"
+	if err == nil {
+		sessionValue = session.Value
+	}
+	cookies.Session = &sessionValue
+	err = h.validator.Struct(cookies)
+	if err != nil {
+		return nil, err
+	}
+	return &cookies, nil
+}
+func (h *Handler) parseListRequest(r *http.Request) (*packagenamemodels.ListRequest, error) {
+	queryParams, err := h.parseListQueryParams(r)
+	if err != nil {
+		return nil, err
+	}
+	headers, err := h.parseListHeaders(r)
+	if err != nil {
+		return nil, err
+	}
+	cookieParams, err := h.parseListCookies(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.ListRequest{Query: *queryParams, Headers: *headers, Cookies: *cookieParams}, nil
+}
+func List200Response() *packagenamemodels.ListResponse {
+	return &packagenamemodels.ListResponse{StatusCode: 200, Response200: &packagenamemodels.ListResponse200{}}
+}
+func (h *Handler) writeList200Response(w http.ResponseWriter, r *packagenamemodels.ListResponse200) {
+}
+func (h *Handler) writeListResponse(w http.ResponseWriter, response *packagenamemodels.ListResponse) {
+	switch response.StatusCode {
+	case 200:
+		if response.Response200 == nil {
+			http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(response.StatusCode)
+		h.writeList200Response(w, response.Response200)
+		return
+	}
+	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+}
+func (h *Handler) handleListRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parseListRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	response, err := h.list.HandleList(ctx, *request)
+	if err != nil || response == nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	h.writeListResponse(w, response)
+	return
+}
+func (h *Handler) handleList(w http.ResponseWriter, r *http.Request) {
+	switch r.Header.Get("Content-Type") {
+	case "application/json":
+		h.handleListRequest(w, r)
+		return
+	case "":
+		h.handleListRequest(w, r)
+		return
+	default:
+		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
+		return
+	}
+}
 `,
 		},
 	} {
@@ -1178,7 +1624,9 @@ import (
 	"packagename/imports/models"
 )
 
+// PostExampleHandler handles POST /example.
 type PostExampleHandler interface {
+	// Example
 	HandlePostExample(ctx context.Context, r packagenamemodels.PostExampleRequest) (*packagenamemodels.PostExampleResponse, error)
 }
 type Handler struct {
@@ -1198,7 +1646,7 @@ func (h *Handler) parsePostExampleRequestBody(r *http.Request) (*packagenamemode
 	if err != nil {
 		return nil, err
 	}
-	err = ValidateBodyJSON(bodyJSON)
+	err = validateBodyJSON(bodyJSON, 0, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1266,7 +1714,10 @@ func (h *Handler) handlePostExample(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
-func ValidateBodyJSON(_ json.RawMessage) error {
+func ValidateBodyJSON(jsonData json.RawMessage) error {
+	return validateBodyJSON(jsonData, 0, false)
+}
+func validateBodyJSON(_ json.RawMessage, _ int, _ bool) error {
 	return nil
 }
 `,
@@ -1362,7 +1813,9 @@ import (
 	"packagename/imports/models"
 )
 
+// OpHandler handles POST /example.
 type OpHandler interface {
+	// Example
 	HandleOp(ctx context.Context, r packagenamemodels.OpRequest) (*packagenamemodels.OpResponse, error)
 }
 type Handler struct {
@@ -1382,7 +1835,7 @@ func (h *Handler) parseOpRequestBody(r *http.Request) (*packagenamemodels.Body,
 	if err != nil {
 		return nil, err
 	}
-	err = ValidateBodyJSON(bodyJSON)
+	err = validateBodyJSON(bodyJSON, 0, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1450,77 +1903,157 @@ func (h *Handler) handleOp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
-func ValidateBodyJSON(_ json.RawMessage) error {
+func ValidateBodyJSON(jsonData json.RawMessage) error {
+	return validateBodyJSON(jsonData, 0, false)
+}
+func validateBodyJSON(_ json.RawMessage, _ int, _ bool) error {
 	return nil
 }
 `,
 		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			input := strings.NewReader(tc.input)
-			outputModels := &bytes.Buffer{}
-			outputHandlers := &bytes.Buffer{}
-			gen := generator.NewGenerator(&options.Options{
-				PackagePrefix: "packagename",
-			})
-			gen.PackageName = "packagename"
-			gen.ImportPrefix = "imports"
-			gen.ModelsImportPath = "packagename/imports/models"
-			err := gen.PrepareAndRead(input)
-			assert.NoError(t, err)
-			err = gen.GenerateFiles()
-			assert.NoError(t, err)
-			err = gen.WriteToOutput(outputModels, outputHandlers)
-			assert.NoError(t, err)
-
-			assert.Equal(t, tc.expectedModels, outputModels.String())
-			assert.Equal(t, tc.expectedHandlers, outputHandlers.String())
-		})
-	}
-}
-
-func TestGenerateCookies(t *testing.T) {
-	for _, tc := range []struct {
-		name             string
-		input            string
-		expectedModels   string
-		expectedHandlers string
-	}{
 		{
-			name: "operation id",
+			name: "string formats",
 			input: `openapi: 3.0.0
 info:
   title: API
   version: 1.0.0
 paths:
-  /example:
-    post:
-      operationId: op
-      summary: Example
+  /items/{id}:
+    get:
+      operationId: getItem
       parameters:
-        - name: cookie-field
-          in: cookie
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+            format: uuid
+        - name: since
+          in: query
           schema:
             type: string
+            format: date
       responses:
         '200':
           description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Item'
+components:
+  schemas:
+    Item:
+      type: object
+      properties:
+        link:
+          type: string
+          format: uri
+        payload:
+          type: string
+          format: byte
+        host:
+          type: string
+          format: hostname
+        ttl:
+          type: string
+          format: duration
 `,
 			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
 
 package packagenamemodels
 
-type OpCookies struct {
-	CookieField *string ` + "`json:\"cookie-field,omitempty\" validate:\"omitempty\"`" + `
+import (
+	"net/url"
+	"time"
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+)
+
+type GetitemPathParams struct {
+	// Constraints: format uuid.
+	ID uuid.UUID ` + "`json:\"id\" validate:\"required\"`" + `
 }
-type OpRequest struct {
-	Cookies OpCookies
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
 }
-type OpResponse200 struct {
+
+func ParseDate(value string) (Date, error) {
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{Year: parsed.Year(), Month: parsed.Month(), Day: parsed.Day()}, nil
 }
-type OpResponse struct {
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+func (d Date) String() string {
+	return d.Time().Format(time.DateOnly)
+}
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+func (d *Date) UnmarshalText(data []byte) error {
+	parsed, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+type GetitemQueryParams struct {
+	// Constraints: format date.
+	Since *Date ` + "`json:\"since,omitempty\" validate:\"omitempty\"`" + `
+}
+type GetitemRequest struct {
+	Path  GetitemPathParams
+	Query GetitemQueryParams
+}
+type GetitemResponse200 struct {
+	Body Item
+}
+type GetitemResponse struct {
 	StatusCode  int
-	Response200 *OpResponse200
+	Response200 *GetitemResponse200
+}
+type URL struct {
+	url.URL
+}
+
+func ParseURL(value string) (URL, error) {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return URL{}, err
+	}
+	if !parsed.IsAbs() {
+		return URL{}, errors.New("uri must be absolute")
+	}
+	return URL{URL: *parsed}, nil
+}
+func (u URL) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+func (u *URL) UnmarshalText(data []byte) error {
+	parsed, err := ParseURL(string(data))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+type Item struct {
+	// Constraints: format hostname.
+	Host *string ` + "`json:\"host,omitempty\" validate:\"omitempty,hostname_rfc1123\"`" + `
+	// Constraints: format uri.
+	Link *URL ` + "`json:\"link,omitempty\" validate:\"omitempty\"`" + `
+	// Constraints: format byte.
+	Payload *[]byte ` + "`json:\"payload,omitempty\" validate:\"omitempty\"`" + `
+	// Constraints: format duration.
+	TTL *string ` + "`json:\"ttl,omitempty\" validate:\"omitempty,pattern=^P(\\\\d+W0x7C(\\\\d+Y)?(\\\\d+M)?(\\\\d+D)?(T(\\\\d+H)?(\\\\d+M)?(\\\\d+(\\\\.\\\\d+)?S)?)?)$\"`" + `
 }
 `,
 			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
@@ -1529,172 +2062,261 @@ package packagename
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"regexp"
 	"strconv"
+	"sync"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-faster/errors"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"packagename/imports/models"
 )
 
-type OpHandler interface {
-	HandleOp(ctx context.Context, r packagenamemodels.OpRequest) (*packagenamemodels.OpResponse, error)
+type GetitemHandler interface {
+	HandleGetitem(ctx context.Context, r packagenamemodels.GetitemRequest) (*packagenamemodels.GetitemResponse, error)
 }
+
+var patternsCache sync.Map
+
 type Handler struct {
 	validator *validator.Validate
-	op        OpHandler
+	getitem   GetitemHandler
 }
 
-func NewHandler(op OpHandler) *Handler {
-	return &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), op: op}
+func NewHandler(getitem GetitemHandler) *Handler {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	_ = v.RegisterValidation("pattern", validatePattern)
+	_ = v.RegisterValidation("multipleOf", validateMultipleOf)
+	return &Handler{validator: v, getitem: getitem}
 }
 func (h *Handler) AddRoutes(router chi.Router) {
-	router.Post("/example", h.handleOp)
+	router.Get("/items/{id}", h.handleGetitem)
 }
-func (h *Handler) parseOpCookies(r *http.Request) (*packagenamemodels.OpCookies, error) {
-	var cookies packagenamemodels.OpCookies
-	cookieField, err := r.Cookie("cookie-field")
-	if err != nil && !errors.Is(err, http.ErrNoCookie) {
+func (h *Handler) parseGetitemPathParams(r *http.Request) (*packagenamemodels.GetitemPathParams, error) {
+	var pathParams packagenamemodels.GetitemPathParams
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		return nil, errors.New("id path param is required")
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "ID is not a valid uuid format")
+	}
+	pathParams.ID = parsedID
+	err = h.validator.Struct(pathParams)
+	if err != nil {
 		return nil, err
 	}
-	if err == nil {
-		cookieFieldValue := cookieField.Value
-		cookies.CookieField = &cookieFieldValue
+	return &pathParams, nil
+}
+func (h *Handler) parseGetitemQueryParams(r *http.Request) (*packagenamemodels.GetitemQueryParams, error) {
+	var queryParams packagenamemodels.GetitemQueryParams
+	since := r.URL.Query().Get("since")
+	if since != "" {
+		parsedSince, err := packagenamemodels.ParseDate(since)
+		if err != nil {
+			return nil, errors.Wrap(err, "Since is not a valid date format")
+		}
+		queryParams.Since = &parsedSince
 	}
-	err = h.validator.Struct(cookies)
+	err := h.validator.Struct(queryParams)
 	if err != nil {
 		return nil, err
 	}
-	return &cookies, nil
+	return &queryParams, nil
 }
-func (h *Handler) parseOpRequest(r *http.Request) (*packagenamemodels.OpRequest, error) {
-	cookieParams, err := h.parseOpCookies(r)
+func (h *Handler) parseGetitemRequest(r *http.Request) (*packagenamemodels.GetitemRequest, error) {
+	pathParams, err := h.parseGetitemPathParams(r)
 	if err != nil {
 		return nil, err
 	}
-	return &packagenamemodels.OpRequest{Cookies: *cookieParams}, nil
+	queryParams, err := h.parseGetitemQueryParams(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.GetitemRequest{Path: *pathParams, Query: *queryParams}, nil
 }
-func Op200Response() *packagenamemodels.OpResponse {
-	return &packagenamemodels.OpResponse{StatusCode: 200, Response200: &packagenamemodels.OpResponse200{}}
+func Getitem200Response(body packagenamemodels.Item) *packagenamemodels.GetitemResponse {
+	return &packagenamemodels.GetitemResponse{StatusCode: 200, Response200: &packagenamemodels.GetitemResponse200{Body: body}}
 }
-func (h *Handler) writeOp200Response(w http.ResponseWriter, r *packagenamemodels.OpResponse200) {
+func (h *Handler) writeGetitem200Response(w http.ResponseWriter, r *packagenamemodels.GetitemResponse200) {
+	var err error
+	err = json.NewEncoder(w).Encode(r.Body)
+	if err != nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
 }
-func (h *Handler) writeOpResponse(w http.ResponseWriter, response *packagenamemodels.OpResponse) {
+func (h *Handler) writeGetitemResponse(w http.ResponseWriter, response *packagenamemodels.GetitemResponse) {
 	switch response.StatusCode {
 	case 200:
 		if response.Response200 == nil {
 			http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
 			return
 		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(response.StatusCode)
-		h.writeOp200Response(w, response.Response200)
+		h.writeGetitem200Response(w, response.Response200)
 		return
 	}
 	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
 }
-func (h *Handler) handleOpRequest(w http.ResponseWriter, r *http.Request) {
-	request, err := h.parseOpRequest(r)
+func (h *Handler) handleGetitemRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parseGetitemRequest(r)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
 		return
 	}
 	ctx := r.Context()
-	response, err := h.op.HandleOp(ctx, *request)
+	response, err := h.getitem.HandleGetitem(ctx, *request)
 	if err != nil || response == nil {
 		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
 		return
 	}
-	h.writeOpResponse(w, response)
+	h.writeGetitemResponse(w, response)
 	return
 }
-func (h *Handler) handleOp(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) handleGetitem(w http.ResponseWriter, r *http.Request) {
 	switch r.Header.Get("Content-Type") {
 	case "application/json":
-		h.handleOpRequest(w, r)
+		h.handleGetitemRequest(w, r)
 		return
 	case "":
-		h.handleOpRequest(w, r)
+		h.handleGetitemRequest(w, r)
 		return
 	default:
 		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
 		return
 	}
 }
-`,
-		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			input := strings.NewReader(tc.input)
-			outputModels := &bytes.Buffer{}
-			outputHandlers := &bytes.Buffer{}
-			gen := generator.NewGenerator(&options.Options{
-				PackagePrefix: "packagename",
-			})
-			gen.PackageName = "packagename"
-			gen.ImportPrefix = "imports"
-			gen.ModelsImportPath = "packagename/imports/models"
-			err := gen.PrepareAndRead(input)
-			assert.NoError(t, err)
-			err = gen.GenerateFiles()
-			assert.NoError(t, err)
-			err = gen.WriteToOutput(outputModels, outputHandlers)
-			assert.NoError(t, err)
-
-			assert.Equal(t, tc.expectedModels, outputModels.String())
-			assert.Equal(t, tc.expectedHandlers, outputHandlers.String())
-		})
+func ValidateItemJSON(jsonData json.RawMessage) error {
+	return validateItemJSON(jsonData, 0, false)
+}
+func validateItemJSON(_ json.RawMessage, _ int, _ bool) error {
+	return nil
+}
+func validatePattern(fl validator.FieldLevel) bool {
+	pattern := fl.Param()
+	cached, ok := patternsCache.Load(pattern)
+	if !ok {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return false
+		}
+		cached, _ = patternsCache.LoadOrStore(pattern, compiled)
 	}
+	re, ok := cached.(*regexp.Regexp)
+	return ok && re.MatchString(fl.Field().String())
 }
-
-func TestGenerateExternal(t *testing.T) {
-	for _, tc := range []struct {
-		name             string
-		input            string
-		expectedModels   string
-		expectedHandlers string
-	}{
+func validateMultipleOf(fl validator.FieldLevel) bool {
+	divisor, err := strconv.ParseFloat(fl.Param(), 64)
+	if err != nil || divisor == 0 {
+		return false
+	}
+	field := fl.Field()
+	var value float64
+	switch {
+	case field.CanInt():
+		value = float64(field.Int())
+	case field.CanUint():
+		value = float64(field.Uint())
+	case field.CanFloat():
+		value = field.Float()
+	default:
+		return false
+	}
+	quotient := value / divisor
+	return math.Abs(quotient-math.Round(quotient)) < 1e-9
+}
+`,
+		},
 		{
-			name: "operation id",
+			name: "x-go-type overrides",
 			input: `openapi: 3.0.0
 info:
   title: API
   version: 1.0.0
 paths:
-  /example:
+  /orders:
     post:
-      operationId: op
-      summary: Example
+      operationId: createOrder
+      parameters:
+        - name: client
+          in: query
+          schema:
+            type: string
+            x-go-type: netip.Addr
+            x-go-type-import: net/netip
       requestBody:
-        required: true
         content:
           application/json:
             schema:
-              type: object
-              properties:
-                external-ref:
-                  $ref: 'testdata/def.yml#/components/schemas/ExternalRef'
+              $ref: '#/components/schemas/Order'
       responses:
         '200':
           description: OK
+components:
+  schemas:
+    Money:
+      type: object
+      x-go-type: decimal.Decimal
+      x-go-type-import:
+        path: github.com/shopspring/decimal
+      properties:
+        amount:
+          type: string
+    Order:
+      type: object
+      required:
+        - total
+      properties:
+        total:
+          $ref: '#/components/schemas/Money'
+        server:
+          type: string
+          x-go-type: netip.Addr
+          x-go-type-import: net/netip
+          x-go-type-skip-optional-pointer: true
+        tags:
+          type: array
+          items:
+            type: object
+            x-go-type: json.RawMessage
+            x-go-type-import: encoding/json
 `,
 			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
 
 package packagenamemodels
 
-import "packagename/generated/def/defmodels"
+import (
+	"encoding/json"
+	"net/netip"
+	"github.com/shopspring/decimal"
+)
 
-type OpRequestBody struct {
-	ExternalRef *defmodels.ExternalRef ` + "`json:\"external-ref,omitempty\" validate:\"omitempty\"`" + `
+type CreateorderQueryParams struct {
+	Client *netip.Addr ` + "`json:\"client,omitempty\" validate:\"omitempty\"`" + `
 }
-type OpRequest struct {
-	Body OpRequestBody
+type CreateorderRequest struct {
+	Query CreateorderQueryParams
+	Body  *Order
 }
-type OpResponse200 struct {
+type CreateorderResponse200 struct {
 }
-type OpResponse struct {
+type CreateorderResponse struct {
 	StatusCode  int
-	Response200 *OpResponse200
+	Response200 *CreateorderResponse200
+}
+type Money = decimal.Decimal
+type OrderTags []json.RawMessage
+type Order struct {
+	Server netip.Addr ` + "`json:\"server,omitzero\" validate:\"omitempty\"`" + `
+	Tags   *OrderTags ` + "`json:\"tags,omitempty\" validate:\"omitempty,dive\"`" + `
+	Total  Money      ` + "`json:\"total\"`" + `
 }
 `,
 			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
@@ -1706,40 +2328,58 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/netip"
 	"strconv"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-faster/errors"
 	"github.com/go-playground/validator/v10"
 	"packagename/imports/models"
 )
 
-type OpHandler interface {
-	HandleOp(ctx context.Context, r packagenamemodels.OpRequest) (*packagenamemodels.OpResponse, error)
+type CreateorderHandler interface {
+	HandleCreateorder(ctx context.Context, r packagenamemodels.CreateorderRequest) (*packagenamemodels.CreateorderResponse, error)
 }
 type Handler struct {
-	validator *validator.Validate
-	op        OpHandler
+	validator   *validator.Validate
+	createorder CreateorderHandler
 }
 
-func NewHandler(op OpHandler) *Handler {
-	return &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), op: op}
+func NewHandler(createorder CreateorderHandler) *Handler {
+	return &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), createorder: createorder}
 }
 func (h *Handler) AddRoutes(router chi.Router) {
-	router.Post("/example", h.handleOp)
+	router.Post("/orders", h.handleCreateorder)
 }
-func ValidateOpRequestBodyJSON(_ json.RawMessage) error {
-	return nil
+func (h *Handler) parseCreateorderQueryParams(r *http.Request) (*packagenamemodels.CreateorderQueryParams, error) {
+	var queryParams packagenamemodels.CreateorderQueryParams
+	client := r.URL.Query().Get("client")
+	if client != "" {
+		var parsedClient netip.Addr
+		if err := parsedClient.UnmarshalText([]byte(client)); err != nil {
+			return nil, errors.Wrap(err, "Client is not a valid netip.Addr")
+		}
+		queryParams.Client = &parsedClient
+	}
+	err := h.validator.Struct(queryParams)
+	if err != nil {
+		return nil, err
+	}
+	return &queryParams, nil
 }
-func (h *Handler) parseOpRequestBody(r *http.Request) (*packagenamemodels.OpRequestBody, error) {
+func (h *Handler) parseCreateorderRequestBody(r *http.Request) (*packagenamemodels.Order, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
 	var bodyJSON json.RawMessage
 	err := json.NewDecoder(r.Body).Decode(&bodyJSON)
 	if err != nil {
 		return nil, err
 	}
-	err = ValidateOpRequestBodyJSON(bodyJSON)
+	err = validateOrderJSON(bodyJSON, 0, true)
 	if err != nil {
 		return nil, err
 	}
-	var body packagenamemodels.OpRequestBody
+	var body packagenamemodels.Order
 	err = json.Unmarshal(bodyJSON, &body)
 	if err != nil {
 		return nil, err
@@ -1750,19 +2390,23 @@ func (h *Handler) parseOpRequestBody(r *http.Request) (*packagenamemodels.OpRequ
 	}
 	return &body, nil
 }
-func (h *Handler) parseOpRequest(r *http.Request) (*packagenamemodels.OpRequest, error) {
-	body, err := h.parseOpRequestBody(r)
+func (h *Handler) parseCreateorderRequest(r *http.Request) (*packagenamemodels.CreateorderRequest, error) {
+	queryParams, err := h.parseCreateorderQueryParams(r)
 	if err != nil {
 		return nil, err
 	}
-	return &packagenamemodels.OpRequest{Body: *body}, nil
+	body, err := h.parseCreateorderRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.CreateorderRequest{Query: *queryParams, Body: body}, nil
 }
-func Op200Response() *packagenamemodels.OpResponse {
-	return &packagenamemodels.OpResponse{StatusCode: 200, Response200: &packagenamemodels.OpResponse200{}}
+func Createorder200Response() *packagenamemodels.CreateorderResponse {
+	return &packagenamemodels.CreateorderResponse{StatusCode: 200, Response200: &packagenamemodels.CreateorderResponse200{}}
 }
-func (h *Handler) writeOp200Response(w http.ResponseWriter, r *packagenamemodels.OpResponse200) {
+func (h *Handler) writeCreateorder200Response(w http.ResponseWriter, r *packagenamemodels.CreateorderResponse200) {
 }
-func (h *Handler) writeOpResponse(w http.ResponseWriter, response *packagenamemodels.OpResponse) {
+func (h *Handler) writeCreateorderResponse(w http.ResponseWriter, response *packagenamemodels.CreateorderResponse) {
 	switch response.StatusCode {
 	case 200:
 		if response.Response200 == nil {
@@ -1770,61 +2414,5095 @@ func (h *Handler) writeOpResponse(w http.ResponseWriter, response *packagenamemo
 			return
 		}
 		w.WriteHeader(response.StatusCode)
-		h.writeOp200Response(w, response.Response200)
+		h.writeCreateorder200Response(w, response.Response200)
 		return
 	}
 	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
 }
-func (h *Handler) handleOpRequest(w http.ResponseWriter, r *http.Request) {
-	request, err := h.parseOpRequest(r)
+func (h *Handler) handleCreateorderRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parseCreateorderRequest(r)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
 		return
 	}
 	ctx := r.Context()
-	response, err := h.op.HandleOp(ctx, *request)
+	response, err := h.createorder.HandleCreateorder(ctx, *request)
 	if err != nil || response == nil {
 		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
 		return
 	}
-	h.writeOpResponse(w, response)
+	h.writeCreateorderResponse(w, response)
 	return
 }
-func (h *Handler) handleOp(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) handleCreateorder(w http.ResponseWriter, r *http.Request) {
 	switch r.Header.Get("Content-Type") {
 	case "application/json":
-		h.handleOpRequest(w, r)
+		h.handleCreateorderRequest(w, r)
 		return
 	case "":
-		h.handleOpRequest(w, r)
+		h.handleCreateorderRequest(w, r)
 		return
 	default:
 		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
 		return
 	}
 }
-`,
-		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			input := strings.NewReader(tc.input)
-			outputModels := &bytes.Buffer{}
-			outputHandlers := &bytes.Buffer{}
+func containsNull(data json.RawMessage) bool {
+	var temp any
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return false
+	}
+	return temp == nil
+}
+func ValidateOrderJSON(jsonData json.RawMessage) error {
+	return validateOrderJSON(jsonData, 0, false)
+}
+func validateOrderJSON(jsonData json.RawMessage, _ int, _ bool) error {
+	requiredFields := map[string]bool{"total": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	return nil
+}
+`,
+		},
+		{
+			name: "doc comments",
+			input: `openapi: 3.0.0
+info:
+  title: Docs
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      summary: Get a pet.
+      description: |
+        Returns the pet with the given id.
+        Deleted pets are not returned.
+      deprecated: true
+      parameters:
+        - name: id
+          in: path
+          required: true
+          description: Pet identifier.
+          schema:
+            type: string
+            pattern: ^[0-9]+$
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      description: A pet in the store.
+      required: [name]
+      properties:
+        name:
+          type: string
+          description: Name of the pet.
+          minLength: 1
+          maxLength: 64
+          example: Rex
+        tag:
+          type: string
+          deprecated: true
+          default: dog
+`,
+			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+import "encoding/json"
+
+type GetPetsIDPathParams struct {
+	// Pet identifier.
+	//
+	// Constraints: pattern ^[0-9]+$.
+	ID string ` + "`json:\"id\" validate:\"required,pattern=^[0-9]+$\"`" + `
+}
+type GetPetsIDRequest struct {
+	Path GetPetsIDPathParams
+}
+type GetPetsIDResponse200 struct {
+	Body Pet
+}
+type GetPetsIDResponse struct {
+	StatusCode  int
+	Response200 *GetPetsIDResponse200
+}
+
+// A pet in the store.
+type Pet struct {
+	// Name of the pet.
+	//
+	// Constraints: min length 1, max length 64.
+	// Example: "Rex".
+	Name string ` + "`json:\"name\" validate:\"min=1,max=64\"`" + `
+	// Default: "dog".
+	//
+	// Deprecated: marked as deprecated in the OpenAPI specification.
+	Tag *string ` + "`json:\"tag,omitempty\" validate:\"omitempty\"`" + `
+}
+
+func (m *Pet) UnmarshalJSON(data []byte) error {
+	type plain Pet
+	value := plain{}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if value.Tag == nil {
+		defaultTag := string("dog")
+		value.Tag = &defaultTag
+	}
+	*m = Pet(value)
+	return nil
+}
+`,
+			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagename
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-faster/errors"
+	"github.com/go-playground/validator/v10"
+	"packagename/imports/models"
+)
+
+// GetPetsIDHandler handles GET /pets/{id}.
+//
+// Deprecated: marked as deprecated in the OpenAPI specification.
+type GetPetsIDHandler interface {
+	// Get a pet.
+	//
+	// Returns the pet with the given id.
+	// Deleted pets are not returned.
+	//
+	// Deprecated: marked as deprecated in the OpenAPI specification.
+	HandleGetPetsID(ctx context.Context, r packagenamemodels.GetPetsIDRequest) (*packagenamemodels.GetPetsIDResponse, error)
+}
+
+var patternsCache sync.Map
+
+type Handler struct {
+	validator *validator.Validate
+	getPetsID GetPetsIDHandler
+}
+
+func NewHandler(getPetsID GetPetsIDHandler) *Handler {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	_ = v.RegisterValidation("pattern", validatePattern)
+	_ = v.RegisterValidation("multipleOf", validateMultipleOf)
+	return &Handler{validator: v, getPetsID: getPetsID}
+}
+func (h *Handler) AddRoutes(router chi.Router) {
+	router.Get("/pets/{id}", h.handleGetPetsID)
+}
+func (h *Handler) parseGetPetsIDPathParams(r *http.Request) (*packagenamemodels.GetPetsIDPathParams, error) {
+	var pathParams packagenamemodels.GetPetsIDPathParams
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		return nil, errors.New("id path param is required")
+	}
+	pathParams.ID = id
+	err := h.validator.Struct(pathParams)
+	if err != nil {
+		return nil, err
+	}
+	return &pathParams, nil
+}
+func (h *Handler) parseGetPetsIDRequest(r *http.Request) (*packagenamemodels.GetPetsIDRequest, error) {
+	pathParams, err := h.parseGetPetsIDPathParams(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.GetPetsIDRequest{Path: *pathParams}, nil
+}
+func GetPetsID200Response(body packagenamemodels.Pet) *packagenamemodels.GetPetsIDResponse {
+	return &packagenamemodels.GetPetsIDResponse{StatusCode: 200, Response200: &packagenamemodels.GetPetsIDResponse200{Body: body}}
+}
+func (h *Handler) writeGetPetsID200Response(w http.ResponseWriter, r *packagenamemodels.GetPetsIDResponse200) {
+	var err error
+	err = json.NewEncoder(w).Encode(r.Body)
+	if err != nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+}
+func (h *Handler) writeGetPetsIDResponse(w http.ResponseWriter, response *packagenamemodels.GetPetsIDResponse) {
+	switch response.StatusCode {
+	case 200:
+		if response.Response200 == nil {
+			http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(response.StatusCode)
+		h.writeGetPetsID200Response(w, response.Response200)
+		return
+	}
+	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+}
+func (h *Handler) handleGetPetsIDRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parseGetPetsIDRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	response, err := h.getPetsID.HandleGetPetsID(ctx, *request)
+	if err != nil || response == nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	h.writeGetPetsIDResponse(w, response)
+	return
+}
+func (h *Handler) handleGetPetsID(w http.ResponseWriter, r *http.Request) {
+	switch r.Header.Get("Content-Type") {
+	case "application/json":
+		h.handleGetPetsIDRequest(w, r)
+		return
+	case "":
+		h.handleGetPetsIDRequest(w, r)
+		return
+	default:
+		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
+		return
+	}
+}
+func containsNull(data json.RawMessage) bool {
+	var temp any
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return false
+	}
+	return temp == nil
+}
+func ValidatePetJSON(jsonData json.RawMessage) error {
+	return validatePetJSON(jsonData, 0, false)
+}
+func validatePetJSON(jsonData json.RawMessage, _ int, _ bool) error {
+	requiredFields := map[string]bool{"name": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	return nil
+}
+func validatePattern(fl validator.FieldLevel) bool {
+	pattern := fl.Param()
+	cached, ok := patternsCache.Load(pattern)
+	if !ok {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return false
+		}
+		cached, _ = patternsCache.LoadOrStore(pattern, compiled)
+	}
+	re, ok := cached.(*regexp.Regexp)
+	return ok && re.MatchString(fl.Field().String())
+}
+func validateMultipleOf(fl validator.FieldLevel) bool {
+	divisor, err := strconv.ParseFloat(fl.Param(), 64)
+	if err != nil || divisor == 0 {
+		return false
+	}
+	field := fl.Field()
+	var value float64
+	switch {
+	case field.CanInt():
+		value = float64(field.Int())
+	case field.CanUint():
+		value = float64(field.Uint())
+	case field.CanFloat():
+		value = field.Float()
+	default:
+		return false
+	}
+	quotient := value / divisor
+	return math.Abs(quotient-math.Round(quotient)) < 1e-9
+}
+`,
+		},
+		{
+			name: "recursive schemas",
+			input: `openapi: 3.0.0
+info:
+  title: Rec
+  version: 1.0.0
+paths:
+  /comments:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Comment'
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Comment'
+components:
+  schemas:
+    Comment:
+      type: object
+      required: [text, author, parent]
+      properties:
+        text:
+          type: string
+        author:
+          $ref: '#/components/schemas/User'
+        parent:
+          $ref: '#/components/schemas/Comment'
+        replies:
+          type: array
+          items:
+            $ref: '#/components/schemas/Comment'
+    User:
+      type: object
+      required: [name, best]
+      properties:
+        name:
+          type: string
+        best:
+          $ref: '#/components/schemas/Comment'
+    Forest:
+      type: array
+      items:
+        $ref: '#/components/schemas/Forest'
+`,
+			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+type PostCommentsRequest struct {
+	Body Comment
+}
+type PostCommentsResponse200 struct {
+	Body Comment
+}
+type PostCommentsResponse struct {
+	StatusCode  int
+	Response200 *PostCommentsResponse200
+}
+type CommentReplies []Comment
+type Comment struct {
+	Author  *User           ` + "`json:\"author\"`" + `
+	Parent  *Comment        ` + "`json:\"parent\"`" + `
+	Replies *CommentReplies ` + "`json:\"replies,omitempty\" validate:\"omitempty,dive\"`" + `
+	Text    string          ` + "`json:\"text\"`" + `
+}
+type Forest []Forest
+type User struct {
+	Best *Comment ` + "`json:\"best\"`" + `
+	Name string   ` + "`json:\"name\"`" + `
+}
+`,
+			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagename
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-faster/errors"
+	"github.com/go-playground/validator/v10"
+	"packagename/imports/models"
+)
+
+type PostCommentsHandler interface {
+	HandlePostComments(ctx context.Context, r packagenamemodels.PostCommentsRequest) (*packagenamemodels.PostCommentsResponse, error)
+}
+
+const maxValidationDepth = 64
+
+type Handler struct {
+	validator    *validator.Validate
+	postComments PostCommentsHandler
+}
+
+func NewHandler(postComments PostCommentsHandler) *Handler {
+	return &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), postComments: postComments}
+}
+func (h *Handler) AddRoutes(router chi.Router) {
+	router.Post("/comments", h.handlePostComments)
+}
+func (h *Handler) parsePostCommentsRequestBody(r *http.Request) (*packagenamemodels.Comment, error) {
+	var bodyJSON json.RawMessage
+	err := json.NewDecoder(r.Body).Decode(&bodyJSON)
+	if err != nil {
+		return nil, err
+	}
+	err = validateCommentJSON(bodyJSON, 0, true)
+	if err != nil {
+		return nil, err
+	}
+	var body packagenamemodels.Comment
+	err = json.Unmarshal(bodyJSON, &body)
+	if err != nil {
+		return nil, err
+	}
+	err = h.validator.Struct(body)
+	if err != nil {
+		return nil, err
+	}
+	return &body, nil
+}
+func (h *Handler) parsePostCommentsRequest(r *http.Request) (*packagenamemodels.PostCommentsRequest, error) {
+	body, err := h.parsePostCommentsRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.PostCommentsRequest{Body: *body}, nil
+}
+func PostComments200Response(body packagenamemodels.Comment) *packagenamemodels.PostCommentsResponse {
+	return &packagenamemodels.PostCommentsResponse{StatusCode: 200, Response200: &packagenamemodels.PostCommentsResponse200{Body: body}}
+}
+func (h *Handler) writePostComments200Response(w http.ResponseWriter, r *packagenamemodels.PostCommentsResponse200) {
+	var err error
+	err = json.NewEncoder(w).Encode(r.Body)
+	if err != nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+}
+func (h *Handler) writePostCommentsResponse(w http.ResponseWriter, response *packagenamemodels.PostCommentsResponse) {
+	switch response.StatusCode {
+	case 200:
+		if response.Response200 == nil {
+			http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(response.StatusCode)
+		h.writePostComments200Response(w, response.Response200)
+		return
+	}
+	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+}
+func (h *Handler) handlePostCommentsRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parsePostCommentsRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	response, err := h.postComments.HandlePostComments(ctx, *request)
+	if err != nil || response == nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	h.writePostCommentsResponse(w, response)
+	return
+}
+func (h *Handler) handlePostComments(w http.ResponseWriter, r *http.Request) {
+	switch r.Header.Get("Content-Type") {
+	case "application/json":
+		h.handlePostCommentsRequest(w, r)
+		return
+	case "":
+		h.handlePostCommentsRequest(w, r)
+		return
+	default:
+		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
+		return
+	}
+}
+func containsNull(data json.RawMessage) bool {
+	var temp any
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return false
+	}
+	return temp == nil
+}
+func ValidateCommentRepliesJSON(jsonData json.RawMessage) error {
+	return validateCommentRepliesJSON(jsonData, 0, false)
+}
+func validateCommentRepliesJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var arr []json.RawMessage
+	err := json.Unmarshal(jsonData, &arr)
+	if err != nil {
+		return err
+	}
+	for index, obj := range arr {
+		if containsNull(obj) {
+			return errors.Errorf("item at index %d cannot be null", index)
+		}
+		err = validateCommentJSON(obj, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating object at index %d", index)
+		}
+	}
+	return nil
+}
+func ValidateCommentJSON(jsonData json.RawMessage) error {
+	return validateCommentJSON(jsonData, 0, false)
+}
+func validateCommentJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	requiredFields := map[string]bool{"author": true, "parent": true, "text": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	val, exists = obj["author"]
+	if exists && !containsNull(val) {
+		err = validateUserJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field author is not valid")
+		}
+	}
+	val, exists = obj["parent"]
+	if exists && !containsNull(val) {
+		err = validateCommentJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field parent is not valid")
+		}
+	}
+	val, exists = obj["replies"]
+	if exists && !containsNull(val) {
+		err = validateCommentRepliesJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field replies is not valid")
+		}
+	}
+	return nil
+}
+func ValidateUserJSON(jsonData json.RawMessage) error {
+	return validateUserJSON(jsonData, 0, false)
+}
+func validateUserJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	requiredFields := map[string]bool{"best": true, "name": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	val, exists = obj["best"]
+	if exists && !containsNull(val) {
+		err = validateCommentJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field best is not valid")
+		}
+	}
+	return nil
+}
+`,
+		},
+		{
+			name: "maps, allOf and nullable items",
+			input: `openapi: 3.0.0
+info:
+  title: Maps
+  version: 1.0.0
+paths:
+  /inventory:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Inventory'
+      responses:
+        '204':
+          description: OK
+components:
+  schemas:
+    Item:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+    Named:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          minLength: 1
+    Inventory:
+      allOf:
+        - $ref: '#/components/schemas/Named'
+        - type: object
+          required: [stock]
+          properties:
+            stock:
+              type: object
+              minProperties: 1
+              additionalProperties:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Item'
+            labels:
+              type: object
+              additionalProperties:
+                type: string
+                maxLength: 10
+            scores:
+              type: array
+              items:
+                type: integer
+                nullable: true
+                minimum: 0
+            extra:
+              type: object
+              additionalProperties: true
+            grid:
+              type: array
+              items:
+                type: object
+                additionalProperties:
+                  type: object
+                  nullable: true
+                  properties:
+                    value:
+                      type: integer
+            owner:
+              nullable: true
+              allOf:
+                - $ref: '#/components/schemas/Named'
+`,
+			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+type PostInventoryRequest struct {
+	Body Inventory
+}
+type PostInventoryResponse204 struct {
+}
+type PostInventoryResponse struct {
+	StatusCode  int
+	Response204 *PostInventoryResponse204
+}
+type InventoryExtra map[string]any
+type InventoryGridItemValue struct {
+	Value *int ` + "`json:\"value,omitempty\" validate:\"omitempty\"`" + `
+}
+type InventoryGridItem map[string]*InventoryGridItemValue
+type InventoryGrid []InventoryGridItem
+type InventoryLabels map[string]string
+type InventoryScores []*int
+type InventoryStockValue []Item
+type InventoryStock map[string]InventoryStockValue
+type Inventory struct {
+	Extra  *InventoryExtra  ` + "`json:\"extra,omitempty\" validate:\"omitempty\"`" + `
+	Grid   *InventoryGrid   ` + "`json:\"grid,omitempty\" validate:\"omitempty,dive,dive\"`" + `
+	Labels *InventoryLabels ` + "`json:\"labels,omitempty\" validate:\"omitempty,dive,max=10\"`" + `
+	// Constraints: min length 1.
+	Name   string           ` + "`json:\"name\" validate:\"min=1\"`" + `
+	Owner  *Named           ` + "`json:\"owner,omitempty\" validate:\"omitempty\"`" + `
+	Scores *InventoryScores ` + "`json:\"scores,omitempty\" validate:\"omitempty,dive,omitnil,min=0\"`" + `
+	Stock  InventoryStock   ` + "`json:\"stock\" validate:\"min=1,dive,dive\"`" + `
+}
+type Item struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+type Named struct {
+	// Constraints: min length 1.
+	Name string ` + "`json:\"name\" validate:\"min=1\"`" + `
+}
+`,
+			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagename
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-faster/errors"
+	"github.com/go-playground/validator/v10"
+	"packagename/imports/models"
+)
+
+type PostInventoryHandler interface {
+	HandlePostInventory(ctx context.Context, r packagenamemodels.PostInventoryRequest) (*packagenamemodels.PostInventoryResponse, error)
+}
+
+const maxValidationDepth = 64
+
+type Handler struct {
+	validator     *validator.Validate
+	postInventory PostInventoryHandler
+}
+
+func NewHandler(postInventory PostInventoryHandler) *Handler {
+	return &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), postInventory: postInventory}
+}
+func (h *Handler) AddRoutes(router chi.Router) {
+	router.Post("/inventory", h.handlePostInventory)
+}
+func (h *Handler) parsePostInventoryRequestBody(r *http.Request) (*packagenamemodels.Inventory, error) {
+	var bodyJSON json.RawMessage
+	err := json.NewDecoder(r.Body).Decode(&bodyJSON)
+	if err != nil {
+		return nil, err
+	}
+	err = validateInventoryJSON(bodyJSON, 0, true)
+	if err != nil {
+		return nil, err
+	}
+	var body packagenamemodels.Inventory
+	err = json.Unmarshal(bodyJSON, &body)
+	if err != nil {
+		return nil, err
+	}
+	err = h.validator.Struct(body)
+	if err != nil {
+		return nil, err
+	}
+	return &body, nil
+}
+func (h *Handler) parsePostInventoryRequest(r *http.Request) (*packagenamemodels.PostInventoryRequest, error) {
+	body, err := h.parsePostInventoryRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.PostInventoryRequest{Body: *body}, nil
+}
+func PostInventory204Response() *packagenamemodels.PostInventoryResponse {
+	return &packagenamemodels.PostInventoryResponse{StatusCode: 204, Response204: &packagenamemodels.PostInventoryResponse204{}}
+}
+func (h *Handler) writePostInventory204Response(w http.ResponseWriter, r *packagenamemodels.PostInventoryResponse204) {
+}
+func (h *Handler) writePostInventoryResponse(w http.ResponseWriter, response *packagenamemodels.PostInventoryResponse) {
+	switch response.StatusCode {
+	case 204:
+		if response.Response204 == nil {
+			http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(response.StatusCode)
+		h.writePostInventory204Response(w, response.Response204)
+		return
+	}
+	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+}
+func (h *Handler) handlePostInventoryRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parsePostInventoryRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	response, err := h.postInventory.HandlePostInventory(ctx, *request)
+	if err != nil || response == nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	h.writePostInventoryResponse(w, response)
+	return
+}
+func (h *Handler) handlePostInventory(w http.ResponseWriter, r *http.Request) {
+	switch r.Header.Get("Content-Type") {
+	case "application/json":
+		h.handlePostInventoryRequest(w, r)
+		return
+	case "":
+		h.handlePostInventoryRequest(w, r)
+		return
+	default:
+		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
+		return
+	}
+}
+func ValidateInventoryExtraJSON(jsonData json.RawMessage) error {
+	return validateInventoryExtraJSON(jsonData, 0, false)
+}
+func validateInventoryExtraJSON(_ json.RawMessage, _ int, _ bool) error {
+	return nil
+}
+func ValidateInventoryGridItemValueJSON(jsonData json.RawMessage) error {
+	return validateInventoryGridItemValueJSON(jsonData, 0, false)
+}
+func validateInventoryGridItemValueJSON(_ json.RawMessage, _ int, _ bool) error {
+	return nil
+}
+func containsNull(data json.RawMessage) bool {
+	var temp any
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return false
+	}
+	return temp == nil
+}
+func ValidateInventoryGridItemJSON(jsonData json.RawMessage) error {
+	return validateInventoryGridItemJSON(jsonData, 0, false)
+}
+func validateInventoryGridItemJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	for key, val := range obj {
+		if !containsNull(val) {
+			err = validateInventoryGridItemValueJSON(val, depth+1, request)
+			if err != nil {
+				return errors.Wrapf(err, "error validating value of key %s", key)
+			}
+		}
+	}
+	return nil
+}
+func ValidateInventoryGridJSON(jsonData json.RawMessage) error {
+	return validateInventoryGridJSON(jsonData, 0, false)
+}
+func validateInventoryGridJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var arr []json.RawMessage
+	err := json.Unmarshal(jsonData, &arr)
+	if err != nil {
+		return err
+	}
+	for index, obj := range arr {
+		if containsNull(obj) {
+			return errors.Errorf("item at index %d cannot be null", index)
+		}
+		err = validateInventoryGridItemJSON(obj, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating object at index %d", index)
+		}
+	}
+	return nil
+}
+func ValidateInventoryLabelsJSON(jsonData json.RawMessage) error {
+	return validateInventoryLabelsJSON(jsonData, 0, false)
+}
+func validateInventoryLabelsJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	for key, val := range obj {
+		if containsNull(val) {
+			return errors.Errorf("value of key %s cannot be null", key)
+		}
+	}
+	return nil
+}
+func ValidateInventoryStockValueJSON(jsonData json.RawMessage) error {
+	return validateInventoryStockValueJSON(jsonData, 0, false)
+}
+func validateInventoryStockValueJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var arr []json.RawMessage
+	err := json.Unmarshal(jsonData, &arr)
+	if err != nil {
+		return err
+	}
+	for index, obj := range arr {
+		if containsNull(obj) {
+			return errors.Errorf("item at index %d cannot be null", index)
+		}
+		err = validateItemJSON(obj, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating object at index %d", index)
+		}
+	}
+	return nil
+}
+func ValidateInventoryStockJSON(jsonData json.RawMessage) error {
+	return validateInventoryStockJSON(jsonData, 0, false)
+}
+func validateInventoryStockJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	for key, val := range obj {
+		if containsNull(val) {
+			return errors.Errorf("value of key %s cannot be null", key)
+		}
+		err = validateInventoryStockValueJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating value of key %s", key)
+		}
+	}
+	return nil
+}
+func ValidateInventoryJSON(jsonData json.RawMessage) error {
+	return validateInventoryJSON(jsonData, 0, false)
+}
+func validateInventoryJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	requiredFields := map[string]bool{"name": true, "stock": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	val, exists = obj["extra"]
+	if exists && !containsNull(val) {
+		err = validateInventoryExtraJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field extra is not valid")
+		}
+	}
+	val, exists = obj["grid"]
+	if exists && !containsNull(val) {
+		err = validateInventoryGridJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field grid is not valid")
+		}
+	}
+	val, exists = obj["labels"]
+	if exists && !containsNull(val) {
+		err = validateInventoryLabelsJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field labels is not valid")
+		}
+	}
+	val, exists = obj["owner"]
+	if exists && !containsNull(val) {
+		err = validateNamedJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field owner is not valid")
+		}
+	}
+	val, exists = obj["stock"]
+	if exists && !containsNull(val) {
+		err = validateInventoryStockJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field stock is not valid")
+		}
+	}
+	return nil
+}
+func ValidateItemJSON(jsonData json.RawMessage) error {
+	return validateItemJSON(jsonData, 0, false)
+}
+func validateItemJSON(jsonData json.RawMessage, _ int, _ bool) error {
+	requiredFields := map[string]bool{"id": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	return nil
+}
+func ValidateNamedJSON(jsonData json.RawMessage) error {
+	return validateNamedJSON(jsonData, 0, false)
+}
+func validateNamedJSON(jsonData json.RawMessage, _ int, _ bool) error {
+	requiredFields := map[string]bool{"name": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	return nil
+}
+`,
+		},
+		{
+			name: "arrays of arrays of objects",
+			input: `openapi: 3.0.0
+info:
+  title: Nest
+  version: 1.0.0
+paths:
+  /grid:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Grid'
+      responses:
+        '204':
+          description: OK
+components:
+  schemas:
+    Grid:
+      type: object
+      required: [cells]
+      properties:
+        cells:
+          type: array
+          items:
+            type: array
+            items:
+              type: object
+              required: [value]
+              properties:
+                value:
+                  type: integer
+        rows:
+          type: array
+          items:
+            type: array
+            items:
+              $ref: '#/components/schemas/Cell'
+    Cell:
+      type: object
+      required: [value]
+      properties:
+        value:
+          type: integer
+`,
+			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+type PostGridRequest struct {
+	Body Grid
+}
+type PostGridResponse204 struct {
+}
+type PostGridResponse struct {
+	StatusCode  int
+	Response204 *PostGridResponse204
+}
+type Cell struct {
+	Value int ` + "`json:\"value\"`" + `
+}
+type GridCellsItemItem struct {
+	Value int ` + "`json:\"value\"`" + `
+}
+type GridCellsItem []GridCellsItemItem
+type GridCells []GridCellsItem
+type GridRowsItem []Cell
+type GridRows []GridRowsItem
+type Grid struct {
+	Cells GridCells ` + "`json:\"cells\" validate:\"dive,dive\"`" + `
+	Rows  *GridRows ` + "`json:\"rows,omitempty\" validate:\"omitempty,dive,dive\"`" + `
+}
+`,
+			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagename
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-faster/errors"
+	"github.com/go-playground/validator/v10"
+	"packagename/imports/models"
+)
+
+type PostGridHandler interface {
+	HandlePostGrid(ctx context.Context, r packagenamemodels.PostGridRequest) (*packagenamemodels.PostGridResponse, error)
+}
+
+const maxValidationDepth = 64
+
+type Handler struct {
+	validator *validator.Validate
+	postGrid  PostGridHandler
+}
+
+func NewHandler(postGrid PostGridHandler) *Handler {
+	return &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), postGrid: postGrid}
+}
+func (h *Handler) AddRoutes(router chi.Router) {
+	router.Post("/grid", h.handlePostGrid)
+}
+func (h *Handler) parsePostGridRequestBody(r *http.Request) (*packagenamemodels.Grid, error) {
+	var bodyJSON json.RawMessage
+	err := json.NewDecoder(r.Body).Decode(&bodyJSON)
+	if err != nil {
+		return nil, err
+	}
+	err = validateGridJSON(bodyJSON, 0, true)
+	if err != nil {
+		return nil, err
+	}
+	var body packagenamemodels.Grid
+	err = json.Unmarshal(bodyJSON, &body)
+	if err != nil {
+		return nil, err
+	}
+	err = h.validator.Struct(body)
+	if err != nil {
+		return nil, err
+	}
+	return &body, nil
+}
+func (h *Handler) parsePostGridRequest(r *http.Request) (*packagenamemodels.PostGridRequest, error) {
+	body, err := h.parsePostGridRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.PostGridRequest{Body: *body}, nil
+}
+func PostGrid204Response() *packagenamemodels.PostGridResponse {
+	return &packagenamemodels.PostGridResponse{StatusCode: 204, Response204: &packagenamemodels.PostGridResponse204{}}
+}
+func (h *Handler) writePostGrid204Response(w http.ResponseWriter, r *packagenamemodels.PostGridResponse204) {
+}
+func (h *Handler) writePostGridResponse(w http.ResponseWriter, response *packagenamemodels.PostGridResponse) {
+	switch response.StatusCode {
+	case 204:
+		if response.Response204 == nil {
+			http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(response.StatusCode)
+		h.writePostGrid204Response(w, response.Response204)
+		return
+	}
+	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+}
+func (h *Handler) handlePostGridRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parsePostGridRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	response, err := h.postGrid.HandlePostGrid(ctx, *request)
+	if err != nil || response == nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	h.writePostGridResponse(w, response)
+	return
+}
+func (h *Handler) handlePostGrid(w http.ResponseWriter, r *http.Request) {
+	switch r.Header.Get("Content-Type") {
+	case "application/json":
+		h.handlePostGridRequest(w, r)
+		return
+	case "":
+		h.handlePostGridRequest(w, r)
+		return
+	default:
+		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
+		return
+	}
+}
+func containsNull(data json.RawMessage) bool {
+	var temp any
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return false
+	}
+	return temp == nil
+}
+func ValidateCellJSON(jsonData json.RawMessage) error {
+	return validateCellJSON(jsonData, 0, false)
+}
+func validateCellJSON(jsonData json.RawMessage, _ int, _ bool) error {
+	requiredFields := map[string]bool{"value": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	return nil
+}
+func ValidateGridCellsItemItemJSON(jsonData json.RawMessage) error {
+	return validateGridCellsItemItemJSON(jsonData, 0, false)
+}
+func validateGridCellsItemItemJSON(jsonData json.RawMessage, _ int, _ bool) error {
+	requiredFields := map[string]bool{"value": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	return nil
+}
+func ValidateGridCellsItemJSON(jsonData json.RawMessage) error {
+	return validateGridCellsItemJSON(jsonData, 0, false)
+}
+func validateGridCellsItemJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var arr []json.RawMessage
+	err := json.Unmarshal(jsonData, &arr)
+	if err != nil {
+		return err
+	}
+	for index, obj := range arr {
+		if containsNull(obj) {
+			return errors.Errorf("item at index %d cannot be null", index)
+		}
+		err = validateGridCellsItemItemJSON(obj, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating object at index %d", index)
+		}
+	}
+	return nil
+}
+func ValidateGridCellsJSON(jsonData json.RawMessage) error {
+	return validateGridCellsJSON(jsonData, 0, false)
+}
+func validateGridCellsJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var arr []json.RawMessage
+	err := json.Unmarshal(jsonData, &arr)
+	if err != nil {
+		return err
+	}
+	for index, obj := range arr {
+		if containsNull(obj) {
+			return errors.Errorf("item at index %d cannot be null", index)
+		}
+		err = validateGridCellsItemJSON(obj, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating object at index %d", index)
+		}
+	}
+	return nil
+}
+func ValidateGridRowsItemJSON(jsonData json.RawMessage) error {
+	return validateGridRowsItemJSON(jsonData, 0, false)
+}
+func validateGridRowsItemJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var arr []json.RawMessage
+	err := json.Unmarshal(jsonData, &arr)
+	if err != nil {
+		return err
+	}
+	for index, obj := range arr {
+		if containsNull(obj) {
+			return errors.Errorf("item at index %d cannot be null", index)
+		}
+		err = validateCellJSON(obj, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating object at index %d", index)
+		}
+	}
+	return nil
+}
+func ValidateGridRowsJSON(jsonData json.RawMessage) error {
+	return validateGridRowsJSON(jsonData, 0, false)
+}
+func validateGridRowsJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var arr []json.RawMessage
+	err := json.Unmarshal(jsonData, &arr)
+	if err != nil {
+		return err
+	}
+	for index, obj := range arr {
+		if containsNull(obj) {
+			return errors.Errorf("item at index %d cannot be null", index)
+		}
+		err = validateGridRowsItemJSON(obj, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating object at index %d", index)
+		}
+	}
+	return nil
+}
+func ValidateGridJSON(jsonData json.RawMessage) error {
+	return validateGridJSON(jsonData, 0, false)
+}
+func validateGridJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	requiredFields := map[string]bool{"cells": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	val, exists = obj["cells"]
+	if exists && !containsNull(val) {
+		err = validateGridCellsJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field cells is not valid")
+		}
+	}
+	val, exists = obj["rows"]
+	if exists && !containsNull(val) {
+		err = validateGridRowsJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field rows is not valid")
+		}
+	}
+	return nil
+}
+`,
+		},
+		{
+			name: "readOnly and writeOnly fields",
+			input: `openapi: 3.0.0
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+components:
+  schemas:
+    User:
+      type: object
+      required: [id, createdAt, name, password]
+      properties:
+        id:
+          type: string
+          minLength: 1
+          readOnly: true
+        createdAt:
+          type: string
+          format: date-time
+          readOnly: true
+        name:
+          type: string
+        password:
+          type: string
+          minLength: 8
+          writeOnly: true
+`,
+			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+import "time"
+
+type PostUsersRequest struct {
+	Body User
+}
+type PostUsersResponse201 struct {
+	Body User
+}
+type PostUsersResponse struct {
+	StatusCode  int
+	Response201 *PostUsersResponse201
+}
+type User struct {
+	// Constraints: format date-time.
+	// Read-only: rejected in requests.
+	Createdat *time.Time ` + "`json:\"createdAt,omitempty\" validate:\"omitempty\"`" + `
+	// Constraints: min length 1.
+	// Read-only: rejected in requests.
+	ID   *string ` + "`json:\"id,omitempty\" validate:\"omitempty,min=1\"`" + `
+	Name string  ` + "`json:\"name\"`" + `
+	// Constraints: min length 8.
+	// Write-only: not expected in responses.
+	Password *string ` + "`json:\"password,omitempty\" validate:\"omitempty,min=8\"`" + `
+}
+`,
+			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagename
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-faster/errors"
+	"github.com/go-playground/validator/v10"
+	"packagename/imports/models"
+)
+
+type PostUsersHandler interface {
+	HandlePostUsers(ctx context.Context, r packagenamemodels.PostUsersRequest) (*packagenamemodels.PostUsersResponse, error)
+}
+type Handler struct {
+	validator *validator.Validate
+	postUsers PostUsersHandler
+}
+
+func NewHandler(postUsers PostUsersHandler) *Handler {
+	return &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), postUsers: postUsers}
+}
+func (h *Handler) AddRoutes(router chi.Router) {
+	router.Post("/users", h.handlePostUsers)
+}
+func (h *Handler) parsePostUsersRequestBody(r *http.Request) (*packagenamemodels.User, error) {
+	var bodyJSON json.RawMessage
+	err := json.NewDecoder(r.Body).Decode(&bodyJSON)
+	if err != nil {
+		return nil, err
+	}
+	err = validateUserJSON(bodyJSON, 0, true)
+	if err != nil {
+		return nil, err
+	}
+	var body packagenamemodels.User
+	err = json.Unmarshal(bodyJSON, &body)
+	if err != nil {
+		return nil, err
+	}
+	err = h.validator.Struct(body)
+	if err != nil {
+		return nil, err
+	}
+	return &body, nil
+}
+func (h *Handler) parsePostUsersRequest(r *http.Request) (*packagenamemodels.PostUsersRequest, error) {
+	body, err := h.parsePostUsersRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.PostUsersRequest{Body: *body}, nil
+}
+func PostUsers201Response(body packagenamemodels.User) *packagenamemodels.PostUsersResponse {
+	return &packagenamemodels.PostUsersResponse{StatusCode: 201, Response201: &packagenamemodels.PostUsersResponse201{Body: body}}
+}
+func (h *Handler) writePostUsers201Response(w http.ResponseWriter, r *packagenamemodels.PostUsersResponse201) {
+	var err error
+	err = json.NewEncoder(w).Encode(r.Body)
+	if err != nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+}
+func (h *Handler) writePostUsersResponse(w http.ResponseWriter, response *packagenamemodels.PostUsersResponse) {
+	switch response.StatusCode {
+	case 201:
+		if response.Response201 == nil {
+			http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(response.StatusCode)
+		h.writePostUsers201Response(w, response.Response201)
+		return
+	}
+	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+}
+func (h *Handler) handlePostUsersRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parsePostUsersRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	response, err := h.postUsers.HandlePostUsers(ctx, *request)
+	if err != nil || response == nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	h.writePostUsersResponse(w, response)
+	return
+}
+func (h *Handler) handlePostUsers(w http.ResponseWriter, r *http.Request) {
+	switch r.Header.Get("Content-Type") {
+	case "application/json":
+		h.handlePostUsersRequest(w, r)
+		return
+	case "":
+		h.handlePostUsersRequest(w, r)
+		return
+	default:
+		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
+		return
+	}
+}
+func containsNull(data json.RawMessage) bool {
+	var temp any
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return false
+	}
+	return temp == nil
+}
+func ValidateUserJSON(jsonData json.RawMessage) error {
+	return validateUserJSON(jsonData, 0, false)
+}
+func validateUserJSON(jsonData json.RawMessage, _ int, request bool) error {
+	requiredFields := map[string]bool{"name": true}
+	if request {
+		requiredFields["password"] = true
+	}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	if request {
+		for _, field := range []string{"createdAt", "id"} {
+			if _, exists = obj[field]; exists {
+				return errors.New("field " + field + " is read only")
+			}
+		}
+	}
+	return nil
+}
+`,
+		},
+		{
+			name: "openapi 3.1",
+			input: `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '204':
+          description: OK
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [kind, name, tag]
+      properties:
+        kind:
+          const: dog
+        name:
+          type: string
+          examples: [Rex]
+        tag:
+          type: [string, "null"]
+        age:
+          type: [integer, "null"]
+          exclusiveMinimum: 0
+        owner:
+          $ref: '#/components/schemas/Owner'
+          description: Owner of the pet.
+        previousOwner:
+          anyOf:
+            - $ref: '#/components/schemas/Owner'
+            - type: 'null'
+        photo:
+          type: string
+          contentEncoding: base64
+          contentMediaType: image/png
+    Owner:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          $comment: full name
+webhooks:
+  newPet:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '200':
+          description: OK
+`,
+			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+type PostPetsRequest struct {
+	Body Pet
+}
+type PostPetsResponse204 struct {
+}
+type PostPetsResponse struct {
+	StatusCode  int
+	Response204 *PostPetsResponse204
+}
+type Owner struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+type PetKind string
+
+const PetKindDog PetKind = "dog"
+
+func (v PetKind) IsValid() bool {
+	switch v {
+	case PetKindDog:
+		return true
+	}
+	return false
+}
+func AllPetKindValues() []PetKind {
+	return []PetKind{PetKindDog}
+}
+
+type Pet struct {
+	// Constraints: exclusive minimum 0.
+	Age  *int    ` + "`json:\"age,omitempty\" validate:\"omitempty,gt=0\"`" + `
+	Kind PetKind ` + "`json:\"kind\" validate:\"oneof=dog\"`" + `
+	// Example: "Rex".
+	Name  string ` + "`json:\"name\"`" + `
+	Owner *Owner ` + "`json:\"owner,omitempty\" validate:\"omitempty\"`" + `
+	// Constraints: format byte.
+	Photo         *[]byte ` + "`json:\"photo,omitempty\" validate:\"omitempty\"`" + `
+	Previousowner *Owner  ` + "`json:\"previousOwner,omitempty\" validate:\"omitempty\"`" + `
+	Tag           string  ` + "`json:\"tag\"`" + `
+}
+`,
+			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagename
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-faster/errors"
+	"github.com/go-playground/validator/v10"
+	"packagename/imports/models"
+)
+
+type PostPetsHandler interface {
+	HandlePostPets(ctx context.Context, r packagenamemodels.PostPetsRequest) (*packagenamemodels.PostPetsResponse, error)
+}
+
+const maxValidationDepth = 64
+
+type Handler struct {
+	validator *validator.Validate
+	postPets  PostPetsHandler
+}
+
+func NewHandler(postPets PostPetsHandler) *Handler {
+	return &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), postPets: postPets}
+}
+func (h *Handler) AddRoutes(router chi.Router) {
+	router.Post("/pets", h.handlePostPets)
+}
+func (h *Handler) parsePostPetsRequestBody(r *http.Request) (*packagenamemodels.Pet, error) {
+	var bodyJSON json.RawMessage
+	err := json.NewDecoder(r.Body).Decode(&bodyJSON)
+	if err != nil {
+		return nil, err
+	}
+	err = validatePetJSON(bodyJSON, 0, true)
+	if err != nil {
+		return nil, err
+	}
+	var body packagenamemodels.Pet
+	err = json.Unmarshal(bodyJSON, &body)
+	if err != nil {
+		return nil, err
+	}
+	err = h.validator.Struct(body)
+	if err != nil {
+		return nil, err
+	}
+	return &body, nil
+}
+func (h *Handler) parsePostPetsRequest(r *http.Request) (*packagenamemodels.PostPetsRequest, error) {
+	body, err := h.parsePostPetsRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.PostPetsRequest{Body: *body}, nil
+}
+func PostPets204Response() *packagenamemodels.PostPetsResponse {
+	return &packagenamemodels.PostPetsResponse{StatusCode: 204, Response204: &packagenamemodels.PostPetsResponse204{}}
+}
+func (h *Handler) writePostPets204Response(w http.ResponseWriter, r *packagenamemodels.PostPetsResponse204) {
+}
+func (h *Handler) writePostPetsResponse(w http.ResponseWriter, response *packagenamemodels.PostPetsResponse) {
+	switch response.StatusCode {
+	case 204:
+		if response.Response204 == nil {
+			http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(response.StatusCode)
+		h.writePostPets204Response(w, response.Response204)
+		return
+	}
+	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+}
+func (h *Handler) handlePostPetsRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parsePostPetsRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	response, err := h.postPets.HandlePostPets(ctx, *request)
+	if err != nil || response == nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	h.writePostPetsResponse(w, response)
+	return
+}
+func (h *Handler) handlePostPets(w http.ResponseWriter, r *http.Request) {
+	switch r.Header.Get("Content-Type") {
+	case "application/json":
+		h.handlePostPetsRequest(w, r)
+		return
+	case "":
+		h.handlePostPetsRequest(w, r)
+		return
+	default:
+		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
+		return
+	}
+}
+func containsNull(data json.RawMessage) bool {
+	var temp any
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return false
+	}
+	return temp == nil
+}
+func ValidateOwnerJSON(jsonData json.RawMessage) error {
+	return validateOwnerJSON(jsonData, 0, false)
+}
+func validateOwnerJSON(jsonData json.RawMessage, _ int, _ bool) error {
+	requiredFields := map[string]bool{"name": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	return nil
+}
+func ValidatePetJSON(jsonData json.RawMessage) error {
+	return validatePetJSON(jsonData, 0, false)
+}
+func validatePetJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	requiredFields := map[string]bool{"kind": true, "name": true, "tag": true}
+	nullableFields := map[string]bool{"tag": true}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	val, exists = obj["owner"]
+	if exists && !containsNull(val) {
+		err = validateOwnerJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field owner is not valid")
+		}
+	}
+	val, exists = obj["previousOwner"]
+	if exists && !containsNull(val) {
+		err = validateOwnerJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field previousOwner is not valid")
+		}
+	}
+	return nil
+}
+`,
+		},
+		{
+			name: "swagger 2.0",
+			input: `swagger: "2.0"
+info:
+  title: Legacy
+  version: 1.0.0
+consumes:
+  - application/json
+  - application/xml
+produces:
+  - application/json
+paths:
+  /pets/{id}:
+    put:
+      operationId: updatePet
+      parameters:
+        - name: id
+          in: path
+          type: string
+          required: true
+        - name: limit
+          in: query
+          type: string
+          minLength: 1
+        - name: body
+          in: body
+          required: true
+          schema:
+            $ref: '#/definitions/Pet'
+      responses:
+        "200":
+          description: OK
+          schema:
+            $ref: '#/definitions/Pet'
+          headers:
+            X-Request-Id:
+              type: string
+definitions:
+  Pet:
+    type: object
+    required: [name]
+    properties:
+      name:
+        type: string
+        maxLength: 10
+      tags:
+        type: array
+        items:
+          type: string
+`,
+			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+type UpdatepetPathParams struct {
+	ID string ` + "`json:\"id\" validate:\"required\"`" + `
+}
+type UpdatepetQueryParams struct {
+	// Constraints: min length 1.
+	Limit *string ` + "`json:\"limit,omitempty\" validate:\"omitempty,min=1\"`" + `
+}
+type UpdatepetRequest struct {
+	Path  UpdatepetPathParams
+	Query UpdatepetQueryParams
+	Body  Pet
+}
+type UpdatepetResponse200Headers struct {
+	XRequestID *string ` + "`json:\"X-Request-Id,omitempty\" validate:\"omitempty\"`" + `
+}
+type UpdatepetResponse200 struct {
+	Body    Pet
+	Headers UpdatepetResponse200Headers
+}
+type UpdatepetResponse struct {
+	StatusCode  int
+	Response200 *UpdatepetResponse200
+}
+type PetTags []string
+type Pet struct {
+	// Constraints: max length 10.
+	Name string   ` + "`json:\"name\" validate:\"max=10\"`" + `
+	Tags *PetTags ` + "`json:\"tags,omitempty\" validate:\"omitempty,dive\"`" + `
+}
+`,
+			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagename
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-faster/errors"
+	"github.com/go-playground/validator/v10"
+	"packagename/imports/models"
+)
+
+type UpdatepetHandler interface {
+	HandleUpdatepet(ctx context.Context, r packagenamemodels.UpdatepetRequest) (*packagenamemodels.UpdatepetResponse, error)
+}
+type Handler struct {
+	validator *validator.Validate
+	updatepet UpdatepetHandler
+}
+
+func NewHandler(updatepet UpdatepetHandler) *Handler {
+	return &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), updatepet: updatepet}
+}
+func (h *Handler) AddRoutes(router chi.Router) {
+	router.Put("/pets/{id}", h.handleUpdatepet)
+}
+func (h *Handler) parseUpdatepetPathParams(r *http.Request) (*packagenamemodels.UpdatepetPathParams, error) {
+	var pathParams packagenamemodels.UpdatepetPathParams
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		return nil, errors.New("id path param is required")
+	}
+	pathParams.ID = id
+	err := h.validator.Struct(pathParams)
+	if err != nil {
+		return nil, err
+	}
+	return &pathParams, nil
+}
+func (h *Handler) parseUpdatepetQueryParams(r *http.Request) (*packagenamemodels.UpdatepetQueryParams, error) {
+	var queryParams packagenamemodels.UpdatepetQueryParams
+	limit := r.URL.Query().Get("limit")
+	if limit != "" {
+		queryParams.Limit = &limit
+	}
+	err := h.validator.Struct(queryParams)
+	if err != nil {
+		return nil, err
+	}
+	return &queryParams, nil
+}
+func (h *Handler) parseUpdatepetRequestBody(r *http.Request) (*packagenamemodels.Pet, error) {
+	var bodyJSON json.RawMessage
+	err := json.NewDecoder(r.Body).Decode(&bodyJSON)
+	if err != nil {
+		return nil, err
+	}
+	err = validatePetJSON(bodyJSON, 0, true)
+	if err != nil {
+		return nil, err
+	}
+	var body packagenamemodels.Pet
+	err = json.Unmarshal(bodyJSON, &body)
+	if err != nil {
+		return nil, err
+	}
+	err = h.validator.Struct(body)
+	if err != nil {
+		return nil, err
+	}
+	return &body, nil
+}
+func (h *Handler) parseUpdatepetRequest(r *http.Request) (*packagenamemodels.UpdatepetRequest, error) {
+	pathParams, err := h.parseUpdatepetPathParams(r)
+	if err != nil {
+		return nil, err
+	}
+	queryParams, err := h.parseUpdatepetQueryParams(r)
+	if err != nil {
+		return nil, err
+	}
+	body, err := h.parseUpdatepetRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.UpdatepetRequest{Path: *pathParams, Query: *queryParams, Body: *body}, nil
+}
+func Updatepet200Response(body packagenamemodels.Pet, headers packagenamemodels.UpdatepetResponse200Headers) *packagenamemodels.UpdatepetResponse {
+	return &packagenamemodels.UpdatepetResponse{StatusCode: 200, Response200: &packagenamemodels.UpdatepetResponse200{Body: body, Headers: headers}}
+}
+func (h *Handler) writeUpdatepet200Response(w http.ResponseWriter, r *packagenamemodels.UpdatepetResponse200) {
+	var err error
+	err = json.NewEncoder(w).Encode(r.Body)
+	if err != nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+}
+func (h *Handler) writeUpdatepet200ResponseHeaders(w http.ResponseWriter, r *packagenamemodels.UpdatepetResponse200) {
+	headersJSON, err := json.Marshal(r.Headers)
+	if err != nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	var headers map[string]string
+	err = json.Unmarshal(headersJSON, &headers)
+	if err != nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	for key, value := range headers {
+		w.Header().Set(key, value)
+	}
+}
+func (h *Handler) writeUpdatepetResponse(w http.ResponseWriter, response *packagenamemodels.UpdatepetResponse) {
+	switch response.StatusCode {
+	case 200:
+		if response.Response200 == nil {
+			http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+			return
+		}
+		h.writeUpdatepet200ResponseHeaders(w, response.Response200)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(response.StatusCode)
+		h.writeUpdatepet200Response(w, response.Response200)
+		return
+	}
+	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+}
+func (h *Handler) handleUpdatepetRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parseUpdatepetRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	response, err := h.updatepet.HandleUpdatepet(ctx, *request)
+	if err != nil || response == nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	h.writeUpdatepetResponse(w, response)
+	return
+}
+func (h *Handler) handleUpdatepet(w http.ResponseWriter, r *http.Request) {
+	switch r.Header.Get("Content-Type") {
+	case "application/json":
+		h.handleUpdatepetRequest(w, r)
+		return
+	case "":
+		h.handleUpdatepetRequest(w, r)
+		return
+	default:
+		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
+		return
+	}
+}
+func containsNull(data json.RawMessage) bool {
+	var temp any
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return false
+	}
+	return temp == nil
+}
+func ValidatePetJSON(jsonData json.RawMessage) error {
+	return validatePetJSON(jsonData, 0, false)
+}
+func validatePetJSON(jsonData json.RawMessage, _ int, _ bool) error {
+	requiredFields := map[string]bool{"name": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	return nil
+}
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input := strings.NewReader(tc.input)
+			outputModels := &bytes.Buffer{}
+			outputHandlers := &bytes.Buffer{}
+			gen := generator.NewGenerator(&options.Options{
+				PackagePrefix: "packagename",
+			})
+			gen.PackageName = "packagename"
+			gen.ImportPrefix = "imports"
+			gen.ModelsImportPath = "packagename/imports/models"
+			err := gen.PrepareAndRead(input)
+			assert.NoError(t, err)
+			err = gen.GenerateFiles()
+			assert.NoError(t, err)
+			err = gen.WriteToOutput(outputModels, outputHandlers)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedModels, outputModels.String())
+			assert.Equal(t, tc.expectedHandlers, outputHandlers.String())
+		})
+	}
+}
+
+func TestGenerateCookies(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		input            string
+		expectedModels   string
+		expectedHandlers string
+	}{
+		{
+			name: "operation id",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example:
+    post:
+      operationId: op
+      summary: Example
+      parameters:
+        - name: cookie-field
+          in: cookie
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+`,
+			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+type OpCookies struct {
+	CookieField *string ` + "`json:\"cookie-field,omitempty\" validate:\"omitempty\"`" + `
+}
+type OpRequest struct {
+	Cookies OpCookies
+}
+type OpResponse200 struct {
+}
+type OpResponse struct {
+	StatusCode  int
+	Response200 *OpResponse200
+}
+`,
+			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagename
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-faster/errors"
+	"github.com/go-playground/validator/v10"
+	"packagename/imports/models"
+)
+
+// OpHandler handles POST /example.
+type OpHandler interface {
+	// Example
+	HandleOp(ctx context.Context, r packagenamemodels.OpRequest) (*packagenamemodels.OpResponse, error)
+}
+type Handler struct {
+	validator *validator.Validate
+	op        OpHandler
+}
+
+func NewHandler(op OpHandler) *Handler {
+	return &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), op: op}
+}
+func (h *Handler) AddRoutes(router chi.Router) {
+	router.Post("/example", h.handleOp)
+}
+func (h *Handler) parseOpCookies(r *http.Request) (*packagenamemodels.OpCookies, error) {
+	var cookies packagenamemodels.OpCookies
+	cookieField, err := r.Cookie("cookie-field")
+	if err != nil && !errors.Is(err, http.ErrNoCookie) {
+		return nil, err
+	}
+	if err == nil {
+		cookieFieldValue := cookieField.Value
+		cookies.CookieField = &cookieFieldValue
+	}
+	err = h.validator.Struct(cookies)
+	if err != nil {
+		return nil, err
+	}
+	return &cookies, nil
+}
+func (h *Handler) parseOpRequest(r *http.Request) (*packagenamemodels.OpRequest, error) {
+	cookieParams, err := h.parseOpCookies(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.OpRequest{Cookies: *cookieParams}, nil
+}
+func Op200Response() *packagenamemodels.OpResponse {
+	return &packagenamemodels.OpResponse{StatusCode: 200, Response200: &packagenamemodels.OpResponse200{}}
+}
+func (h *Handler) writeOp200Response(w http.ResponseWriter, r *packagenamemodels.OpResponse200) {
+}
+func (h *Handler) writeOpResponse(w http.ResponseWriter, response *packagenamemodels.OpResponse) {
+	switch response.StatusCode {
+	case 200:
+		if response.Response200 == nil {
+			http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(response.StatusCode)
+		h.writeOp200Response(w, response.Response200)
+		return
+	}
+	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+}
+func (h *Handler) handleOpRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parseOpRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	response, err := h.op.HandleOp(ctx, *request)
+	if err != nil || response == nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	h.writeOpResponse(w, response)
+	return
+}
+func (h *Handler) handleOp(w http.ResponseWriter, r *http.Request) {
+	switch r.Header.Get("Content-Type") {
+	case "application/json":
+		h.handleOpRequest(w, r)
+		return
+	case "":
+		h.handleOpRequest(w, r)
+		return
+	default:
+		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
+		return
+	}
+}
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input := strings.NewReader(tc.input)
+			outputModels := &bytes.Buffer{}
+			outputHandlers := &bytes.Buffer{}
+			gen := generator.NewGenerator(&options.Options{
+				PackagePrefix: "packagename",
+			})
+			gen.PackageName = "packagename"
+			gen.ImportPrefix = "imports"
+			gen.ModelsImportPath = "packagename/imports/models"
+			err := gen.PrepareAndRead(input)
+			assert.NoError(t, err)
+			err = gen.GenerateFiles()
+			assert.NoError(t, err)
+			err = gen.WriteToOutput(outputModels, outputHandlers)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedModels, outputModels.String())
+			assert.Equal(t, tc.expectedHandlers, outputHandlers.String())
+		})
+	}
+}
+
+func TestGenerateExternal(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		input            string
+		expectedModels   string
+		expectedHandlers string
+	}{
+		{
+			name: "operation id",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example:
+    post:
+      operationId: op
+      summary: Example
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                external-ref:
+                  $ref: 'testdata/def.yml#/components/schemas/ExternalRef'
+      responses:
+        '200':
+          description: OK
+`,
+			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+import "packagename/generated/def/defmodels"
+
+type OpRequestBody struct {
+	ExternalRef *defmodels.ExternalRef ` + "`json:\"external-ref,omitempty\" validate:\"omitempty\"`" + `
+}
+type OpRequest struct {
+	Body OpRequestBody
+}
+type OpResponse200 struct {
+}
+type OpResponse struct {
+	StatusCode  int
+	Response200 *OpResponse200
+}
+`,
+			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagename
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"packagename/imports/models"
+)
+
+// OpHandler handles POST /example.
+type OpHandler interface {
+	// Example
+	HandleOp(ctx context.Context, r packagenamemodels.OpRequest) (*packagenamemodels.OpResponse, error)
+}
+
+var patternsCache sync.Map
+
+type Handler struct {
+	validator *validator.Validate
+	op        OpHandler
+}
+
+func NewHandler(op OpHandler) *Handler {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	_ = v.RegisterValidation("pattern", validatePattern)
+	_ = v.RegisterValidation("multipleOf", validateMultipleOf)
+	return &Handler{validator: v, op: op}
+}
+func (h *Handler) AddRoutes(router chi.Router) {
+	router.Post("/example", h.handleOp)
+}
+func ValidateOpRequestBodyJSON(jsonData json.RawMessage) error {
+	return validateOpRequestBodyJSON(jsonData, 0, false)
+}
+func validateOpRequestBodyJSON(_ json.RawMessage, _ int, _ bool) error {
+	return nil
+}
+func (h *Handler) parseOpRequestBody(r *http.Request) (*packagenamemodels.OpRequestBody, error) {
+	var bodyJSON json.RawMessage
+	err := json.NewDecoder(r.Body).Decode(&bodyJSON)
+	if err != nil {
+		return nil, err
+	}
+	err = validateOpRequestBodyJSON(bodyJSON, 0, true)
+	if err != nil {
+		return nil, err
+	}
+	var body packagenamemodels.OpRequestBody
+	err = json.Unmarshal(bodyJSON, &body)
+	if err != nil {
+		return nil, err
+	}
+	err = h.validator.Struct(body)
+	if err != nil {
+		return nil, err
+	}
+	return &body, nil
+}
+func (h *Handler) parseOpRequest(r *http.Request) (*packagenamemodels.OpRequest, error) {
+	body, err := h.parseOpRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.OpRequest{Body: *body}, nil
+}
+func Op200Response() *packagenamemodels.OpResponse {
+	return &packagenamemodels.OpResponse{StatusCode: 200, Response200: &packagenamemodels.OpResponse200{}}
+}
+func (h *Handler) writeOp200Response(w http.ResponseWriter, r *packagenamemodels.OpResponse200) {
+}
+func (h *Handler) writeOpResponse(w http.ResponseWriter, response *packagenamemodels.OpResponse) {
+	switch response.StatusCode {
+	case 200:
+		if response.Response200 == nil {
+			http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(response.StatusCode)
+		h.writeOp200Response(w, response.Response200)
+		return
+	}
+	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+}
+func (h *Handler) handleOpRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parseOpRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	response, err := h.op.HandleOp(ctx, *request)
+	if err != nil || response == nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	h.writeOpResponse(w, response)
+	return
+}
+func (h *Handler) handleOp(w http.ResponseWriter, r *http.Request) {
+	switch r.Header.Get("Content-Type") {
+	case "application/json":
+		h.handleOpRequest(w, r)
+		return
+	case "":
+		h.handleOpRequest(w, r)
+		return
+	default:
+		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
+		return
+	}
+}
+func validatePattern(fl validator.FieldLevel) bool {
+	pattern := fl.Param()
+	cached, ok := patternsCache.Load(pattern)
+	if !ok {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return false
+		}
+		cached, _ = patternsCache.LoadOrStore(pattern, compiled)
+	}
+	re, ok := cached.(*regexp.Regexp)
+	return ok && re.MatchString(fl.Field().String())
+}
+func validateMultipleOf(fl validator.FieldLevel) bool {
+	divisor, err := strconv.ParseFloat(fl.Param(), 64)
+	if err != nil || divisor == 0 {
+		return false
+	}
+	field := fl.Field()
+	var value float64
+	switch {
+	case field.CanInt():
+		value = float64(field.Int())
+	case field.CanUint():
+		value = float64(field.Uint())
+	case field.CanFloat():
+		value = field.Float()
+	default:
+		return false
+	}
+	quotient := value / divisor
+	return math.Abs(quotient-math.Round(quotient)) < 1e-9
+}
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input := strings.NewReader(tc.input)
+			outputModels := &bytes.Buffer{}
+			outputHandlers := &bytes.Buffer{}
+			gen := generator.NewGenerator(&options.Options{
+				PackagePrefix: "packagename",
+			})
+			gen.PackageName = "packagename"
+			gen.ImportPrefix = "imports"
+			gen.ModelsImportPath = "packagename/imports/models"
+			err := gen.PrepareAndRead(input)
+			assert.NoError(t, err)
+			err = gen.GenerateFiles()
+			assert.NoError(t, err)
+			err = gen.WriteToOutput(outputModels, outputHandlers)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedModels, outputModels.String())
+			assert.Equal(t, tc.expectedHandlers, outputHandlers.String())
+		})
+	}
+}
+
+func TestGenerateDefaultFieldsAreValues(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "body defaults",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    ObjectModel:
+      type: object
+      properties:
+        limit:
+          type: integer
+          default: 20
+        name:
+          type: string
+          default: unnamed
+        kind:
+          type: string
+          enum: [a, b]
+          default: b
+        ratio:
+          type: number
+          default: 0.5
+        enabled:
+          type: boolean
+          default: true
+        required_field:
+          type: string
+      required:
+        - required_field
+`,
+			expected: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+import "encoding/json"
+
+// Default: "b".
+type ObjectModelKind string
+
+const (
+	ObjectModelKindA ObjectModelKind = "a"
+	ObjectModelKindB ObjectModelKind = "b"
+)
+
+func (v ObjectModelKind) IsValid() bool {
+	switch v {
+	case ObjectModelKindA, ObjectModelKindB:
+		return true
+	}
+	return false
+}
+func AllObjectModelKindValues() []ObjectModelKind {
+	return []ObjectModelKind{ObjectModelKindA, ObjectModelKindB}
+}
+
+type ObjectModel struct {
+	// Default: true.
+	Enabled bool ` + "`json:\"enabled\" validate:\"omitempty\"`" + `
+	// Default: "b".
+	Kind ObjectModelKind ` + "`json:\"kind\" validate:\"omitempty,oneof=a b\"`" + `
+	// Default: 20.
+	Limit int ` + "`json:\"limit\" validate:\"omitempty\"`" + `
+	// Default: "unnamed".
+	Name string ` + "`json:\"name\" validate:\"omitempty\"`" + `
+	// Default: 0.5.
+	Ratio         float64 ` + "`json:\"ratio\" validate:\"omitempty\"`" + `
+	RequiredField string  ` + "`json:\"required_field\"`" + `
+}
+
+func (m *ObjectModel) UnmarshalJSON(data []byte) error {
+	type plain ObjectModel
+	value := plain{Enabled: true, Kind: "b", Limit: 20, Name: "unnamed", Ratio: 0.5}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	*m = ObjectModel(value)
+	return nil
+}
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input := strings.NewReader(tc.input)
+			outputModels := &bytes.Buffer{}
+			outputHandlers := &bytes.Buffer{}
+			gen := generator.NewGenerator(&options.Options{
+				PackagePrefix:          "packagename",
+				DefaultFieldsAreValues: true,
+			})
+			gen.PackageName = "packagename"
+			gen.ImportPrefix = "imports"
+			gen.ModelsImportPath = "packagename/imports/models"
+			err := gen.PrepareAndRead(input)
+			assert.NoError(t, err)
+			err = gen.GenerateFiles()
+			assert.NoError(t, err)
+			err = gen.WriteToOutput(outputModels, outputHandlers)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expected, outputModels.String())
+		})
+	}
+}
+
+func TestGenerateNullableType(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		input            string
+		expectedModels   string
+		expectedHandlers string
+	}{
+		{
+			name: "nullable fields",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example:
+    patch:
+      operationId: update
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Patch'
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Patch:
+      type: object
+      properties:
+        name:
+          type: string
+          nullable: true
+          minLength: 3
+        count:
+          type: integer
+          nullable: true
+        plain:
+          type: string
+        date:
+          type: string
+          format: date-time
+          nullable: true
+        required_nullable:
+          type: string
+          nullable: true
+          maxLength: 10
+      required:
+        - required_nullable
+`,
+			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type UpdateRequest struct {
+	Body Patch
+}
+type UpdateResponse200 struct {
+}
+type UpdateResponse struct {
+	StatusCode  int
+	Response200 *UpdateResponse200
+}
+type Nullable[T any] struct {
+	Value T
+	Set   bool
+	Null  bool
+}
+
+func NewNullable[T any](value T) Nullable[T] {
+	return Nullable[T]{Value: value, Set: true}
+}
+func NewNull[T any]() Nullable[T] {
+	return Nullable[T]{Set: true, Null: true}
+}
+func (n Nullable[T]) Get() (T, bool) {
+	return n.Value, n.Set && !n.Null
+}
+func (n Nullable[T]) IsZero() bool {
+	return !n.Set
+}
+func (n Nullable[T]) ValidationValue() any {
+	if !n.Set || n.Null {
+		return (*T)(nil)
+	}
+	return n.Value
+}
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.Set || n.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	n.Null = string(data) == "null"
+	if n.Null {
+		var zero T
+		n.Value = zero
+		return nil
+	}
+	return json.Unmarshal(data, &n.Value)
+}
+
+type Patch struct {
+	Count Nullable[int] ` + "`json:\"count,omitzero\" validate:\"omitempty\"`" + `
+	// Constraints: format date-time.
+	Date Nullable[time.Time] ` + "`json:\"date,omitzero\" validate:\"omitempty\"`" + `
+	// Constraints: min length 3.
+	Name  Nullable[string] ` + "`json:\"name,omitzero\" validate:\"omitempty,min=3\"`" + `
+	Plain *string          ` + "`json:\"plain,omitempty\" validate:\"omitempty\"`" + `
+	// Constraints: max length 10.
+	RequiredNullable Nullable[string] ` + "`json:\"required_nullable\" validate:\"omitnil,max=10\"`" + `
+}
+`,
+			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagename
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-faster/errors"
+	"github.com/go-playground/validator/v10"
+	"packagename/imports/models"
+)
+
+type UpdateHandler interface {
+	HandleUpdate(ctx context.Context, r packagenamemodels.UpdateRequest) (*packagenamemodels.UpdateResponse, error)
+}
+type Handler struct {
+	validator *validator.Validate
+	update    UpdateHandler
+}
+
+func NewHandler(update UpdateHandler) *Handler {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterCustomTypeFunc(nullableValue, packagenamemodels.Nullable[int]{}, packagenamemodels.Nullable[string]{})
+	return &Handler{validator: v, update: update}
+}
+func (h *Handler) AddRoutes(router chi.Router) {
+	router.Patch("/example", h.handleUpdate)
+}
+func (h *Handler) parseUpdateRequestBody(r *http.Request) (*packagenamemodels.Patch, error) {
+	var bodyJSON json.RawMessage
+	err := json.NewDecoder(r.Body).Decode(&bodyJSON)
+	if err != nil {
+		return nil, err
+	}
+	err = validatePatchJSON(bodyJSON, 0, true)
+	if err != nil {
+		return nil, err
+	}
+	var body packagenamemodels.Patch
+	err = json.Unmarshal(bodyJSON, &body)
+	if err != nil {
+		return nil, err
+	}
+	err = h.validator.Struct(body)
+	if err != nil {
+		return nil, err
+	}
+	return &body, nil
+}
+func (h *Handler) parseUpdateRequest(r *http.Request) (*packagenamemodels.UpdateRequest, error) {
+	body, err := h.parseUpdateRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.UpdateRequest{Body: *body}, nil
+}
+func Update200Response() *packagenamemodels.UpdateResponse {
+	return &packagenamemodels.UpdateResponse{StatusCode: 200, Response200: &packagenamemodels.UpdateResponse200{}}
+}
+func (h *Handler) writeUpdate200Response(w http.ResponseWriter, r *packagenamemodels.UpdateResponse200) {
+}
+func (h *Handler) writeUpdateResponse(w http.ResponseWriter, response *packagenamemodels.UpdateResponse) {
+	switch response.StatusCode {
+	case 200:
+		if response.Response200 == nil {
+			http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(response.StatusCode)
+		h.writeUpdate200Response(w, response.Response200)
+		return
+	}
+	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+}
+func (h *Handler) handleUpdateRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parseUpdateRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	response, err := h.update.HandleUpdate(ctx, *request)
+	if err != nil || response == nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	h.writeUpdateResponse(w, response)
+	return
+}
+func (h *Handler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	switch r.Header.Get("Content-Type") {
+	case "application/json":
+		h.handleUpdateRequest(w, r)
+		return
+	case "":
+		h.handleUpdateRequest(w, r)
+		return
+	default:
+		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
+		return
+	}
+}
+func containsNull(data json.RawMessage) bool {
+	var temp any
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return false
+	}
+	return temp == nil
+}
+func ValidatePatchJSON(jsonData json.RawMessage) error {
+	return validatePatchJSON(jsonData, 0, false)
+}
+func validatePatchJSON(jsonData json.RawMessage, _ int, _ bool) error {
+	requiredFields := map[string]bool{"required_nullable": true}
+	nullableFields := map[string]bool{"required_nullable": true}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	return nil
+}
+func nullableValue(field reflect.Value) any {
+	if value, ok := field.Interface().(interface {
+		ValidationValue() any
+	}); ok {
+		return value.ValidationValue()
+	}
+	return nil
+}
+`,
+		},
+		{
+			name: "recursive nullable fields",
+			input: `openapi: 3.0.0
+info:
+  title: Rec
+  version: 1.0.0
+paths:
+  /comments:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Comment'
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Comment'
+components:
+  schemas:
+    Comment:
+      nullable: true
+      type: object
+      required: [text, author, parent]
+      properties:
+        text:
+          type: string
+        author:
+          $ref: '#/components/schemas/User'
+        parent:
+          $ref: '#/components/schemas/Comment'
+        replies:
+          type: array
+          items:
+            $ref: '#/components/schemas/Comment'
+    User:
+      type: object
+      required: [name, best]
+      properties:
+        name:
+          type: string
+        best:
+          $ref: '#/components/schemas/Comment'
+`,
+			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+import "encoding/json"
+
+type PostCommentsRequest struct {
+	Body Comment
+}
+type PostCommentsResponse200 struct {
+	Body Comment
+}
+type PostCommentsResponse struct {
+	StatusCode  int
+	Response200 *PostCommentsResponse200
+}
+type Nullable[T any] struct {
+	Value T
+	Set   bool
+	Null  bool
+}
+
+func NewNullable[T any](value T) Nullable[T] {
+	return Nullable[T]{Value: value, Set: true}
+}
+func NewNull[T any]() Nullable[T] {
+	return Nullable[T]{Set: true, Null: true}
+}
+func (n Nullable[T]) Get() (T, bool) {
+	return n.Value, n.Set && !n.Null
+}
+func (n Nullable[T]) IsZero() bool {
+	return !n.Set
+}
+func (n Nullable[T]) ValidationValue() any {
+	if !n.Set || n.Null {
+		return (*T)(nil)
+	}
+	return n.Value
+}
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.Set || n.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	n.Null = string(data) == "null"
+	if n.Null {
+		var zero T
+		n.Value = zero
+		return nil
+	}
+	return json.Unmarshal(data, &n.Value)
+}
+
+type CommentReplies []*Comment
+type Comment struct {
+	Author  *User              ` + "`json:\"author\"`" + `
+	Parent  Nullable[*Comment] ` + "`json:\"parent\"`" + `
+	Replies *CommentReplies    ` + "`json:\"replies,omitempty\" validate:\"omitempty,dive\"`" + `
+	Text    string             ` + "`json:\"text\"`" + `
+}
+type User struct {
+	Best Nullable[*Comment] ` + "`json:\"best\"`" + `
+	Name string             ` + "`json:\"name\"`" + `
+}
+`,
+			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagename
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-faster/errors"
+	"github.com/go-playground/validator/v10"
+	"packagename/imports/models"
+)
+
+type PostCommentsHandler interface {
+	HandlePostComments(ctx context.Context, r packagenamemodels.PostCommentsRequest) (*packagenamemodels.PostCommentsResponse, error)
+}
+
+const maxValidationDepth = 64
+
+type Handler struct {
+	validator    *validator.Validate
+	postComments PostCommentsHandler
+}
+
+func NewHandler(postComments PostCommentsHandler) *Handler {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterCustomTypeFunc(nullableValue, packagenamemodels.Nullable[*packagenamemodels.Comment]{})
+	return &Handler{validator: v, postComments: postComments}
+}
+func (h *Handler) AddRoutes(router chi.Router) {
+	router.Post("/comments", h.handlePostComments)
+}
+func (h *Handler) parsePostCommentsRequestBody(r *http.Request) (*packagenamemodels.Comment, error) {
+	var bodyJSON json.RawMessage
+	err := json.NewDecoder(r.Body).Decode(&bodyJSON)
+	if err != nil {
+		return nil, err
+	}
+	err = validateCommentJSON(bodyJSON, 0, true)
+	if err != nil {
+		return nil, err
+	}
+	var body packagenamemodels.Comment
+	err = json.Unmarshal(bodyJSON, &body)
+	if err != nil {
+		return nil, err
+	}
+	err = h.validator.Struct(body)
+	if err != nil {
+		return nil, err
+	}
+	return &body, nil
+}
+func (h *Handler) parsePostCommentsRequest(r *http.Request) (*packagenamemodels.PostCommentsRequest, error) {
+	body, err := h.parsePostCommentsRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.PostCommentsRequest{Body: *body}, nil
+}
+func PostComments200Response(body packagenamemodels.Comment) *packagenamemodels.PostCommentsResponse {
+	return &packagenamemodels.PostCommentsResponse{StatusCode: 200, Response200: &packagenamemodels.PostCommentsResponse200{Body: body}}
+}
+func (h *Handler) writePostComments200Response(w http.ResponseWriter, r *packagenamemodels.PostCommentsResponse200) {
+	var err error
+	err = json.NewEncoder(w).Encode(r.Body)
+	if err != nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+}
+func (h *Handler) writePostCommentsResponse(w http.ResponseWriter, response *packagenamemodels.PostCommentsResponse) {
+	switch response.StatusCode {
+	case 200:
+		if response.Response200 == nil {
+			http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(response.StatusCode)
+		h.writePostComments200Response(w, response.Response200)
+		return
+	}
+	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+}
+func (h *Handler) handlePostCommentsRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parsePostCommentsRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	response, err := h.postComments.HandlePostComments(ctx, *request)
+	if err != nil || response == nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	h.writePostCommentsResponse(w, response)
+	return
+}
+func (h *Handler) handlePostComments(w http.ResponseWriter, r *http.Request) {
+	switch r.Header.Get("Content-Type") {
+	case "application/json":
+		h.handlePostCommentsRequest(w, r)
+		return
+	case "":
+		h.handlePostCommentsRequest(w, r)
+		return
+	default:
+		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
+		return
+	}
+}
+func containsNull(data json.RawMessage) bool {
+	var temp any
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return false
+	}
+	return temp == nil
+}
+func ValidateCommentRepliesJSON(jsonData json.RawMessage) error {
+	return validateCommentRepliesJSON(jsonData, 0, false)
+}
+func validateCommentRepliesJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var arr []json.RawMessage
+	err := json.Unmarshal(jsonData, &arr)
+	if err != nil {
+		return err
+	}
+	for index, obj := range arr {
+		if !containsNull(obj) {
+			err = validateCommentJSON(obj, depth+1, request)
+			if err != nil {
+				return errors.Wrapf(err, "error validating object at index %d", index)
+			}
+		}
+	}
+	return nil
+}
+func ValidateCommentJSON(jsonData json.RawMessage) error {
+	return validateCommentJSON(jsonData, 0, false)
+}
+func validateCommentJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	requiredFields := map[string]bool{"author": true, "parent": true, "text": true}
+	nullableFields := map[string]bool{"parent": true}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	val, exists = obj["author"]
+	if exists && !containsNull(val) {
+		err = validateUserJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field author is not valid")
+		}
+	}
+	val, exists = obj["parent"]
+	if exists && !containsNull(val) {
+		err = validateCommentJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field parent is not valid")
+		}
+	}
+	val, exists = obj["replies"]
+	if exists && !containsNull(val) {
+		err = validateCommentRepliesJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field replies is not valid")
+		}
+	}
+	return nil
+}
+func ValidateUserJSON(jsonData json.RawMessage) error {
+	return validateUserJSON(jsonData, 0, false)
+}
+func validateUserJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	requiredFields := map[string]bool{"best": true, "name": true}
+	nullableFields := map[string]bool{"best": true}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	val, exists = obj["best"]
+	if exists && !containsNull(val) {
+		err = validateCommentJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field best is not valid")
+		}
+	}
+	return nil
+}
+func nullableValue(field reflect.Value) any {
+	if value, ok := field.Interface().(interface {
+		ValidationValue() any
+	}); ok {
+		return value.ValidationValue()
+	}
+	return nil
+}
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input := strings.NewReader(tc.input)
+			outputModels := &bytes.Buffer{}
+			outputHandlers := &bytes.Buffer{}
+			gen := generator.NewGenerator(&options.Options{
+				PackagePrefix: "packagename",
+				NullableType:  true,
+			})
+			gen.PackageName = "packagename"
+			gen.ImportPrefix = "imports"
+			gen.ModelsImportPath = "packagename/imports/models"
+			err := gen.PrepareAndRead(input)
+			assert.NoError(t, err)
+			err = gen.GenerateFiles()
+			assert.NoError(t, err)
+			err = gen.WriteToOutput(outputModels, outputHandlers)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedModels, outputModels.String())
+			assert.Equal(t, tc.expectedHandlers, outputHandlers.String())
+		})
+	}
+}
+
+func TestGenerateGoNames(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		input            string
+		expectedModels   string
+		expectedHandlers string
+	}{
+		{
+			name: "x-go-name and initialisms",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example/{param_name}:
+    post:
+      operationId: post-example-param-name
+      x-go-name: UpdateExample
+      parameters:
+        - name: param_name
+          in: path
+          required: true
+          schema:
+            type: string
+          x-go-name: Slug
+        - name: etag-value
+          in: header
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/example_payload'
+      responses:
+        '200':
+          description: OK
+          headers:
+            X-Etag:
+              schema:
+                type: string
+              x-go-name: Version
+components:
+  schemas:
+    example_payload:
+      type: object
+      x-go-name: Payload
+      properties:
+        sku_code:
+          type: string
+          x-go-name: SKU
+        details:
+          type: object
+          x-go-name: Info
+          properties:
+            note:
+              type: string
+`,
+			expectedModels: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagenamemodels
+
+type UpdateExamplePathParams struct {
+	Slug string ` + "`json:\"param_name\" validate:\"required\"`" + `
+}
+type UpdateExampleHeaders struct {
+	ETAGValue *string ` + "`json:\"etag-value,omitempty\" validate:\"omitempty\"`" + `
+}
+type UpdateExampleRequest struct {
+	Path    UpdateExamplePathParams
+	Headers UpdateExampleHeaders
+	Body    *Payload
+}
+type UpdateExampleResponse200Headers struct {
+	Version *string ` + "`json:\"X-Etag,omitempty\" validate:\"omitempty\"`" + `
+}
+type UpdateExampleResponse200 struct {
+	Headers UpdateExampleResponse200Headers
+}
+type UpdateExampleResponse struct {
+	StatusCode  int
+	Response200 *UpdateExampleResponse200
+}
+type PayloadInfo struct {
+	Note *string ` + "`json:\"note,omitempty\" validate:\"omitempty\"`" + `
+}
+type Payload struct {
+	Info *PayloadInfo ` + "`json:\"details,omitempty\" validate:\"omitempty\"`" + `
+	SKU  *string      ` + "`json:\"sku_code,omitempty\" validate:\"omitempty\"`" + `
+}
+`,
+			expectedHandlers: `// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.
+
+package packagename
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-faster/errors"
+	"github.com/go-playground/validator/v10"
+	"packagename/imports/models"
+)
+
+type UpdateExampleHandler interface {
+	HandleUpdateExample(ctx context.Context, r packagenamemodels.UpdateExampleRequest) (*packagenamemodels.UpdateExampleResponse, error)
+}
+
+const maxValidationDepth = 64
+
+type Handler struct {
+	validator     *validator.Validate
+	updateExample UpdateExampleHandler
+}
+
+func NewHandler(updateExample UpdateExampleHandler) *Handler {
+	return &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), updateExample: updateExample}
+}
+func (h *Handler) AddRoutes(router chi.Router) {
+	router.Post("/example/{param_name}", h.handleUpdateExample)
+}
+func (h *Handler) parseUpdateExamplePathParams(r *http.Request) (*packagenamemodels.UpdateExamplePathParams, error) {
+	var pathParams packagenamemodels.UpdateExamplePathParams
+	slug := chi.URLParam(r, "param_name")
+	if slug == "" {
+		return nil, errors.New("param_name path param is required")
+	}
+	pathParams.Slug = slug
+	err := h.validator.Struct(pathParams)
+	if err != nil {
+		return nil, err
+	}
+	return &pathParams, nil
+}
+func (h *Handler) parseUpdateExampleHeaders(r *http.Request) (*packagenamemodels.UpdateExampleHeaders, error) {
+	var headers packagenamemodels.UpdateExampleHeaders
+	etagValue := r.Header.Get("etag-value")
+	if etagValue != "" {
+		headers.ETAGValue = &etagValue
+	}
+	err := h.validator.Struct(headers)
+	if err != nil {
+		return nil, err
+	}
+	return &headers, nil
+}
+func (h *Handler) parseUpdateExampleRequestBody(r *http.Request) (*packagenamemodels.Payload, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	var bodyJSON json.RawMessage
+	err := json.NewDecoder(r.Body).Decode(&bodyJSON)
+	if err != nil {
+		return nil, err
+	}
+	err = validatePayloadJSON(bodyJSON, 0, true)
+	if err != nil {
+		return nil, err
+	}
+	var body packagenamemodels.Payload
+	err = json.Unmarshal(bodyJSON, &body)
+	if err != nil {
+		return nil, err
+	}
+	err = h.validator.Struct(body)
+	if err != nil {
+		return nil, err
+	}
+	return &body, nil
+}
+func (h *Handler) parseUpdateExampleRequest(r *http.Request) (*packagenamemodels.UpdateExampleRequest, error) {
+	pathParams, err := h.parseUpdateExamplePathParams(r)
+	if err != nil {
+		return nil, err
+	}
+	headers, err := h.parseUpdateExampleHeaders(r)
+	if err != nil {
+		return nil, err
+	}
+	body, err := h.parseUpdateExampleRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+	return &packagenamemodels.UpdateExampleRequest{Path: *pathParams, Headers: *headers, Body: body}, nil
+}
+func UpdateExample200Response(headers packagenamemodels.UpdateExampleResponse200Headers) *packagenamemodels.UpdateExampleResponse {
+	return &packagenamemodels.UpdateExampleResponse{StatusCode: 200, Response200: &packagenamemodels.UpdateExampleResponse200{Headers: headers}}
+}
+func (h *Handler) writeUpdateExample200Response(w http.ResponseWriter, r *packagenamemodels.UpdateExampleResponse200) {
+}
+func (h *Handler) writeUpdateExample200ResponseHeaders(w http.ResponseWriter, r *packagenamemodels.UpdateExampleResponse200) {
+	headersJSON, err := json.Marshal(r.Headers)
+	if err != nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	var headers map[string]string
+	err = json.Unmarshal(headersJSON, &headers)
+	if err != nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	for key, value := range headers {
+		w.Header().Set(key, value)
+	}
+}
+func (h *Handler) writeUpdateExampleResponse(w http.ResponseWriter, response *packagenamemodels.UpdateExampleResponse) {
+	switch response.StatusCode {
+	case 200:
+		if response.Response200 == nil {
+			http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+			return
+		}
+		h.writeUpdateExample200ResponseHeaders(w, response.Response200)
+		w.WriteHeader(response.StatusCode)
+		h.writeUpdateExample200Response(w, response.Response200)
+		return
+	}
+	http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+}
+func (h *Handler) handleUpdateExampleRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := h.parseUpdateExampleRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"error\":%s}", strconv.Quote(err.Error())), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	response, err := h.updateExample.HandleUpdateExample(ctx, *request)
+	if err != nil || response == nil {
+		http.Error(w, "{\"error\":\"InternalServerError\"}", http.StatusInternalServerError)
+		return
+	}
+	h.writeUpdateExampleResponse(w, response)
+	return
+}
+func (h *Handler) handleUpdateExample(w http.ResponseWriter, r *http.Request) {
+	switch r.Header.Get("Content-Type") {
+	case "application/json":
+		h.handleUpdateExampleRequest(w, r)
+		return
+	case "":
+		h.handleUpdateExampleRequest(w, r)
+		return
+	default:
+		http.Error(w, "{\"error\":\"Unsupported Content-Type\"}", http.StatusUnsupportedMediaType)
+		return
+	}
+}
+func ValidatePayloadInfoJSON(jsonData json.RawMessage) error {
+	return validatePayloadInfoJSON(jsonData, 0, false)
+}
+func validatePayloadInfoJSON(_ json.RawMessage, _ int, _ bool) error {
+	return nil
+}
+func containsNull(data json.RawMessage) bool {
+	var temp any
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return false
+	}
+	return temp == nil
+}
+func ValidatePayloadJSON(jsonData json.RawMessage) error {
+	return validatePayloadJSON(jsonData, 0, false)
+}
+func validatePayloadJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	val, exists = obj["details"]
+	if exists && !containsNull(val) {
+		err = validatePayloadInfoJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field details is not valid")
+		}
+	}
+	return nil
+}
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input := strings.NewReader(tc.input)
+			outputModels := &bytes.Buffer{}
+			outputHandlers := &bytes.Buffer{}
+			gen := generator.NewGenerator(&options.Options{
+				PackagePrefix: "packagename",
+				Initialisms:   []string{"etag"},
+			})
+			gen.PackageName = "packagename"
+			gen.ImportPrefix = "imports"
+			gen.ModelsImportPath = "packagename/imports/models"
+			err := gen.PrepareAndRead(input)
+			assert.NoError(t, err)
+			err = gen.GenerateFiles()
+			assert.NoError(t, err)
+			err = gen.WriteToOutput(outputModels, outputHandlers)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedModels, outputModels.String())
+			assert.Equal(t, tc.expectedHandlers, outputHandlers.String())
+		})
+	}
+}
+
+func TestGenerateGoNameCollisions(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		input        string
+		nullableType bool
+	}{
+		{
+			name: "properties",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Item:
+      type: object
+      properties:
+        user_id:
+          type: string
+        user-id:
+          type: string
+`,
+		},
+		{
+			name: "schemas",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Item:
+      type: string
+    LegacyItem:
+      type: string
+      x-go-name: Item
+`,
+		},
+		{
+			name: "operations",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /a:
+    get:
+      operationId: get-item
+      responses:
+        '200':
+          description: OK
+  /b:
+    get:
+      operationId: get_item
+      responses:
+        '200':
+          description: OK
+`,
+		},
+		{
+			name: "inline and component types",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Foo:
+      type: object
+      properties:
+        bar:
+          type: object
+          properties:
+            x:
+              type: string
+    FooBar:
+      type: object
+      required: [y]
+      properties:
+        y:
+          type: string
+`,
+		},
+		{
+			name: "operation and component types",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      operationId: getItems
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    GetitemsResponse200:
+      type: string
+`,
+		},
+		{
+			name: "date helper",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Date:
+      type: string
+    Event:
+      type: object
+      properties:
+        day:
+          type: string
+          format: date
+`,
+		},
+		{
+			name: "url helper",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Link:
+      type: object
+      properties:
+        url:
+          type: string
+          format: uri
+    URL:
+      type: string
+`,
+		},
+		{
+			name: "nullable helper",
+			input: `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Item:
+      type: object
+      properties:
+        name:
+          type: string
+          nullable: true
+    Nullable:
+      type: boolean
+`,
+			nullableType: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
 			gen := generator.NewGenerator(&options.Options{
 				PackagePrefix: "packagename",
+				NullableType:  tc.nullableType,
 			})
 			gen.PackageName = "packagename"
 			gen.ImportPrefix = "imports"
 			gen.ModelsImportPath = "packagename/imports/models"
-			err := gen.PrepareAndRead(input)
+			err := gen.PrepareAndRead(strings.NewReader(tc.input))
 			assert.NoError(t, err)
 			err = gen.GenerateFiles()
-			assert.NoError(t, err)
-			err = gen.WriteToOutput(outputModels, outputHandlers)
-			assert.NoError(t, err)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestOpenAPI31UnsupportedKeywords(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		schema   string
+		pointer  string
+		column   int
+		expected string
+	}{
+		{
+			name:     "prefixItems",
+			schema:   `{type: array, prefixItems: [{type: string}]}`,
+			pointer:  "#/components/schemas/Value/prefixItems",
+			column:   26,
+			expected: "unsupported OpenAPI 3.1 keyword prefixItems",
+		},
+		{
+			name:     "defs",
+			schema:   `{type: object, properties: {id: {$defs: {}}}}`,
+			pointer:  "#/components/schemas/Value/properties/id/$defs",
+			column:   45,
+			expected: "unsupported OpenAPI 3.1 keyword $defs",
+		},
+		{
+			name:     "multiple types",
+			schema:   `{type: [string, integer]}`,
+			pointer:  "#/components/schemas/Value/type",
+			column:   13,
+			expected: "multiple types string, integer are not supported",
+		},
+		{
+			name:     "null type",
+			schema:   `{type: "null"}`,
+			pointer:  "#/components/schemas/Value/type",
+			column:   13,
+			expected: "type null without another type is not supported",
+		},
+		{
+			name:     "null type in list",
+			schema:   `{type: ["null"]}`,
+			pointer:  "#/components/schemas/Value/type",
+			column:   13,
+			expected: "type null without another type is not supported",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input := `openapi: 3.1.0
+info:
+  title: API
+  version: 1.0.0
+components:
+  schemas:
+    Value: ` + tc.schema + "\n"
+			gen := generator.NewGenerator(&options.Options{
+				PackagePrefix: "packagename",
+			})
+			err := gen.PrepareAndRead(strings.NewReader(input))
+			var diagnostics generator.Diagnostics
+			require.ErrorAs(t, err, &diagnostics)
+			require.Len(t, diagnostics, 1)
+			assert.Equal(t, tc.pointer, diagnostics[0].Pointer)
+			assert.Equal(t, 7, diagnostics[0].Line)
+			assert.Equal(t, tc.column, diagnostics[0].Column)
+			assert.Equal(t, tc.expected, diagnostics[0].Message())
+		})
+	}
+}
+
+func TestOpenAPI31UnsupportedKeywordsInReferencedFile(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: API
+  version: 1.0.0
+components:
+  schemas:
+    Value:
+      $ref: 'common.yaml#/components/schemas/Value'
+`
+	common := `components:
+  schemas:
+    Value:
+      type: object
+      properties:
+        id:
+          type: [string, integer]
+`
+	dir := t.TempDir()
+	specFile := path.Join(dir, "api.yaml")
+	require.NoError(t, os.WriteFile(specFile, []byte(spec), 0o600))
+	require.NoError(t, os.WriteFile(path.Join(dir, "common.yaml"), []byte(common), 0o600))
+	gen := generator.NewGenerator(&options.Options{
+		DirPrefix:     dir,
+		PackagePrefix: "packagename",
+		YAMLFiles:     []string{specFile},
+	})
+	gen.Output = make(map[string][]byte)
+	err := gen.Generate(context.Background())
+	var diagnostics generator.Diagnostics
+	require.ErrorAs(t, err, &diagnostics)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, path.Join(dir, "common.yaml"), diagnostics[0].File)
+	assert.Equal(t, "#/components/schemas/Value/properties/id/type", diagnostics[0].Pointer)
+	assert.Equal(t, 7, diagnostics[0].Line)
+	assert.Equal(t, "multiple types string, integer are not supported", diagnostics[0].Message())
+}
+
+func TestOpenAPI31ExclusiveBounds(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Range:
+      type: object
+      properties:
+        inclusiveMin:
+          type: integer
+          minimum: 10
+          exclusiveMinimum: 5
+        exclusiveMin:
+          type: integer
+          minimum: 5
+          exclusiveMinimum: 10
+        equalMin:
+          type: integer
+          minimum: 5
+          exclusiveMinimum: 5
+        inclusiveMax:
+          type: integer
+          maximum: 5
+          exclusiveMaximum: 10
+        exclusiveMax:
+          type: integer
+          maximum: 10
+          exclusiveMaximum: 5
+        onlyExclusive:
+          type: integer
+          exclusiveMinimum: 0
+          exclusiveMaximum: 100
+`
+	dir := t.TempDir()
+	specFile := path.Join(dir, "api.yaml")
+	require.NoError(t, os.WriteFile(specFile, []byte(spec), 0o600))
+	gen := generator.NewGenerator(&options.Options{
+		DirPrefix:     dir,
+		PackagePrefix: "packagename",
+		YAMLFiles:     []string{specFile},
+	})
+	gen.Output = make(map[string][]byte)
+	require.NoError(t, gen.Generate(context.Background()))
+
+	models := string(gen.Output[path.Join(dir, "generated/api/apimodels/models.go")])
+	for _, expected := range []string{
+		"Inclusivemin *int `json:\"inclusiveMin,omitempty\" validate:\"omitempty,min=10\"`",
+		"Exclusivemin *int `json:\"exclusiveMin,omitempty\" validate:\"omitempty,gt=10\"`",
+		"Equalmin *int `json:\"equalMin,omitempty\" validate:\"omitempty,gt=5\"`",
+		"Inclusivemax *int `json:\"inclusiveMax,omitempty\" validate:\"omitempty,max=5\"`",
+		"Exclusivemax *int `json:\"exclusiveMax,omitempty\" validate:\"omitempty,lt=5\"`",
+		"Onlyexclusive *int `json:\"onlyExclusive,omitempty\" validate:\"omitempty,gt=0,lt=100\"`",
+	} {
+		assert.Contains(t, models, expected)
+	}
+}
+
+func TestSwagger2FormData(t *testing.T) {
+	input := `swagger: "2.0"
+info:
+  title: Legacy
+  version: 1.0.0
+consumes:
+  - application/x-www-form-urlencoded
+parameters:
+  Token:
+    name: token
+    in: formData
+    type: string
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      parameters:
+        - {name: name, in: formData, type: string, required: true}
+        - {name: X-Request-ID, in: header, type: string}
+        - $ref: '#/parameters/Token'
+      responses:
+        '204':
+          description: Created
+`
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+	})
+	err := gen.PrepareAndRead(strings.NewReader(input))
+	var diagnostics generator.Diagnostics
+	require.ErrorAs(t, err, &diagnostics)
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, "#/paths/~1pets/post/parameters/0", diagnostics[0].Pointer)
+	assert.Equal(t, 17, diagnostics[0].Line)
+	assert.Equal(t, "formData parameter name is not supported, only JSON request bodies are generated",
+		diagnostics[0].Message())
+	assert.Equal(t, "#/paths/~1pets/post/parameters/2", diagnostics[1].Pointer)
+	assert.Equal(t, "formData parameter token is not supported, only JSON request bodies are generated",
+		diagnostics[1].Message())
+}
+
+func TestGenerateExternalLayout(t *testing.T) {
+	input := `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /example:
+    post:
+      operationId: op
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                external-ref:
+                  $ref: 'testdata/def.yml#/components/schemas/ExternalRef'
+      responses:
+        '200':
+          description: OK
+`
+	outputModels := &bytes.Buffer{}
+	outputHandlers := &bytes.Buffer{}
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+		DirPrefix:     "internal",
+		Specs: map[string]options.Settings{
+			"testdata/def.yml": {
+				Package:       "shared",
+				ModelsPackage: "sharedmodels",
+				HandlersPath:  "pkg/shared",
+				ModelsPath:    "pkg/shared/models",
+			},
+		},
+	})
+	assert.Equal(t, generator.Layout{
+		Package:        "shared",
+		ModelsPackage:  "sharedmodels",
+		HandlersDir:    "internal/pkg/shared",
+		ModelsDir:      "internal/pkg/shared/models",
+		HandlersImport: "packagename/pkg/shared",
+		ModelsImport:   "packagename/pkg/shared/models",
+	}, gen.GetLayout("testdata/def.yml"))
+	assert.Equal(t, generator.Layout{
+		Package:        "api",
+		ModelsPackage:  "apimodels",
+		HandlersDir:    "internal/generated/api",
+		ModelsDir:      "internal/generated/api/apimodels",
+		HandlersImport: "packagename/generated/api",
+		ModelsImport:   "packagename/generated/api/apimodels",
+	}, gen.GetLayout("a_pi.yaml"))
+
+	gen.PackageName = "packagename"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(strings.NewReader(input))
+	assert.NoError(t, err)
+	err = gen.GenerateFiles()
+	assert.NoError(t, err)
+	err = gen.WriteToOutput(outputModels, outputHandlers)
+	assert.NoError(t, err)
+
+	assert.Contains(t, outputModels.String(), `import "packagename/pkg/shared/models"`)
+	assert.Contains(t, outputModels.String(), "ExternalRef *sharedmodels.ExternalRef")
+}
+
+func TestGenerateCheck(t *testing.T) {
+	dir := t.TempDir()
+	specFile := path.Join(dir, "api.yaml")
+	err := os.WriteFile(specFile, []byte(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Name:
+      type: string
+`), 0o600)
+	require.NoError(t, err)
+	handlersFile := path.Join(dir, "generated/api/handlers.go")
+	modelsFile := path.Join(dir, "generated/api/apimodels/models.go")
+	opts := func(check bool) *options.Options {
+		return &options.Options{
+			DirPrefix:     dir,
+			PackagePrefix: "packagename",
+			YAMLFiles:     []string{specFile},
+			Check:         check,
+		}
+	}
+
+	var stale *generator.StaleFilesError
+	err = generator.NewGenerator(opts(true)).Generate(context.Background())
+	require.ErrorAs(t, err, &stale)
+	assert.Equal(t, []string{modelsFile, handlersFile}, stale.Files)
+	assert.NoDirExists(t, path.Join(dir, "generated"))
+
+	require.NoError(t, generator.NewGenerator(opts(false)).Generate(context.Background()))
+	require.NoError(t, generator.NewGenerator(opts(true)).Generate(context.Background()))
+
+	models, err := os.ReadFile(modelsFile)
+	require.NoError(t, err)
+	err = os.WriteFile(modelsFile, bytes.Replace(models, []byte("type Name string"), []byte("type Name int"), 1), 0o600)
+	require.NoError(t, err)
+	err = generator.NewGenerator(opts(true)).Generate(context.Background())
+	require.ErrorAs(t, err, &stale)
+	assert.Equal(t, []string{modelsFile}, stale.Files)
+	assert.Contains(t, stale.Diff, "--- a/"+modelsFile+"\n+++ b/"+modelsFile+"\n")
+	assert.Contains(t, stale.Diff, "-type Name int\n+type Name string\n")
+}
+
+// buildGenerated generates the code of the spec inside the module and builds it.
+func buildGenerated(t *testing.T, spec string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("building the generated code is slow")
+	}
+	dir, err := os.MkdirTemp("testdata", "build")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	specFile := path.Join(dir, "api.yaml")
+	require.NoError(t, os.WriteFile(specFile, []byte(spec), 0o600))
+
+	err = generator.NewGenerator(&options.Options{
+		DirPrefix:     dir,
+		PackagePrefix: "github.com/jolfzverb/codegen/internal/generator/" + dir,
+		YAMLFiles:     []string{specFile},
+	}).Generate(context.Background())
+	require.NoError(t, err)
+
+	output, err := exec.Command("go", "build", "./"+dir+"/...").CombinedOutput()
+	require.NoError(t, err, string(output))
+}
+
+func TestGenerateBuildsParams(t *testing.T) {
+	buildGenerated(t, `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /items/{id}/{day}:
+    get:
+      operationId: getItem
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string, format: uuid}}
+        - {name: day, in: path, required: true, schema: {type: string, format: date}}
+        - {name: link, in: query, required: true, schema: {type: string, format: uri}}
+        - {name: since, in: query, schema: {type: string, format: date}}
+        - {name: X-Token, in: header, required: true, schema: {type: string, format: byte}}
+        - {name: session, in: cookie, required: true, schema: {type: string, format: uuid}}
+      responses:
+        '204':
+          description: No content
+`)
+}
+
+func TestGenerateEnumParams(t *testing.T) {
+	spec := `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /items/{kind}:
+    get:
+      operationId: getItems
+      parameters:
+        - {name: kind, in: path, required: true, schema: {type: string, enum: [new, used]}}
+        - {name: sort, in: query, schema: {type: string, enum: [asc, desc]}}
+        - {name: order, in: query, required: true, schema: {$ref: '#/components/schemas/Order'}}
+        - {name: X-Mode, in: header, schema: {type: string, enum: [fast, slow], default: fast}}
+        - {name: theme, in: cookie, schema: {type: string, enum: [dark, light]}}
+      responses:
+        '204':
+          description: No content
+components:
+  schemas:
+    Order:
+      type: string
+      enum: [asc, desc]
+`
+	dir := t.TempDir()
+	specFile := path.Join(dir, "api.yaml")
+	require.NoError(t, os.WriteFile(specFile, []byte(spec), 0o600))
+	gen := generator.NewGenerator(&options.Options{
+		DirPrefix:     dir,
+		PackagePrefix: "packagename",
+		YAMLFiles:     []string{specFile},
+	})
+	gen.Output = make(map[string][]byte)
+	require.NoError(t, gen.Generate(context.Background()))
+
+	models := string(gen.Output[path.Join(dir, "generated/api/apimodels/models.go")])
+	for _, expected := range []string{
+		"type GetitemsPathParamsKind string",
+		"func (v GetitemsQueryParamsSort) IsValid() bool {",
+		"func AllGetitemsHeadersXModeValues() []GetitemsHeadersXMode {",
+		"GetitemsCookiesThemeDark  GetitemsCookiesTheme = \"dark\"",
+		"Kind GetitemsPathParamsKind `json:\"kind\" validate:\"required,oneof=new used\"`",
+		"Sort  *GetitemsQueryParamsSort `json:\"sort,omitempty\" validate:\"omitempty,oneof=asc desc\"`",
+		"Order Order                    `json:\"order\" validate:\"required,oneof=asc desc\"`",
+	} {
+		assert.Contains(t, models, expected)
+	}
+	handlers := string(gen.Output[path.Join(dir, "generated/api/handlers.go")])
+	for _, expected := range []string{
+		"pathParams.Kind = apimodels.GetitemsPathParamsKind(kind)",
+		"parsedSort := apimodels.GetitemsQueryParamsSort(sort)",
+		"queryParams.Order = apimodels.Order(order)",
+	} {
+		assert.Contains(t, handlers, expected)
+	}
+
+	buildGenerated(t, spec)
+}
+
+func TestGenerateCache(t *testing.T) {
+	dir := t.TempDir()
+	specFile := path.Join(dir, "api.yaml")
+	defFile := path.Join(dir, "def.yaml")
+	writeSpec := func(fileName string, content string) {
+		require.NoError(t, os.WriteFile(fileName, []byte(content), 0o600))
+	}
+	writeSpec(specFile, `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          $ref: 'def.yaml#/components/schemas/Name'
+`)
+	writeSpec(defFile, `openapi: 3.0.0
+info:
+  title: Definitions
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Name:
+      type: string
+`)
+	modelsFile := path.Join(dir, "generated/api/apimodels/models.go")
+	defModelsFile := path.Join(dir, "generated/def/defmodels/models.go")
+	generate := func(force bool) {
+		t.Helper()
+		opts := &options.Options{
+			DirPrefix:     dir,
+			PackagePrefix: "packagename",
+			YAMLFiles:     []string{specFile},
+			Force:         force,
+		}
+		require.NoError(t, generator.NewGenerator(opts).Generate(context.Background()))
+	}
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	touched := func(fileName string) bool {
+		t.Helper()
+		info, err := os.Stat(fileName)
+		require.NoError(t, err)
+
+		return !info.ModTime().Equal(past)
+	}
+	resetTimes := func() {
+		for _, fileName := range []string{modelsFile, defModelsFile} {
+			require.NoError(t, os.Chtimes(fileName, past, past))
+		}
+	}
+
+	generate(false)
+	assert.FileExists(t, path.Join(dir, ".codegen-cache"))
+	resetTimes()
+	generate(false)
+	assert.False(t, touched(modelsFile))
+	assert.False(t, touched(defModelsFile))
+
+	writeSpec(defFile, `openapi: 3.0.0
+info:
+  title: Definitions
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Name:
+      type: integer
+`)
+	generate(false)
+	assert.True(t, touched(modelsFile))
+	assert.True(t, touched(defModelsFile))
+	models, err := os.ReadFile(defModelsFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(models), "type Name int")
+
+	require.NoError(t, os.Remove(modelsFile))
+	require.NoError(t, os.Chtimes(defModelsFile, past, past))
+	generate(false)
+	assert.FileExists(t, modelsFile)
+	assert.False(t, touched(defModelsFile))
+
+	resetTimes()
+	generate(true)
+	assert.True(t, touched(modelsFile))
+	assert.True(t, touched(defModelsFile))
+}
 
-			assert.Equal(t, tc.expectedModels, outputModels.String())
-			assert.Equal(t, tc.expectedHandlers, outputHandlers.String())
-		})
+func TestGenerateCacheReferencedLayout(t *testing.T) {
+	dir := t.TempDir()
+	specFile := path.Join(dir, "api.yaml")
+	defFile := path.Join(dir, "def.yaml")
+	require.NoError(t, os.WriteFile(specFile, []byte(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          $ref: 'def.yaml#/components/schemas/Name'
+`), 0o600))
+	require.NoError(t, os.WriteFile(defFile, []byte(`openapi: 3.0.0
+info:
+  title: Definitions
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Name:
+      type: string
+`), 0o600))
+	generate := func(specs map[string]options.Settings) string {
+		t.Helper()
+		opts := &options.Options{
+			DirPrefix:     dir,
+			PackagePrefix: "packagename",
+			YAMLFiles:     []string{specFile},
+			Specs:         specs,
+		}
+		require.NoError(t, generator.NewGenerator(opts).Generate(context.Background()))
+		models, err := os.ReadFile(path.Join(dir, "generated/api/apimodels/models.go"))
+		require.NoError(t, err)
+
+		return string(models)
+	}
+
+	assert.Contains(t, generate(nil), `"packagename/generated/def/defmodels"`)
+	models := generate(map[string]options.Settings{
+		defFile: {ModelsPackage: "definitions", ModelsPath: "models/definitions"},
+	})
+	assert.Contains(t, models, `"packagename/models/definitions"`)
+	assert.Contains(t, models, "definitions.Name")
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	specFile := path.Join(dir, "api.yaml")
+	defFile := path.Join(dir, "def.yaml")
+	otherFile := path.Join(dir, "other.yaml")
+	writeSpec := func(fileName string, schema string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(fileName, []byte(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+`+schema), 0o600))
+	}
+	writeSpec(specFile, `    Pet:
+      type: object
+      properties:
+        name:
+          $ref: 'def.yaml#/components/schemas/Name'
+`)
+	writeSpec(defFile, "    Name:\n      type: string\n")
+	writeSpec(otherFile, "    Other:\n      type: string\n")
+
+	type report struct {
+		files []string
+		err   error
+	}
+	reports := make(chan report, 10)
+	watcher := generator.NewWatcher(&options.Options{
+		DirPrefix:     dir,
+		PackagePrefix: "packagename",
+		YAMLFiles:     []string{specFile, otherFile},
+	})
+	watcher.Interval = 10 * time.Millisecond
+	watcher.Debounce = 50 * time.Millisecond
+	watcher.Report = func(files []string, err error) {
+		reports <- report{files: files, err: err}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- watcher.Watch(ctx)
+	}()
+	next := func() report {
+		t.Helper()
+		select {
+		case r := <-reports:
+			return r
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "no generation")
+
+			return report{}
+		}
+	}
+
+	first := next()
+	require.NoError(t, first.err)
+	assert.Equal(t, []string{specFile, otherFile}, first.files)
+
+	// the modification times of quick writes may be equal, the sizes differ
+	writeSpec(defFile, "    Name:\n      type: integer\n")
+	writeSpec(defFile, "    Name:\n      type: number\n")
+	second := next()
+	require.NoError(t, second.err)
+	assert.Equal(t, []string{specFile, defFile}, second.files)
+	models, err := os.ReadFile(path.Join(dir, "generated/def/defmodels/models.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(models), "type Name float64")
+
+	writeSpec(specFile, "    Pet: {type: objec}\n")
+	third := next()
+	assert.Equal(t, []string{specFile}, third.files)
+	require.Error(t, third.err)
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Empty(t, reports)
+}
+
+func TestGenerateRoutes(t *testing.T) {
+	gen := generator.NewGenerator(&options.Options{
+		DirPrefix:     "internal",
+		PackagePrefix: "packagename",
+		YAMLFiles:     []string{"api.yaml"},
+	})
+	gen.ReadFile = func(string) ([]byte, error) {
+		return []byte(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+    post:
+      operationId: createPet
+      responses:
+        '200':
+          description: OK
+`), nil
+	}
+	gen.Output = make(map[string][]byte)
+	require.NoError(t, gen.Generate(context.Background()))
+	assert.Equal(t, []generator.Route{
+		{File: "api.yaml", Method: "GET", Path: "/pets", Handler: "GetPetsHandler"},
+		{File: "api.yaml", Method: "POST", Path: "/pets", OperationID: "createPet", Handler: "CreatepetHandler"},
+	}, gen.Routes)
+}
+
+func TestLint(t *testing.T) {
+	gen := generator.NewGenerator(&options.Options{
+		DirPrefix:     "internal",
+		PackagePrefix: "packagename",
+		YAMLFiles:     []string{"api.yaml"},
+	})
+	gen.ReadFile = func(string) ([]byte, error) {
+		return []byte(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      parameters:
+        - {name: limit, in: query, schema: {type: integer}}
+        - {name: user-id, in: query, schema: {type: string}}
+        - {name: user_id, in: query, schema: {type: string}}
+      responses:
+        '200':
+          description: OK
+    post:
+      operationId: createItem
+      requestBody:
+        content:
+          text/plain:
+            schema:
+              type: string
+      responses:
+        '200':
+          description: OK
+    head:
+      operationId: headItems
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Item:
+      type: object
+      properties:
+        id:
+          type: string
+          format: uuid
+          pattern: '^[a-f0-9-]+$'
+        count:
+          type: string
+          multipleOf: 2
+        kind:
+          oneOf:
+            - type: string
+            - type: integer
+`), nil
+	}
+
+	findings, err := gen.Lint(context.Background())
+	require.NoError(t, err)
+	type finding struct {
+		severity generator.Severity
+		rule     string
+		pointer  string
+		line     int
+	}
+	actual := make([]finding, 0, len(findings))
+	for _, f := range findings {
+		assert.Equal(t, "api.yaml", f.File)
+		actual = append(actual, finding{severity: f.Severity, rule: f.Rule, pointer: f.Pointer, line: f.Line})
+	}
+	assert.Equal(t, []finding{
+		{generator.SeverityInfo, generator.RuleOperationID, "#/paths/~1items/get", 7},
+		{generator.SeverityError, generator.RuleParamType, "#/paths/~1items/get/parameters/0/schema", 9},
+		{generator.SeverityError, generator.RuleNameCollision, "#/paths/~1items/get/parameters/2", 11},
+		{generator.SeverityError, generator.RuleContentType, "#/paths/~1items/post/requestBody/content/text~1plain", 19},
+		{generator.SeverityWarning, generator.RuleMethod, "#/paths/~1items/head", 25},
+		{generator.SeverityWarning, generator.RuleIgnoredValidator, "#/components/schemas/Item/properties/id/pattern", 38},
+		{generator.SeverityWarning, generator.RuleIgnoredValidator, "#/components/schemas/Item/properties/count/multipleOf", 41},
+		{generator.SeverityWarning, generator.RuleComposition, "#/components/schemas/Item/properties/kind/oneOf", 43},
+	}, actual)
+	assert.Equal(t, "api.yaml:9:36: error: query parameter limit of type integer is not supported, "+
+		"only string parameters are [param-type] (#/paths/~1items/get/parameters/0/schema)", findings[1].String())
+}
+
+func TestLintTypeNames(t *testing.T) {
+	gen := generator.NewGenerator(&options.Options{
+		DirPrefix:     "internal",
+		PackagePrefix: "packagename",
+		YAMLFiles:     []string{"api.yaml"},
+		NullableType:  true,
+	})
+	gen.ReadFile = func(string) ([]byte, error) {
+		return []byte(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      operationId: getItems
+      parameters:
+        - {name: sort, in: query, schema: {type: string, enum: [asc, desc]}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  day: {type: string, format: date}
+                  note: {type: string, nullable: true}
+components:
+  schemas:
+    Date:
+      type: string
+    Foo:
+      type: object
+      properties:
+        bar:
+          type: object
+          properties:
+            x: {type: string}
+    FooBar:
+      type: object
+      required: [y]
+      properties:
+        y: {type: string}
+    GetitemsQueryParamsSort:
+      type: string
+    GetitemsRequest:
+      type: string
+    Nullable:
+      type: boolean
+`), nil
+	}
+
+	findings, err := gen.Lint(context.Background())
+	require.NoError(t, err)
+	type finding struct {
+		rule    string
+		pointer string
+		message string
 	}
+	actual := make([]finding, 0, len(findings))
+	for _, f := range findings {
+		assert.Equal(t, generator.SeverityError, f.Severity)
+		actual = append(actual, finding{rule: f.Rule, pointer: f.Pointer, message: f.Message})
+	}
+	assert.Equal(t, []finding{
+		{generator.RuleNameCollision, "#/components/schemas/Date",
+			"the Go type Date collides with the helper type generated for the date format"},
+		{generator.RuleNameCollision, "#/components/schemas/FooBar",
+			"the Go type FooBar is already generated for #/components/schemas/Foo/properties/bar"},
+		{generator.RuleNameCollision, "#/components/schemas/GetitemsQueryParamsSort",
+			"the Go type GetitemsQueryParamsSort is already generated for #/paths/~1items/get/parameters/0/schema"},
+		{generator.RuleNameCollision, "#/components/schemas/GetitemsRequest",
+			"the Go type GetitemsRequest is already generated for #/paths/~1items/get"},
+		{generator.RuleNameCollision, "#/components/schemas/Nullable",
+			"the Go type Nullable collides with the helper type generated for nullable fields"},
+	}, actual)
+}
+
+func TestDiffSpecs(t *testing.T) {
+	dir := t.TempDir()
+	oldFile := path.Join(dir, "old", "api.yaml")
+	newFile := path.Join(dir, "api.yaml")
+	require.NoError(t, os.Mkdir(path.Join(dir, "old"), 0o700))
+	require.NoError(t, os.WriteFile(oldFile, []byte(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    delete:
+      operationId: deletePet
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: string}}
+      responses:
+        '204':
+          description: OK
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+        kind: {type: string, enum: [cat, dog]}
+        age: {type: integer}
+`), 0o600))
+	require.NoError(t, os.WriteFile(newFile, []byte(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      responses:
+        '201':
+          description: Created
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      parameters:
+        - {name: petId, in: path, required: true, schema: {type: string}}
+        - {name: tenant, in: header, required: true, schema: {type: string}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+        kind: {type: string, enum: [cat, dog, bird]}
+        age: {type: string}
+        tag: {type: string}
+`), 0o600))
+
+	opts := &options.Options{DirPrefix: "internal", PackagePrefix: "packagename"}
+	specDiff, err := generator.DiffSpecs(opts, oldFile, newFile)
+	require.NoError(t, err)
+	assert.True(t, specDiff.Breaking())
+	type change struct {
+		kind     string
+		breaking bool
+		pointer  string
+	}
+	changes := make([]change, 0, len(specDiff.Changes))
+	for _, c := range specDiff.Changes {
+		changes = append(changes, change{kind: c.Kind, breaking: c.Breaking, pointer: c.Pointer})
+	}
+	assert.Equal(t, []change{
+		{generator.ChangeRemovedBody, true, "#/paths/~1pets/post/requestBody"},
+		{generator.ChangeRemovedContent, true, "#/paths/~1pets/post/responses/201/content/application~1json"},
+		{generator.ChangeNewRequiredParameter, true, "#/paths/~1pets~1{petId}/get/parameters/1"},
+		{generator.ChangeRemovedOperation, true, "#/paths/~1pets~1{id}/delete"},
+		{generator.ChangeType, true, "#/components/schemas/Pet/properties/age"},
+		{generator.ChangeWidenedEnum, true, "#/components/schemas/Pet/properties/kind/enum"},
+		{generator.ChangeAddedField, false, "#/components/schemas/Pet/properties/tag"},
+	}, changes)
+	assert.Contains(t, specDiff.Symbols,
+		generator.SymbolChange{Symbol: "api.DeletepetHandler", Change: "removed", Breaking: true})
+	assert.Contains(t, specDiff.Symbols, generator.SymbolChange{Symbol: "apimodels.Pet.Age", Change: "changed"})
+	assert.Contains(t, specDiff.Symbols, generator.SymbolChange{Symbol: "apimodels.PetKindBird", Change: "added"})
+	assert.NotContains(t, specDiff.Symbols, generator.SymbolChange{Symbol: "api.GetpetHandler", Change: "changed"})
+
+	specDiff, err = generator.DiffSpecs(opts, newFile, newFile)
+	require.NoError(t, err)
+	assert.False(t, specDiff.Breaking())
+	assert.Empty(t, specDiff.Changes)
+	assert.Empty(t, specDiff.Symbols)
+}
+
+func TestDiffSpecsRemovedSymbols(t *testing.T) {
+	dir := t.TempDir()
+	oldFile := path.Join(dir, "old", "api.yaml")
+	newFile := path.Join(dir, "api.yaml")
+	require.NoError(t, os.Mkdir(path.Join(dir, "old"), 0o700))
+	spec := func(parameters string) []byte {
+		return []byte(`openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - {name: tenant, in: header, required: true, schema: {type: string}}
+` + parameters + `      responses:
+        '204':
+          description: OK
+`)
+	}
+	require.NoError(t, os.WriteFile(oldFile, spec("        - {name: cursor, in: query, schema: {type: string}}\n"), 0o600))
+	require.NoError(t, os.WriteFile(newFile, spec(""), 0o600))
+
+	opts := &options.Options{DirPrefix: "internal", PackagePrefix: "packagename"}
+	specDiff, err := generator.DiffSpecs(opts, oldFile, newFile)
+	require.NoError(t, err)
+	require.Len(t, specDiff.Changes, 1)
+	assert.Equal(t, generator.ChangeRemovedParameter, specDiff.Changes[0].Kind)
+	assert.False(t, specDiff.Changes[0].Breaking)
+	assert.Contains(t, specDiff.Symbols,
+		generator.SymbolChange{Symbol: "apimodels.ListpetsQueryParams.Cursor", Change: "removed", Breaking: true})
+	assert.True(t, specDiff.Breaking())
+}
+
+func TestGenerateDiagnostics(t *testing.T) {
+	input := `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: string
+      responses:
+        '200':
+          description: OK
+  /items/batch:
+    post:
+      requestBody:
+        content:
+          text/plain:
+            schema:
+              type: string
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Item:
+      type: object
+      properties:
+        tags:
+          type: array
+          items:
+            type: object
+            properties:
+              user_id:
+                type: string
+              user-id:
+                type: string
+    Valid:
+      type: string
+`
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+	})
+	gen.CurrentYAMLFile = "api.yaml"
+	gen.PackageName = "packagename"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(strings.NewReader(input))
+	require.NoError(t, err)
+	err = gen.GenerateFiles()
+
+	var diagnostics generator.Diagnostics
+	require.ErrorAs(t, err, &diagnostics)
+	require.Len(t, diagnostics, 3)
+	assert.Equal(t, "#/paths/~1items~1batch/post/requestBody/content/text~1plain", diagnostics[0].Pointer)
+	assert.Equal(t, "#/paths/~1items/get/requestBody", diagnostics[1].Pointer)
+	assert.Equal(t, "#/components/schemas/Item/properties/tags/items", diagnostics[2].Pointer)
+	assert.Equal(t, []string{
+		"api.yaml:20:11: #/paths/~1items~1batch/post/requestBody/content/text~1plain: unsupported content type text/plain",
+		"api.yaml:8:7: #/paths/~1items/get/requestBody: GET method should not have request body",
+		"api.yaml:33:11: #/components/schemas/Item/properties/tags/items: " +
+			"model ItemTagsItem has several fields with the Go name UserID",
+	}, strings.Split(diagnostics.Error(), "\n"))
+}
+
+func TestGenerateAllOfPatterns(t *testing.T) {
+	input := `openapi: 3.0.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /items:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                code:
+                  $ref: '#/components/schemas/Code'
+                name:
+                  allOf:
+                    - $ref: '#/components/schemas/Lower'
+                    - maxLength: 10
+                      pattern: '^[a-z]+$'
+      responses:
+        '204':
+          description: OK
+components:
+  schemas:
+    Lower:
+      type: string
+      pattern: '^[a-z]+$'
+    Code:
+      allOf:
+        - $ref: '#/components/schemas/Lower'
+        - pattern: '^.{3}$'
+`
+	gen := generator.NewGenerator(&options.Options{
+		PackagePrefix: "packagename",
+	})
+	gen.CurrentYAMLFile = "api.yaml"
+	gen.PackageName = "packagename"
+	gen.ModelsImportPath = "packagename/imports/models"
+	err := gen.PrepareAndRead(strings.NewReader(input))
+	require.NoError(t, err)
+	err = gen.GenerateFiles()
+
+	var diagnostics generator.Diagnostics
+	require.ErrorAs(t, err, &diagnostics)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "api.yaml:33:11: #/components/schemas/Code/allOf/1: "+
+		`pattern "^.{3}$" conflicts with the pattern "^[a-z]+$" of the schema or of another allOf part, `+
+		"only one pattern is validated", diagnostics.Error())
 }
@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+	"log/slog"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	goTypeExtension                    = "x-go-type"
+	goTypeImportExtension              = "x-go-type-import"
+	goTypeSkipOptionalPointerExtension = "x-go-type-skip-optional-pointer"
+)
+
+// GetGoTypeOverride returns the Go type set with x-go-type or an empty string.
+func GetGoTypeOverride(schema *openapi3.SchemaRef) string {
+	if schema == nil || schema.Value == nil {
+		return ""
+	}
+	goType, ok := schema.Value.Extensions[goTypeExtension].(string)
+	if !ok {
+		return ""
+	}
+
+	return goType
+}
+
+// GetGoTypeImport returns the import path of the x-go-type, which is set either
+// as a plain string or as an object with the path field.
+func GetGoTypeImport(schema *openapi3.SchemaRef) string {
+	if schema == nil || schema.Value == nil {
+		return ""
+	}
+	switch value := schema.Value.Extensions[goTypeImportExtension].(type) {
+	case string:
+		return value
+	case map[string]any:
+		if name, ok := value["name"]; ok {
+			slog.Warn("import aliases are not supported, the package name is used", slog.Any("name", name))
+		}
+		path, _ := value["path"].(string)
+
+		return path
+	}
+
+	return ""
+}
+
+func skipOptionalPointer(schema *openapi3.SchemaRef) bool {
+	if schema == nil || schema.Value == nil {
+		return false
+	}
+	skip, _ := schema.Value.Extensions[goTypeSkipOptionalPointerExtension].(bool)
+
+	return skip
+}
+
+func (g *Generator) GetGoTypeOverrideForSchemas(schema *openapi3.SchemaRef) string {
+	goType := GetGoTypeOverride(schema)
+	if goType == "" {
+		return ""
+	}
+	if importPath := GetGoTypeImport(schema); importPath != "" {
+		g.AddSchemasImport(importPath)
+	}
+
+	return goType
+}
+
+func (g *Generator) GetGoTypeOverrideForHandlers(schema *openapi3.SchemaRef) string {
+	goType := GetGoTypeOverride(schema)
+	if goType == "" {
+		return ""
+	}
+	if importPath := GetGoTypeImport(schema); importPath != "" {
+		g.AddHandlersImport(importPath)
+	}
+
+	return goType
+}
+
+func (g *Generator) ProcessGoTypeSchema(modelName string, schema *openapi3.SchemaRef) {
+	g.SchemasFile.decls = append(g.SchemasFile.decls, &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: I(modelName),
+				// alias keeps the methods of the original type
+				Assign: 1,
+				Type:   I(g.GetGoTypeOverrideForSchemas(schema)),
+			},
+		},
+	})
+}
+
+// AssignTextField parses a parameter into an x-go-type implementing
+// encoding.TextUnmarshaler.
+func (g *Generator) AssignTextField(paramsName string, varName string, fieldName string, param *openapi3.SchemaRef, required bool) []ast.Stmt {
+	goType := g.GetGoTypeOverrideForHandlers(param)
+	g.AddHandlersImport("github.com/go-faster/errors")
+	parsedName := "parsed" + fieldName
+
+	var rhs ast.Expr
+	if (required || skipOptionalPointer(param)) && !g.HandlersFile.requiredFieldsArePointers {
+		rhs = I(parsedName)
+	} else {
+		rhs = Amp(I(parsedName))
+	}
+
+	return []ast.Stmt{
+		&ast.DeclStmt{Decl: &ast.GenDecl{
+			Tok:   token.VAR,
+			Specs: []ast.Spec{&ast.ValueSpec{Names: []*ast.Ident{I(parsedName)}, Type: I(goType)}},
+		}},
+		&ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{I("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{
+					Fun:  Sel(I(parsedName), "UnmarshalText"),
+					Args: []ast.Expr{&ast.CallExpr{Fun: &ast.ArrayType{Elt: I("byte")}, Args: []ast.Expr{I(varName)}}},
+				}},
+			},
+			Cond: Ne(I("err"), I("nil")),
+			Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(
+				I("nil"),
+				&ast.CallExpr{
+					Fun:  Sel(I("errors"), "Wrap"),
+					Args: []ast.Expr{I("err"), Str(fieldName + " is not a valid " + goType)},
+				},
+			)}},
+		},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{Sel(I(paramsName), fieldName)},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{rhs},
+		},
+	}
+}
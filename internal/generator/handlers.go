@@ -12,6 +12,7 @@ import (
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-faster/errors"
+	"github.com/jolfzverb/codegen/plugin"
 )
 
 type HandlersFile struct {
@@ -19,6 +20,7 @@ type HandlersFile struct {
 	packageName               *ast.Ident
 	packageImports            []string
 	interfaceDecls            []*ast.GenDecl
+	varDecls                  []*ast.GenDecl
 
 	handlerDecl            *ast.GenDecl
 	handlerDeclQAFieldList *ast.FieldList // quick access to handler struct field list
@@ -31,6 +33,10 @@ type HandlersFile struct {
 	handleDeclQASwitches  map[string]*ast.BlockStmt
 	restDecls             []*ast.FuncDecl
 	hasContainsNullMethod bool
+	hasMaxValidationDepth bool
+	validatorSetup        []ast.Stmt // statements run on the validator "v" in NewHandler
+	operationNames        map[string]string
+	operations            []plugin.Operation
 }
 
 func (g *Generator) InitHandlerImports() {
@@ -112,19 +118,20 @@ func (g *Generator) InitHandlerFields(packageName string) {
 func (g *Generator) NewHandlersFile() {
 	g.HandlersFile = &HandlersFile{
 		requiredFieldsArePointers: g.Opts.RequiredFieldsArePointers,
+		operationNames:            make(map[string]string),
 	}
 }
 
 func (g *Generator) WriteHandlersToOutput(output io.Writer) error {
 	const op = "generator.HandlersFile.WriteToOutput"
 	// go/ast package is great!
-	_, err := output.Write([]byte("// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.\n\n"))
+	_, err := output.Write([]byte(generatedHeader))
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
 
 	file := g.GenerateHandlersFile()
-	err = format.Node(output, token.NewFileSet(), file)
+	err = format.Node(output, PositionDocComments(file), file)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
@@ -132,7 +139,9 @@ func (g *Generator) WriteHandlersToOutput(output io.Writer) error {
 	return nil
 }
 
-func (g *Generator) AddHandlersInterface(name string, methodName string, requestName string, responseName string) {
+func (g *Generator) AddHandlersInterface(name string, methodName string, requestName string, responseName string,
+	doc []string, methodDoc []string,
+) {
 	var methodParams []*ast.Field
 	methodParams = append(methodParams, Field("ctx", Sel(I("context"), "Context"), ""))
 	methodParams = append(methodParams, Field("r", Sel(I(g.GetCurrentModelsPackage()), requestName), ""))
@@ -140,6 +149,7 @@ func (g *Generator) AddHandlersInterface(name string, methodName string, request
 	methodResults = append(methodResults, Field("", Star(Sel(I(g.GetCurrentModelsPackage()), responseName)), ""))
 	methodResults = append(methodResults, Field("", I("error"), ""))
 	g.HandlersFile.interfaceDecls = append(g.HandlersFile.interfaceDecls, &ast.GenDecl{
+		Doc: Doc(doc),
 		Tok: token.TYPE,
 		Specs: []ast.Spec{
 			&ast.TypeSpec{
@@ -147,6 +157,7 @@ func (g *Generator) AddHandlersInterface(name string, methodName string, request
 				Type: &ast.InterfaceType{
 					Methods: &ast.FieldList{
 						List: []*ast.Field{{
+							Doc:   Doc(methodDoc),
 							Names: []*ast.Ident{I(methodName)},
 							Type: &ast.FuncType{
 								Params: &ast.FieldList{
@@ -165,7 +176,7 @@ func (g *Generator) AddHandlersInterface(name string, methodName string, request
 }
 
 func (g *Generator) AddDependencyToHandlers(baseName string) {
-	fieldName := GoIdentLowercase(baseName)
+	fieldName := g.GoVarName(baseName)
 
 	g.HandlersFile.handlerDeclQAFieldList.List = append(g.HandlersFile.handlerDeclQAFieldList.List,
 		Field(fieldName, I(baseName+"Handler"), ""))
@@ -236,7 +247,30 @@ func (g *Generator) GenerateImportsSpecs(imp []string) ([]*ast.ImportSpec, []ast
 	return specs, declSpecs
 }
 
+func (g *Generator) FinalizeHandlerConstructor() {
+	if len(g.HandlersFile.validatorSetup) == 0 {
+		return
+	}
+	// the validator is always the first field of the handler initializer
+	validatorValue, ok := g.HandlersFile.handlerConstructorDeclQAConstructorComposite.Elts[0].(*ast.KeyValueExpr)
+	if !ok {
+		return
+	}
+	setup := []ast.Stmt{&ast.AssignStmt{
+		Lhs: []ast.Expr{I("v")},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{validatorValue.Value},
+	}}
+	setup = append(setup, g.HandlersFile.validatorSetup...)
+	constructorBody := g.HandlersFile.handlerConstructorDecl.Body
+	constructorBody.List = append(setup, constructorBody.List...)
+	validatorValue.Value = I("v")
+}
+
 func (g *Generator) GenerateHandlersFile() *ast.File {
+	g.AddCustomValidatorsIfNeeded()
+	g.AddNullableValidation()
+	g.FinalizeHandlerConstructor()
 	importSpecs, declSpecs := g.GenerateImportsSpecs(g.HandlersFile.packageImports)
 
 	g.FinalizeHandlerSwitches()
@@ -254,6 +288,9 @@ func (g *Generator) GenerateHandlersFile() *ast.File {
 	for _, d := range g.HandlersFile.interfaceDecls {
 		file.Decls = append(file.Decls, d)
 	}
+	for _, d := range g.HandlersFile.varDecls {
+		file.Decls = append(file.Decls, d)
+	}
 
 	file.Decls = append(file.Decls, g.HandlersFile.handlerDecl)
 	file.Decls = append(file.Decls, g.HandlersFile.handlerConstructorDecl)
@@ -453,7 +490,7 @@ func (g *Generator) AddHandleOperationMethodHandlers(baseName string) {
 				Tok: token.DEFINE,
 				Rhs: []ast.Expr{
 					&ast.CallExpr{
-						Fun: Sel(Sel(I("h"), GoIdentLowercase(baseName)), "Handle"+baseName),
+						Fun: Sel(Sel(I("h"), g.GoVarName(baseName)), "Handle"+baseName),
 						Args: []ast.Expr{
 							I("ctx"),
 							Star(I("request")),
@@ -853,7 +890,7 @@ func (g *Generator) AddParsePathParamsMethod(baseName string, params openapi3.Pa
 			continue
 		}
 
-		varName := GoIdentLowercase(FormatGoLikeIdentifier(param.Value.Name))
+		varName := g.GoVarName(g.GetParamGoName(param))
 		bodyList = append(bodyList, &ast.AssignStmt{
 			Lhs: []ast.Expr{I(varName)},
 			Tok: token.DEFINE,
@@ -876,10 +913,16 @@ func (g *Generator) AddParsePathParamsMethod(baseName string, params openapi3.Pa
 			},
 		})
 		g.AddHandlersImport("github.com/go-faster/errors")
+		goType := g.ParamGoType(baseName+"PathParams", param)
 		switch {
+		case param.Value.Schema.Value.Type.Permits("string") && (goType != nil ||
+			!stringFormatIsPlain(param.Value.Schema.Value.Format) || GetGoTypeOverride(param.Value.Schema) != ""):
+			bodyList = append(bodyList,
+				g.AssignStringField("pathParams", varName, g.GetParamGoName(param), param.Value.Schema, goType, true)...,
+			)
 		case param.Value.Schema.Value.Type.Permits("string"):
 			bodyList = append(bodyList, &ast.AssignStmt{
-				Lhs: []ast.Expr{Sel(I("pathParams"), FormatGoLikeIdentifier(param.Value.Name))},
+				Lhs: []ast.Expr{Sel(I("pathParams"), g.GetParamGoName(param))},
 				Tok: token.ASSIGN,
 				Rhs: []ast.Expr{
 					I(varName),
@@ -890,18 +933,7 @@ func (g *Generator) AddParsePathParamsMethod(baseName string, params openapi3.Pa
 		}
 	}
 
-	bodyList = append(bodyList, &ast.AssignStmt{
-		Lhs: []ast.Expr{I("err")},
-		Tok: token.DEFINE,
-		Rhs: []ast.Expr{
-			&ast.CallExpr{
-				Fun: Sel(Sel(I("h"), "validator"), "Struct"),
-				Args: []ast.Expr{
-					I("pathParams"),
-				},
-			},
-		},
-	})
+	bodyList = append(bodyList, ValidateParamsStmt(bodyList, "pathParams"))
 	bodyList = append(bodyList, &ast.IfStmt{
 		Cond: Ne(I("err"), I("nil")),
 		Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
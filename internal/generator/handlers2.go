@@ -30,7 +30,8 @@ func (g *Generator) AddParseQueryParamsMethod(baseName string, params openapi3.P
 			continue
 		}
 
-		varName := GoIdentLowercase(FormatGoLikeIdentifier(param.Value.Name))
+		varName := g.GoVarName(g.GetParamGoName(param))
+		goType := g.ParamGoType(baseName+"QueryParams", param)
 		bodyList = append(bodyList, &ast.AssignStmt{
 			Lhs: []ast.Expr{I(varName)},
 			Tok: token.DEFINE,
@@ -62,32 +63,26 @@ func (g *Generator) AddParseQueryParamsMethod(baseName string, params openapi3.P
 			switch {
 			case param.Value.Schema.Value.Type.Permits("string"):
 				bodyList = append(bodyList,
-					g.AssignStringField("queryParams", varName, FormatGoLikeIdentifier(param.Value.Name), param.Value.Schema, param.Value.Required)...,
+					g.AssignStringField("queryParams", varName, g.GetParamGoName(param), param.Value.Schema, goType, param.Value.Required)...,
 				)
 			default:
 				return errors.New(fmt.Sprintf("unsupported path parameter type: %v", param.Value.Schema.Value.Type)) //nolint:revive
 			}
+		} else if defaultValue := GetDefaultValueExpr(param.Value.Schema); defaultValue != nil {
+			bodyList = append(bodyList, AssignDefaultIfEmpty(varName, defaultValue))
+			bodyList = append(bodyList,
+				g.AssignStringField("queryParams", varName, g.GetParamGoName(param), param.Value.Schema, goType, g.hasValueDefault(param.Value.Schema))...,
+			)
 		} else {
 			bodyList = append(bodyList, &ast.IfStmt{
 				Cond: Ne(I(varName), Str("")),
 				Body: &ast.BlockStmt{
-					List: g.AssignStringField("queryParams", varName, FormatGoLikeIdentifier(param.Value.Name), param.Value.Schema, param.Value.Required),
+					List: g.AssignStringField("queryParams", varName, g.GetParamGoName(param), param.Value.Schema, goType, param.Value.Required),
 				},
 			})
 		}
 	}
-	bodyList = append(bodyList, &ast.AssignStmt{
-		Lhs: []ast.Expr{I("err")},
-		Tok: token.DEFINE,
-		Rhs: []ast.Expr{
-			&ast.CallExpr{
-				Fun: Sel(Sel(I("h"), "validator"), "Struct"),
-				Args: []ast.Expr{
-					I("queryParams"),
-				},
-			},
-		},
-	})
+	bodyList = append(bodyList, ValidateParamsStmt(bodyList, "queryParams"))
 	bodyList = append(bodyList, &ast.IfStmt{
 		Cond: Ne(I("err"), I("nil")),
 		Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
@@ -110,9 +105,63 @@ func (g *Generator) AddParseQueryParamsMethod(baseName string, params openapi3.P
 	return nil
 }
 
-func (g *Generator) AssignStringField(paramsName string, varName string, fieldName string, param *openapi3.SchemaRef, required bool) []ast.Stmt {
-	if param.Value.Format == "date-time" {
-		g.AddHandlersImport("time")
+// ParamGoType returns the named string type of a param in the handlers, or nil
+// when the param is a plain string or is parsed from its format.
+func (g *Generator) ParamGoType(modelName string, param *openapi3.ParameterRef) ast.Expr {
+	schema := param.Value.Schema
+	if GetGoTypeOverride(schema) != "" || !stringFormatIsPlain(schema.Value.Format) {
+		return nil
+	}
+	if schema.Ref != "" {
+		typeName, importPath := g.ParseSchemaRefTypeName(schema)
+		if importPath != "" {
+			g.AddHandlersImport(importPath)
+
+			return I(typeName)
+		}
+
+		return Sel(I(g.GetCurrentModelsPackage()), typeName)
+	}
+	if isEnumSchema(schema) {
+		return Sel(I(g.GetCurrentModelsPackage()), modelName+g.GetParamGoName(param))
+	}
+
+	return nil
+}
+
+// AssignStringField assigns the string variable to the field of the params,
+// converted to goType when it is not nil.
+func (g *Generator) AssignStringField(paramsName string, varName string, fieldName string, param *openapi3.SchemaRef,
+	goType ast.Expr, required bool,
+) []ast.Stmt {
+	if GetGoTypeOverride(param) != "" {
+		return g.AssignTextField(paramsName, varName, fieldName, param, required)
+	}
+	if goType != nil {
+		value := &ast.CallExpr{Fun: goType, Args: []ast.Expr{I(varName)}}
+		if required && !g.HandlersFile.requiredFieldsArePointers {
+			return []ast.Stmt{&ast.AssignStmt{
+				Lhs: []ast.Expr{Sel(I(paramsName), fieldName)},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{value},
+			}}
+		}
+
+		return []ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("parsed" + fieldName)},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{value},
+			},
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{Sel(I(paramsName), fieldName)},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{Amp(I("parsed" + fieldName))},
+			},
+		}
+	}
+	if parseExpr := g.GetStringParseExpr(param.Value.Format, varName); parseExpr != nil {
+		g.AddHandlersImport("github.com/go-faster/errors")
 		var result []ast.Stmt
 		result = append(result, &ast.AssignStmt{
 			Lhs: []ast.Expr{
@@ -120,15 +169,7 @@ func (g *Generator) AssignStringField(paramsName string, varName string, fieldNa
 				I("err"),
 			},
 			Tok: token.DEFINE,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: Sel(I("time"), "Parse"),
-					Args: []ast.Expr{
-						Sel(I("time"), "RFC3339"),
-						I(varName),
-					},
-				},
-			},
+			Rhs: []ast.Expr{parseExpr},
 		})
 		result = append(result, &ast.IfStmt{
 			Cond: Ne(I("err"), I("nil")),
@@ -139,7 +180,7 @@ func (g *Generator) AssignStringField(paramsName string, varName string, fieldNa
 						Fun: Sel(I("errors"), "Wrap"),
 						Args: []ast.Expr{
 							I("err"),
-							Str(fieldName + " is not a valid date-time format"),
+							Str(fieldName + " is not a valid " + param.Value.Format + " format"),
 						},
 					},
 				)},
@@ -172,6 +213,47 @@ func (g *Generator) AssignStringField(paramsName string, varName string, fieldNa
 	}}
 }
 
+// ValidateParamsStmt validates the parsed params, err is only defined when no
+// earlier statement of body defined it while parsing a param.
+func ValidateParamsStmt(body []ast.Stmt, paramsName string) ast.Stmt {
+	tok := token.DEFINE
+	for _, stmt := range body {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.DEFINE {
+			continue
+		}
+		for _, lhs := range assign.Lhs {
+			if ident, ok := lhs.(*ast.Ident); ok && ident.Name == "err" {
+				tok = token.ASSIGN
+			}
+		}
+	}
+
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{I("err")},
+		Tok: tok,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun:  Sel(Sel(I("h"), "validator"), "Struct"),
+				Args: []ast.Expr{I(paramsName)},
+			},
+		},
+	}
+}
+
+func AssignDefaultIfEmpty(varName string, defaultValue ast.Expr) ast.Stmt {
+	return &ast.IfStmt{
+		Cond: Eq(I(varName), Str("")),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{&ast.AssignStmt{
+				Lhs: []ast.Expr{I(varName)},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{defaultValue},
+			}},
+		},
+	}
+}
+
 func (g *Generator) AddParseHeadersMethod(baseName string, params openapi3.Parameters) error {
 	bodyList := []ast.Stmt{
 		&ast.DeclStmt{
@@ -192,13 +274,14 @@ func (g *Generator) AddParseHeadersMethod(baseName string, params openapi3.Param
 		}
 		if g.Opts.AllowRemoteAddrParam && param.Value.Name == "Remote-Addr" && param.Value.Schema.Value.Format == "remote-addr" {
 			bodyList = append(bodyList, &ast.AssignStmt{
-				Lhs: []ast.Expr{Sel(I("headers"), FormatGoLikeIdentifier(param.Value.Name))},
+				Lhs: []ast.Expr{Sel(I("headers"), g.GetParamGoName(param))},
 				Tok: token.ASSIGN,
 				Rhs: []ast.Expr{Sel(I("r"), "RemoteAddr")},
 			})
 			continue
 		}
-		varName := GoIdentLowercase(FormatGoLikeIdentifier(param.Value.Name))
+		varName := g.GoVarName(g.GetParamGoName(param))
+		goType := g.ParamGoType(baseName+"Headers", param)
 		bodyList = append(bodyList, &ast.AssignStmt{
 			Lhs: []ast.Expr{I(varName)},
 			Tok: token.DEFINE,
@@ -227,36 +310,32 @@ func (g *Generator) AddParseHeadersMethod(baseName string, params openapi3.Param
 			switch {
 			case param.Value.Schema.Value.Type.Permits("string"):
 				bodyList = append(bodyList,
-					g.AssignStringField("headers", varName, FormatGoLikeIdentifier(param.Value.Name),
-						param.Value.Schema, param.Value.Required,
+					g.AssignStringField("headers", varName, g.GetParamGoName(param),
+						param.Value.Schema, goType, param.Value.Required,
 					)...,
 				)
 			default:
 				return errors.New("unsupported path parameter type: " + fmt.Sprint(param.Value.Schema.Value.Type))
 			}
+		} else if defaultValue := GetDefaultValueExpr(param.Value.Schema); defaultValue != nil {
+			bodyList = append(bodyList, AssignDefaultIfEmpty(varName, defaultValue))
+			bodyList = append(bodyList,
+				g.AssignStringField("headers", varName, g.GetParamGoName(param),
+					param.Value.Schema, goType, g.hasValueDefault(param.Value.Schema),
+				)...,
+			)
 		} else {
 			bodyList = append(bodyList, &ast.IfStmt{
 				Cond: Ne(I(varName), Str("")),
 				Body: &ast.BlockStmt{
-					List: g.AssignStringField("headers", varName, FormatGoLikeIdentifier(param.Value.Name),
-						param.Value.Schema, param.Value.Required,
+					List: g.AssignStringField("headers", varName, g.GetParamGoName(param),
+						param.Value.Schema, goType, param.Value.Required,
 					),
 				},
 			})
 		}
 	}
-	bodyList = append(bodyList, &ast.AssignStmt{
-		Lhs: []ast.Expr{I("err")},
-		Tok: token.DEFINE,
-		Rhs: []ast.Expr{
-			&ast.CallExpr{
-				Fun: Sel(Sel(I("h"), "validator"), "Struct"),
-				Args: []ast.Expr{
-					I("headers"),
-				},
-			},
-		},
-	})
+	bodyList = append(bodyList, ValidateParamsStmt(bodyList, "headers"))
 	bodyList = append(bodyList, &ast.IfStmt{
 		Cond: Ne(I("err"), I("nil")),
 		Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(I("nil"), I("err"))}},
@@ -296,7 +375,8 @@ func (g *Generator) AddParseCookiesMethod(baseName string, params openapi3.Param
 			continue
 		}
 
-		varName := GoIdentLowercase(FormatGoLikeIdentifier(param.Value.Name))
+		varName := g.GoVarName(g.GetParamGoName(param))
+		goType := g.ParamGoType(baseName+"Cookies", param)
 		bodyList = append(bodyList, &ast.AssignStmt{
 			Lhs: []ast.Expr{I(varName), I("err")},
 			Tok: token.DEFINE,
@@ -344,13 +424,34 @@ func (g *Generator) AddParseCookiesMethod(baseName string, params openapi3.Param
 			switch {
 			case param.Value.Schema.Value.Type.Permits("string"):
 				bodyList = append(bodyList,
-					g.AssignStringField("cookies", varName+"Value", FormatGoLikeIdentifier(param.Value.Name),
-						param.Value.Schema, param.Value.Required,
+					g.AssignStringField("cookies", varName+"Value", g.GetParamGoName(param),
+						param.Value.Schema, goType, param.Value.Required,
 					)...,
 				)
 			default:
 				return errors.New("unsupported path parameter type: " + fmt.Sprint(param.Value.Schema.Value.Type))
 			}
+		} else if defaultValue := GetDefaultValueExpr(param.Value.Schema); defaultValue != nil {
+			bodyList = append(bodyList, &ast.AssignStmt{
+				Lhs: []ast.Expr{I(varName + "Value")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{defaultValue},
+			})
+			bodyList = append(bodyList, &ast.IfStmt{
+				Cond: Eq(I("err"), I("nil")),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{&ast.AssignStmt{
+						Lhs: []ast.Expr{I(varName + "Value")},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{Sel(I(varName), "Value")},
+					}},
+				},
+			})
+			bodyList = append(bodyList,
+				g.AssignStringField("cookies", varName+"Value", g.GetParamGoName(param),
+					param.Value.Schema, goType, g.hasValueDefault(param.Value.Schema),
+				)...,
+			)
 		} else {
 			ifBody := []ast.Stmt{&ast.AssignStmt{
 				Lhs: []ast.Expr{I(varName + "Value")},
@@ -358,8 +459,8 @@ func (g *Generator) AddParseCookiesMethod(baseName string, params openapi3.Param
 				Rhs: []ast.Expr{Sel(I(varName), "Value")},
 			}}
 			ifBody = append(ifBody,
-				g.AssignStringField("cookies", varName+"Value", FormatGoLikeIdentifier(param.Value.Name),
-					param.Value.Schema, param.Value.Required,
+				g.AssignStringField("cookies", varName+"Value", g.GetParamGoName(param),
+					param.Value.Schema, goType, param.Value.Required,
 				)...,
 			)
 			bodyList = append(bodyList, &ast.IfStmt{
@@ -400,29 +501,31 @@ func (g *Generator) AddParseCookiesMethod(baseName string, params openapi3.Param
 	return nil
 }
 
-func (g *Generator) GetValidateFuncStmt(typeName string, ref string) ast.Expr {
-	validateFuncName := "Validate" + typeName + "JSON"
+// ValidateFuncCall calls the JSON validation of a model. Models of the same
+// package are validated at the given depth and in the given direction by the
+// unexported function, the exported one starts at depth 0 without the checks
+// of requests. Models of other packages and calls without a depth use the
+// exported one.
+func (g *Generator) ValidateFuncCall(typeName string, ref string, arg ast.Expr, depth ast.Expr, request ast.Expr,
+) ast.Expr {
+	if ref != "" && refIsExternal(ref) {
+		if filename := parseFilenameFromRef(ref); filename != "" {
+			// typeName of an external model is prefixed by its package
+			if _, baseName, ok := strings.Cut(typeName, "."); ok {
+				typeName = baseName
+			}
+			g.YAMLFilesToProcess = append(g.YAMLFilesToProcess, g.GetYAMLFilePath(filename))
+			layout := g.GetLayout(g.GetYAMLFilePath(filename))
+			g.AddHandlersImport(layout.HandlersImport)
 
-	if ref == "" || !refIsExternal(ref) {
-		return I(validateFuncName)
+			return &ast.CallExpr{Fun: Sel(I(layout.Package), "Validate"+typeName+"JSON"), Args: []ast.Expr{arg}}
+		}
 	}
-
-	filename := parseFilenameFromRef(ref)
-	if filename == "" {
-		return I(validateFuncName)
+	if depth == nil {
+		return &ast.CallExpr{Fun: I("Validate" + typeName + "JSON"), Args: []ast.Expr{arg}}
 	}
 
-	parts := strings.Split(ref, "/")
-	if len(parts) == 0 {
-		return I(validateFuncName)
-	}
-
-	validateFuncName = "Validate" + parts[len(parts)-1] + "JSON"
-
-	g.YAMLFilesToProcess = append(g.YAMLFilesToProcess, g.GetYAMLFilePath(filename))
-	g.AddHandlersImport(g.GetHandlersImportForFile(filename))
-	modelName := g.GetModelName(filename)
-	return Sel(I(modelName), validateFuncName)
+	return &ast.CallExpr{Fun: I("validate" + typeName + "JSON"), Args: []ast.Expr{arg, depth, request}}
 }
 
 func (g *Generator) AddParseRequestBodyMethod(baseName string, contentType string, body *openapi3.RequestBodyRef) error {
@@ -441,7 +544,7 @@ func (g *Generator) AddParseRequestBodyMethod(baseName string, contentType strin
 	if ok && content.Schema != nil {
 		if content.Schema.Ref != "" {
 			var importPath string
-			typeName, importPath = g.ParseRefTypeName(content.Schema.Ref)
+			typeName, importPath = g.ParseSchemaRefTypeName(content.Schema)
 			bodyType = Sel(I(g.GetCurrentModelsPackage()), typeName)
 			if importPath != "" {
 				g.AddHandlersImport(importPath)
@@ -489,12 +592,8 @@ func (g *Generator) AddParseRequestBodyMethod(baseName string, contentType strin
 		Lhs: []ast.Expr{I("err")},
 		Tok: token.ASSIGN,
 		Rhs: []ast.Expr{
-			&ast.CallExpr{
-				Fun: g.GetValidateFuncStmt(typeName, content.Schema.Ref),
-				Args: []ast.Expr{
-					I("bodyJSON"),
-				},
-			},
+			g.ValidateFuncCall(typeName, content.Schema.Ref, I("bodyJSON"),
+				&ast.BasicLit{Kind: token.INT, Value: "0"}, I("true")),
 		},
 	})
 	bodyList = append(bodyList, &ast.IfStmt{
@@ -744,7 +843,7 @@ func (g *Generator) AddCreateResponseModel(baseName string, code string, respons
 			astType = Sel(I(g.GetCurrentModelsPackage()), typeName)
 			if json.Schema.Ref != "" {
 				var importPath string
-				typeName, importPath = g.ParseRefTypeName(json.Schema.Ref)
+				typeName, importPath = g.ParseSchemaRefTypeName(json.Schema)
 				if refIsExternal(json.Schema.Ref) {
 					astType = I(typeName)
 				} else {
@@ -875,6 +974,9 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 	nullableFields := make([]string, 0)
 	objectFields := make(map[string]ast.Expr, 0)
 
+	readOnlyFields := make([]string, 0)
+	writeOnlyRequired := make(map[string]bool, 0)
+
 	for _, requiredField := range schema.Value.Required {
 		requiredFieldsMap[requiredField] = true
 	}
@@ -883,27 +985,35 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 		if fieldSchema.Value == nil {
 			continue
 		}
+		if fieldSchema.Value.ReadOnly {
+			// read-only fields are optional and not allowed in requests
+			delete(requiredFieldsMap, fieldName)
+			readOnlyFields = append(readOnlyFields, fieldName)
+		}
+		if fieldSchema.Value.WriteOnly && requiredFieldsMap[fieldName] {
+			// write-only fields are only required in requests
+			writeOnlyRequired[fieldName] = true
+		}
 		if fieldSchema.Value.Nullable && requiredFieldsMap[fieldName] {
 			nullableFields = append(nullableFields, fieldName)
 		}
+		if GetGoTypeOverride(fieldSchema) != "" {
+			// custom types are validated by their own unmarshalling
+			continue
+		}
 		if fieldSchema.Value.Type.Permits(openapi3.TypeObject) {
 			fieldType, err := g.GetFieldTypeFromSchema(modelName, fieldName, fieldSchema)
 			if err != nil {
 				return errors.Wrap(err, op)
 			}
-			objectFields[fieldName] = g.GetValidateFuncStmt(fieldType, fieldSchema.Ref)
+			objectFields[fieldName] = g.ValidateFuncCall(fieldType, fieldSchema.Ref, I("val"), nextDepth(), I("request"))
 		}
-		if fieldSchema.Value.Type.Permits(openapi3.TypeArray) {
-			if fieldSchema.Value.Items != nil {
-				itemsType := g.getMostNestedArrayItemType(fieldSchema.Value.Items)
-				if itemsType != nil && itemsType.Permits(openapi3.TypeObject) {
-					fieldType, err := g.GetFieldTypeFromSchema(modelName, fieldName, fieldSchema)
-					if err != nil {
-						return errors.Wrap(err, op)
-					}
-					objectFields[fieldName] = g.GetValidateFuncStmt(fieldType, fieldSchema.Ref)
-				}
+		if fieldSchema.Value.Type.Permits(openapi3.TypeArray) && needsJSONValidation(fieldSchema.Value.Items) {
+			fieldType, err := g.GetFieldTypeFromSchema(modelName, fieldName, fieldSchema)
+			if err != nil {
+				return errors.Wrap(err, op)
 			}
+			objectFields[fieldName] = g.ValidateFuncCall(fieldType, fieldSchema.Ref, I("val"), nextDepth(), I("request"))
 		}
 	}
 	requiredFields := make([]string, 0, len(requiredFieldsMap))
@@ -912,12 +1022,24 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 	}
 	sort.Strings(requiredFields)
 	sort.Strings(nullableFields)
+	sort.Strings(readOnlyFields)
+	parsesObject := len(requiredFields) > 0 || len(objectFields) > 0 || len(readOnlyFields) > 0
 
 	funcBody := make([]ast.Stmt, 0, len(objectFields))
 
 	if len(requiredFields) > 0 {
 		requiredFieldsElts := make([]ast.Expr, 0, len(requiredFields))
+		requestRequired := make([]ast.Stmt, 0, len(writeOnlyRequired))
 		for _, fieldName := range requiredFields {
+			if writeOnlyRequired[fieldName] {
+				requestRequired = append(requestRequired, &ast.AssignStmt{
+					Lhs: []ast.Expr{&ast.IndexExpr{X: I("requiredFields"), Index: Str(fieldName)}},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{I("true")},
+				})
+
+				continue
+			}
 			requiredFieldsElts = append(requiredFieldsElts, &ast.KeyValueExpr{
 				Key:   Str(fieldName),
 				Value: I("true"),
@@ -936,6 +1058,12 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 				},
 			},
 		})
+		if len(requestRequired) > 0 {
+			funcBody = append(funcBody, &ast.IfStmt{
+				Cond: I("request"),
+				Body: &ast.BlockStmt{List: requestRequired},
+			})
+		}
 
 		nullableFieldsElts := make([]ast.Expr, 0, len(nullableFields))
 		for _, fieldName := range nullableFields {
@@ -959,7 +1087,7 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 		})
 	}
 
-	if len(requiredFields) > 0 || len(objectFields) > 0 {
+	if parsesObject {
 		funcBody = append(funcBody, &ast.DeclStmt{
 			Decl: &ast.GenDecl{
 				Tok: token.VAR,
@@ -1005,6 +1133,8 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 				},
 			},
 		})
+	}
+	if parsesObject {
 		funcBody = append(funcBody, &ast.DeclStmt{
 			Decl: &ast.GenDecl{
 				Tok: token.VAR,
@@ -1099,6 +1229,50 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 		g.AddHandlersImport("github.com/go-faster/errors")
 	}
 
+	if len(readOnlyFields) > 0 {
+		readOnlyFieldsElts := make([]ast.Expr, 0, len(readOnlyFields))
+		for _, fieldName := range readOnlyFields {
+			readOnlyFieldsElts = append(readOnlyFieldsElts, Str(fieldName))
+		}
+		readOnlyCheck := &ast.RangeStmt{
+			Key:   I("_"),
+			Value: I("field"),
+			Tok:   token.DEFINE,
+			X:     &ast.CompositeLit{Type: &ast.ArrayType{Elt: I("string")}, Elts: readOnlyFieldsElts},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.IfStmt{
+						Init: &ast.AssignStmt{
+							Lhs: []ast.Expr{I("_"), I("exists")},
+							Tok: token.ASSIGN,
+							Rhs: []ast.Expr{&ast.IndexExpr{X: I("obj"), Index: I("field")}},
+						},
+						Cond: I("exists"),
+						Body: &ast.BlockStmt{
+							List: []ast.Stmt{
+								Ret1(&ast.CallExpr{
+									Fun: Sel(I("errors"), "New"),
+									Args: []ast.Expr{
+										&ast.BinaryExpr{
+											X:  &ast.BinaryExpr{X: Str("field "), Op: token.ADD, Y: I("field")},
+											Op: token.ADD,
+											Y:  Str(" is read only"),
+										},
+									},
+								}),
+							},
+						},
+					},
+				},
+			},
+		}
+		funcBody = append(funcBody, &ast.IfStmt{
+			Cond: I("request"),
+			Body: &ast.BlockStmt{List: []ast.Stmt{readOnlyCheck}},
+		})
+		g.AddHandlersImport("github.com/go-faster/errors")
+	}
+
 	objectFieldsNames := make([]string, 0, len(objectFields))
 	for fieldName := range objectFields {
 		objectFieldsNames = append(objectFieldsNames, fieldName)
@@ -1106,7 +1280,7 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 	sort.Strings(objectFieldsNames)
 
 	for _, fieldName := range objectFieldsNames {
-		fieldValidationFunc := objectFields[fieldName]
+		fieldValidation := objectFields[fieldName]
 		funcBody = append(funcBody, &ast.AssignStmt{
 			Lhs: []ast.Expr{I("val"), I("exists")},
 			Tok: token.ASSIGN,
@@ -1134,12 +1308,7 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 					&ast.AssignStmt{
 						Lhs: []ast.Expr{I("err")},
 						Tok: token.ASSIGN,
-						Rhs: []ast.Expr{
-							&ast.CallExpr{
-								Fun:  fieldValidationFunc,
-								Args: []ast.Expr{I("val")},
-							},
-						},
+						Rhs: []ast.Expr{fieldValidation},
 					},
 					&ast.IfStmt{
 						Cond: Ne(I("err"), I("nil")),
@@ -1168,20 +1337,21 @@ func (g *Generator) AddObjectValidate(modelName string, schema *openapi3.SchemaR
 	})
 
 	fieldName := "jsonData"
-	if len(requiredFields) == 0 && len(objectFields) == 0 {
+	if !parsesObject {
 		fieldName = "_"
 	}
+	depthName := "_"
+	if len(objectFields) > 0 {
+		depthName = "depth"
+		funcBody = append([]ast.Stmt{g.CheckValidationDepthStmt()}, funcBody...)
+	}
 
-	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func("Validate"+modelName+"JSON",
-		nil,
-		[]*ast.Field{
-			Field(fieldName, Sel(I("json"), "RawMessage"), ""),
-		},
-		[]*ast.Field{
-			Field("", I("error"), ""),
-		},
-		funcBody,
-	))
+	requestName := "_"
+	if len(objectFields) > 0 || len(readOnlyFields) > 0 || len(writeOnlyRequired) > 0 {
+		requestName = "request"
+	}
+
+	g.AddValidateFuncs(modelName, fieldName, depthName, requestName, funcBody)
 	return nil
 }
 
@@ -1192,16 +1362,10 @@ func (g *Generator) AddArrayValidate(modelName string, schema *openapi3.SchemaRe
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
-	validateFunc := g.GetValidateFuncStmt(elemType, schema.Value.Items.Ref)
-	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func("Validate"+modelName+"JSON",
-		nil,
-		[]*ast.Field{
-			Field("jsonData", Sel(I("json"), "RawMessage"), ""),
-		},
-		[]*ast.Field{
-			Field("", I("error"), ""),
-		},
+	validateCall := g.ValidateFuncCall(elemType, schema.Value.Items.Ref, I("obj"), nextDepth(), I("request"))
+	g.AddValidateFuncs(modelName, "jsonData", "depth", "request",
 		[]ast.Stmt{
+			g.CheckValidationDepthStmt(),
 			&ast.DeclStmt{
 				Decl: &ast.GenDecl{
 					Tok: token.VAR,
@@ -1238,51 +1402,35 @@ func (g *Generator) AddArrayValidate(modelName string, schema *openapi3.SchemaRe
 				Tok:   token.DEFINE,
 				X:     I("arr"),
 				Body: &ast.BlockStmt{
-					List: []ast.Stmt{
-						&ast.IfStmt{
-							Cond: &ast.UnaryExpr{
-								Op: token.NOT,
-								X: &ast.CallExpr{
-									Fun:  I("containsNull"),
-									Args: []ast.Expr{I("obj")},
-								},
+					List: g.elementNullCheck(schema.Value.Items, I("obj"), "item at index %d cannot be null", I("index"),
+						[]ast.Stmt{
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{I("err")},
+								Tok: token.ASSIGN,
+								Rhs: []ast.Expr{validateCall},
 							},
-							Body: &ast.BlockStmt{
-								List: []ast.Stmt{
-									&ast.AssignStmt{
-										Lhs: []ast.Expr{I("err")},
-										Tok: token.ASSIGN,
-										Rhs: []ast.Expr{
-											&ast.CallExpr{
-												Fun:  validateFunc,
-												Args: []ast.Expr{I("obj")},
-											},
-										},
-									},
-									&ast.IfStmt{
-										Cond: Ne(I("err"), I("nil")),
-										Body: &ast.BlockStmt{
-											List: []ast.Stmt{Ret1(&ast.CallExpr{
-												Fun: Sel(I("errors"), "Wrapf"),
-												Args: []ast.Expr{
-													I("err"),
-													Str("error validating object at index %d"),
-													I("index"),
-												},
-											})},
+							&ast.IfStmt{
+								Cond: Ne(I("err"), I("nil")),
+								Body: &ast.BlockStmt{
+									List: []ast.Stmt{Ret1(&ast.CallExpr{
+										Fun: Sel(I("errors"), "Wrapf"),
+										Args: []ast.Expr{
+											I("err"),
+											Str("error validating object at index %d"),
+											I("index"),
 										},
-									},
+									})},
 								},
 							},
 						},
-					},
+					),
 				},
 			},
 			&ast.ReturnStmt{
 				Results: []ast.Expr{I("nil")},
 			},
 		},
-	))
+	)
 	g.AddHandlersImport("github.com/go-faster/errors")
 
 	return nil
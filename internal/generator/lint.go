@@ -0,0 +1,660 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+type Severity string
+
+const (
+	// SeverityError is a construct the code cannot be generated for.
+	SeverityError Severity = "error"
+	// SeverityWarning is a construct the generated code ignores.
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+const (
+	RuleMethod           = "method"
+	RuleOperationID      = "operation-id"
+	RuleRequestBody      = "request-body"
+	RuleContentType      = "content-type"
+	RuleParamType        = "param-type"
+	RuleComposition      = "composition"
+	RuleNameCollision    = "name-collision"
+	RuleIgnoredValidator = "ignored-validator"
+	// RuleGeneration reports the other problems found by the generator.
+	RuleGeneration = "generation"
+)
+
+// Finding is a construct of a spec file which the generator does not handle.
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	File     string   `json:"file"`
+	Pointer  string   `json:"pointer"`
+	// Line and Column are 1-based, they are zero when the position is unknown.
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	location := f.File
+	if f.Line > 0 {
+		location += ":" + strconv.Itoa(f.Line) + ":" + strconv.Itoa(f.Column)
+	}
+
+	return fmt.Sprintf("%s: %s: %s [%s] (%s)", location, f.Severity, f.Message, f.Rule, f.Pointer)
+}
+
+// Lint generates the spec files in memory and returns the constructs of the
+// spec files which the generator does not handle, by spec file and position.
+// The error is only returned when a spec file cannot be loaded.
+func (g *Generator) Lint(ctx context.Context) ([]Finding, error) {
+	const op = "generator.Lint"
+	g.Output = make(map[string][]byte)
+	g.linting = true
+	err := g.Generate(ctx)
+	if err != nil {
+		return g.findings, errors.Wrap(err, op)
+	}
+
+	return g.findings, nil
+}
+
+var lintMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+var generatedMethods = map[string]bool{"get": true, "post": true, "delete": true, "put": true, "patch": true}
+
+type linter struct {
+	g        *Generator
+	findings []Finding
+	visited  map[*openapi3.Schema]bool
+	// typeOwners are the elements generating the Go types, keyed by type name.
+	typeOwners map[string]typeOwner
+}
+
+// typeOwner is the element of the spec file a Go type is generated for, the
+// schema is nil for the models of the operations.
+type typeOwner struct {
+	schema   *openapi3.Schema
+	segments []string
+}
+
+// lintFile returns the findings of the loaded spec file, the diagnostics of
+// its generation are added where no error was found in the same element.
+func (g *Generator) lintFile(diagnostics Diagnostics) []Finding {
+	l := &linter{g: g, visited: make(map[*openapi3.Schema]bool), typeOwners: make(map[string]typeOwner)}
+	if g.yaml.Paths != nil {
+		l.lintPaths(g.yaml.Paths)
+	}
+	if g.yaml.Components != nil {
+		l.lintSchemas(g.yaml.Components.Schemas)
+	}
+	l.lintTypeNames()
+
+	for _, diagnostic := range diagnostics {
+		if !l.hasError(diagnostic.Pointer) {
+			l.findings = append(l.findings, Finding{
+				Severity: SeverityError,
+				Rule:     RuleGeneration,
+				File:     diagnostic.File,
+				Pointer:  diagnostic.Pointer,
+				Line:     diagnostic.Line,
+				Column:   diagnostic.Column,
+				Message:  diagnostic.Message(),
+			})
+		}
+	}
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		return positionKey(l.findings[i]) < positionKey(l.findings[j])
+	})
+
+	return l.findings
+}
+
+// positionKey orders the findings by position, the ones without a position
+// come last.
+func positionKey(f Finding) int64 {
+	if f.Line == 0 {
+		return 1 << 62
+	}
+
+	return int64(f.Line)<<32 | int64(f.Column)
+}
+
+// hasError reports whether an error is found in the element at pointer, in an
+// element it contains or in an element containing it.
+func (l *linter) hasError(pointer string) bool {
+	for _, finding := range l.findings {
+		if finding.Severity != SeverityError {
+			continue
+		}
+		if isPointerPrefix(finding.Pointer, pointer) || isPointerPrefix(pointer, finding.Pointer) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isPointerPrefix(prefix string, pointer string) bool {
+	return pointer == prefix || strings.HasPrefix(pointer, prefix+"/")
+}
+
+func (l *linter) add(severity Severity, rule string, message string, segments []string) {
+	finding := Finding{
+		Severity: severity,
+		Rule:     rule,
+		File:     l.g.CurrentYAMLFile,
+		Pointer:  toPointer(segments),
+		Message:  message,
+	}
+	finding.Line, finding.Column = findPosition(l.g.source, segments)
+	l.findings = append(l.findings, finding)
+}
+
+func toPointer(segments []string) string {
+	pointer := "#"
+	for _, segment := range segments {
+		pointer += "/" + escapePointer(segment)
+	}
+
+	return pointer
+}
+
+// child returns segments with the child segments appended, without changing
+// segments.
+func child(segments []string, children ...string) []string {
+	return append(append(make([]string, 0, len(segments)+len(children)), segments...), children...)
+}
+
+func (l *linter) lintPaths(paths *openapi3.Paths) {
+	operationNames := make(map[string]string)
+	for _, pathName := range paths.InMatchingOrder() {
+		pathItem := paths.Value(pathName)
+		segments := []string{"paths", pathName}
+		if len(pathItem.Parameters) > 0 {
+			l.add(SeverityWarning, RuleParamType,
+				"parameters of a path are not generated, they must be set on the operations",
+				child(segments, "parameters"))
+		}
+		for _, method := range lintMethods {
+			operation := pathItem.GetOperation(strings.ToUpper(method))
+			if operation == nil {
+				continue
+			}
+			operationSegments := child(segments, method)
+			if !generatedMethods[method] {
+				l.add(SeverityWarning, RuleMethod,
+					strings.ToUpper(method)+" operations are not generated", operationSegments)
+
+				continue
+			}
+			l.lintOperation(pathName, method, operation, operationSegments, operationNames)
+		}
+	}
+}
+
+func (l *linter) lintOperation(pathName string, method string, operation *openapi3.Operation, segments []string,
+	operationNames map[string]string,
+) {
+	baseName := l.operationBaseName(pathName, method, operation)
+	if _, hasGoName := operation.Extensions[goNameExtension].(string); operation.OperationID == "" && !hasGoName {
+		l.add(SeverityInfo, RuleOperationID,
+			"operation has no operationId, its handler is named "+baseName+"Handler", segments)
+	}
+	operationName := strings.ToUpper(method) + " " + pathName
+	if other, ok := operationNames[baseName]; ok {
+		l.add(SeverityError, RuleNameCollision,
+			fmt.Sprintf("operations %s and %s have the same Go name %s", other, operationName, baseName), segments)
+	} else {
+		operationNames[baseName] = operationName
+	}
+
+	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+		l.lintRequestBody(method, operation.RequestBody.Value, child(segments, "requestBody"))
+	}
+
+	paramNames := make(map[string]string)
+	for i, param := range operation.Parameters {
+		if param == nil || param.Value == nil || isExternalRef(param.Ref) {
+			continue
+		}
+		paramSegments := child(segments, "parameters", strconv.Itoa(i))
+		l.lintParam(param, paramSegments, paramNames)
+	}
+
+	if operation.Responses != nil {
+		codes := make([]string, 0, operation.Responses.Len())
+		for code := range operation.Responses.Map() {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		for _, code := range codes {
+			response := operation.Responses.Value(code)
+			if response == nil || response.Value == nil || isExternalRef(response.Ref) {
+				continue
+			}
+			l.lintResponse(response.Value, child(segments, "responses", code))
+		}
+	}
+}
+
+// operationBaseName is the Go name the models and the handler of the
+// operation are prefixed with.
+func (l *linter) operationBaseName(pathName string, method string, operation *openapi3.Operation) string {
+	baseName := l.g.GoName(method) + l.g.GoName(pathName)
+	if operation.OperationID != "" {
+		baseName = l.g.GoName(operation.OperationID)
+	}
+	if goName, ok := operation.Extensions[goNameExtension].(string); ok && goName != "" {
+		baseName = goName
+	}
+
+	return baseName
+}
+
+func (l *linter) lintRequestBody(method string, body *openapi3.RequestBody, segments []string) {
+	switch {
+	case method == "get":
+		l.add(SeverityError, RuleRequestBody, "GET method should not have request body", segments)
+
+		return
+	case method == "delete" && !l.g.Opts.AllowDeleteWithBody:
+		l.add(SeverityError, RuleRequestBody, "DELETE method should not have request body", segments)
+
+		return
+	}
+	for _, contentType := range sortedKeys(body.Content) {
+		contentSegments := child(segments, "content", contentType)
+		if contentType != applicationJSONCT {
+			l.add(SeverityError, RuleContentType, "unsupported content type "+contentType, contentSegments)
+
+			continue
+		}
+		l.lintSchema(body.Content[contentType].Schema, child(contentSegments, "schema"))
+	}
+}
+
+func (l *linter) lintParam(param *openapi3.ParameterRef, segments []string, paramNames map[string]string) {
+	name := param.Value.Name
+	schema := param.Value.Schema
+	if schema == nil || schema.Value == nil {
+		l.add(SeverityWarning, RuleParamType, "parameter "+name+" without a schema is ignored", segments)
+
+		return
+	}
+	if !schema.Value.Type.Permits(openapi3.TypeString) {
+		l.add(SeverityError, RuleParamType,
+			fmt.Sprintf("%s parameter %s of type %s is not supported, only string parameters are",
+				param.Value.In, name, typeName(schema.Value)), child(segments, "schema"))
+	}
+	key := param.Value.In + " " + l.g.GetParamGoName(param)
+	if other, ok := paramNames[key]; ok && other != name {
+		l.add(SeverityError, RuleNameCollision,
+			fmt.Sprintf("%s parameters %s and %s have the same Go name %s",
+				param.Value.In, other, name, l.g.GetParamGoName(param)), segments)
+	} else {
+		paramNames[key] = name
+	}
+	l.lintSchema(schema, child(segments, "schema"))
+}
+
+func (l *linter) lintResponse(response *openapi3.Response, segments []string) {
+	if len(response.Content) > 1 {
+		l.add(SeverityError, RuleContentType, "multiple response content types are not supported",
+			child(segments, "content"))
+	}
+	for _, contentType := range sortedKeys(response.Content) {
+		contentSegments := child(segments, "content", contentType)
+		if contentType != applicationJSONCT {
+			l.add(SeverityError, RuleContentType, "unsupported response content type "+contentType, contentSegments)
+
+			continue
+		}
+		l.lintSchema(response.Content[contentType].Schema, child(contentSegments, "schema"))
+	}
+	for _, name := range sortedKeys(response.Headers) {
+		header := response.Headers[name]
+		if header == nil || header.Value == nil || header.Value.Schema == nil || header.Value.Schema.Value == nil {
+			continue
+		}
+		headerSegments := child(segments, "headers", name)
+		if !header.Value.Schema.Value.Type.Permits(openapi3.TypeString) {
+			l.add(SeverityError, RuleParamType,
+				fmt.Sprintf("response header %s of type %s is not supported, only string headers are",
+					name, typeName(header.Value.Schema.Value)), child(headerSegments, "schema"))
+		}
+		l.lintSchema(header.Value.Schema, child(headerSegments, "schema"))
+	}
+}
+
+func (l *linter) lintSchemas(schemas openapi3.Schemas) {
+	goNames := make(map[string]string, len(schemas))
+	for _, name := range sortedKeys(schemas) {
+		schema := schemas[name]
+		segments := []string{"components", "schemas", name}
+		goName := GetSchemaGoName(name, schema)
+		if other, ok := goNames[goName]; ok {
+			l.add(SeverityError, RuleNameCollision,
+				fmt.Sprintf("schemas %s and %s have the same Go name %s", other, name, goName), segments)
+		} else {
+			goNames[goName] = name
+		}
+		if schema != nil && !isExternalRef(schema.Ref) {
+			l.lintSchemaValue(schema, segments)
+		}
+	}
+}
+
+// lintSchema lints an inline schema, the referenced schemas are linted with
+// the components they are defined in.
+func (l *linter) lintSchema(schema *openapi3.SchemaRef, segments []string) {
+	if schema == nil || schema.Ref != "" {
+		return
+	}
+	l.lintSchemaValue(schema, segments)
+}
+
+func (l *linter) lintSchemaValue(schema *openapi3.SchemaRef, segments []string) {
+	value := schema.Value
+	if value == nil || l.visited[value] {
+		return
+	}
+	l.visited[value] = true
+
+	for _, keyword := range []struct {
+		name    string
+		present bool
+	}{
+		{name: "oneOf", present: len(value.OneOf) > 0},
+		{name: "anyOf", present: len(value.AnyOf) > 0},
+		{name: "not", present: value.Not != nil},
+	} {
+		if keyword.present {
+			l.add(SeverityWarning, RuleComposition,
+				keyword.name+" is not supported, the generated type ignores it", child(segments, keyword.name))
+		}
+	}
+	for _, ignored := range ignoredValidators(schema) {
+		l.add(SeverityWarning, RuleIgnoredValidator, ignored.keyword+" is ignored "+ignored.reason,
+			child(segments, ignored.keyword))
+	}
+
+	fieldNames := make(map[string]string, len(value.Properties))
+	for _, name := range sortedKeys(value.Properties) {
+		property := value.Properties[name]
+		propertySegments := child(segments, "properties", name)
+		goName := l.g.GetFieldGoName(name, property)
+		if other, ok := fieldNames[goName]; ok {
+			l.add(SeverityError, RuleNameCollision,
+				fmt.Sprintf("properties %s and %s have the same Go name %s", other, name, goName), propertySegments)
+		} else {
+			fieldNames[goName] = name
+		}
+		l.lintSchema(property, propertySegments)
+	}
+	l.lintSchema(value.Items, child(segments, "items"))
+	l.lintSchema(value.AdditionalProperties.Schema, child(segments, "additionalProperties"))
+}
+
+// lintTypeNames reports the Go types generated for several elements of the spec
+// file, claiming the type names in the order of the generator.
+func (l *linter) lintTypeNames() {
+	g := l.g
+	if g.yaml.Paths != nil {
+		for _, pathName := range g.yaml.Paths.InMatchingOrder() {
+			pathItem := g.yaml.Paths.Value(pathName)
+			for _, method := range []string{"get", "post", "delete", "put", "patch"} {
+				operation := pathItem.GetOperation(strings.ToUpper(method))
+				if operation != nil && l.isGenerated(method, operation) {
+					l.operationTypeNames(pathName, method, operation, []string{"paths", pathName, method})
+				}
+			}
+		}
+	}
+	if g.yaml.Components != nil {
+		goNames := make(map[string]bool, len(g.yaml.Components.Schemas))
+		for _, name := range sortedKeys(g.yaml.Components.Schemas) {
+			schema := g.yaml.Components.Schemas[name]
+			goName := GetSchemaGoName(name, schema)
+			if goNames[goName] {
+				// reported by lintSchemas
+				continue
+			}
+			goNames[goName] = true
+			l.schemaTypeNames(goName, schema, []string{"components", "schemas", name})
+		}
+	}
+
+	helpers := g.helperTypes()
+	for _, name := range sortedKeys(helpers) {
+		if owner, ok := l.typeOwners[name]; ok {
+			l.add(SeverityError, RuleNameCollision,
+				fmt.Sprintf("the Go type %s collides with the helper type generated for %s", name, helpers[name]),
+				owner.segments)
+		}
+	}
+}
+
+// isGenerated reports whether the generator generates the models of the
+// operation, it stops at the first request content type which is not JSON.
+func (l *linter) isGenerated(method string, operation *openapi3.Operation) bool {
+	if operation.RequestBody == nil {
+		return true
+	}
+	if method == "get" || (method == "delete" && !l.g.Opts.AllowDeleteWithBody) || operation.RequestBody.Value == nil {
+		return false
+	}
+	contentTypes := sortedKeys(operation.RequestBody.Value.Content)
+
+	return len(contentTypes) > 0 && contentTypes[0] == applicationJSONCT
+}
+
+func (l *linter) operationTypeNames(pathName string, method string, operation *openapi3.Operation, segments []string) {
+	g := l.g
+	baseName := l.operationBaseName(pathName, method, operation)
+	l.claimType(baseName+"Request", nil, segments)
+	l.claimType(baseName+"Response", nil, segments)
+
+	for _, params := range []struct {
+		in     string
+		suffix string
+	}{
+		{in: openapi3.ParameterInPath, suffix: "PathParams"},
+		{in: openapi3.ParameterInQuery, suffix: "QueryParams"},
+		{in: openapi3.ParameterInHeader, suffix: "Headers"},
+		{in: openapi3.ParameterInCookie, suffix: "Cookies"},
+	} {
+		found := false
+		for i, param := range operation.Parameters {
+			if param == nil || param.Value == nil || param.Value.In != params.in {
+				continue
+			}
+			found = true
+			schema := param.Value.Schema
+			if schema != nil && schema.Ref == "" && GetGoTypeOverride(schema) == "" && isEnumSchema(schema) {
+				l.schemaTypeNames(baseName+params.suffix+g.GetParamGoName(param), schema,
+					child(segments, "parameters", strconv.Itoa(i), "schema"))
+			}
+		}
+		if found {
+			l.claimType(baseName+params.suffix, nil, child(segments, "parameters"))
+		}
+	}
+
+	if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+		content := operation.RequestBody.Value.Content[applicationJSONCT]
+		if content != nil && content.Schema != nil && content.Schema.Ref == "" {
+			l.schemaTypeNames(baseName+"RequestBody", content.Schema,
+				child(segments, "requestBody", "content", applicationJSONCT, "schema"))
+		}
+	}
+
+	if operation.Responses == nil {
+		return
+	}
+	for _, code := range sortedKeys(operation.Responses.Map()) {
+		response := operation.Responses.Value(code)
+		if response == nil || response.Value == nil {
+			continue
+		}
+		responseSegments := child(segments, "responses", code)
+		l.claimType(baseName+"Response"+code, nil, responseSegments)
+		if len(response.Value.Content) == 1 {
+			for contentType, content := range response.Value.Content {
+				if content.Schema != nil && content.Schema.Ref == "" {
+					l.schemaTypeNames(baseName+"Response"+code+"Body", content.Schema,
+						child(responseSegments, "content", contentType, "schema"))
+				}
+			}
+		}
+		if len(response.Value.Headers) > 0 {
+			l.claimType(baseName+"Response"+code+"Headers", nil, responseSegments)
+		}
+	}
+}
+
+// schemaTypeNames claims the Go types of the schema and of its inline schemas,
+// named as by ProcessSchema.
+func (l *linter) schemaTypeNames(modelName string, schema *openapi3.SchemaRef, segments []string) {
+	if schema == nil || schema.Value == nil || isExternalRef(schema.Ref) {
+		return
+	}
+	if !l.claimType(modelName, schema.Value, segments) {
+		return
+	}
+	g := l.g
+	switch {
+	case GetGoTypeOverride(schema) != "", isEnumSchema(schema):
+	case isMapSchema(schema):
+		if value := mapValueSchema(schema); hasInlineType(value) {
+			l.schemaTypeNames(modelName+g.GetFieldGoName("Value", value), value, child(segments, "additionalProperties"))
+		}
+	case schema.Value.Type.Permits(openapi3.TypeObject):
+		for _, name := range sortedKeys(schema.Value.Properties) {
+			property := schema.Value.Properties[name]
+			if hasInlineType(property) {
+				l.schemaTypeNames(modelName+g.GetFieldGoName(name, property), property,
+					child(segments, "properties", name))
+			}
+		}
+	case schema.Value.Type.Permits(openapi3.TypeArray):
+		if items := schema.Value.Items; hasInlineType(items) {
+			l.schemaTypeNames(modelName+g.GetFieldGoName("Item", items), items, child(segments, "items"))
+		}
+	}
+}
+
+// hasInlineType reports whether a type of its own is generated for the schema
+// of a property, an item or a map value.
+func hasInlineType(schema *openapi3.SchemaRef) bool {
+	return schema != nil && schema.Value != nil && schema.Ref == "" && GetGoTypeOverride(schema) == "" &&
+		(isEnumSchema(schema) || schema.Value.Type.Permits(openapi3.TypeObject) ||
+			schema.Value.Type.Permits(openapi3.TypeArray))
+}
+
+// claimType records the element generating the Go type, it reports false when
+// the type is already generated, with a finding when it is generated for another
+// element.
+func (l *linter) claimType(name string, schema *openapi3.Schema, segments []string) bool {
+	owner, ok := l.typeOwners[name]
+	if !ok {
+		l.typeOwners[name] = typeOwner{schema: schema, segments: segments}
+
+		return true
+	}
+	if schema == nil || owner.schema != schema {
+		l.add(SeverityError, RuleNameCollision,
+			fmt.Sprintf("the Go type %s is already generated for %s", name, toPointer(owner.segments)), segments)
+	}
+
+	return false
+}
+
+type ignoredValidator struct {
+	keyword string
+	reason  string
+}
+
+// ignoredValidators returns the validation keywords of the schema which are
+// not turned into validate tags, see GetSchemaValidators.
+func ignoredValidators(schema *openapi3.SchemaRef) []ignoredValidator {
+	value := schema.Value
+	keywords := []struct {
+		name    string
+		kind    string
+		present bool
+	}{
+		{name: "minLength", kind: openapi3.TypeString, present: value.MinLength > 0},
+		{name: "maxLength", kind: openapi3.TypeString, present: value.MaxLength != nil},
+		{name: "pattern", kind: openapi3.TypeString, present: value.Pattern != ""},
+		{name: "minimum", kind: openapi3.TypeNumber, present: value.Min != nil},
+		{name: "maximum", kind: openapi3.TypeNumber, present: value.Max != nil},
+		{name: "exclusiveMinimum", kind: openapi3.TypeNumber, present: value.ExclusiveMin},
+		{name: "exclusiveMaximum", kind: openapi3.TypeNumber, present: value.ExclusiveMax},
+		{name: "multipleOf", kind: openapi3.TypeNumber, present: value.MultipleOf != nil},
+		{name: "minItems", kind: openapi3.TypeArray, present: value.MinItems > 0},
+		{name: "maxItems", kind: openapi3.TypeArray, present: value.MaxItems != nil},
+		{name: "uniqueItems", kind: openapi3.TypeArray, present: value.UniqueItems},
+		{name: "minProperties", kind: openapi3.TypeObject, present: value.MinProps > 0},
+		{name: "maxProperties", kind: openapi3.TypeObject, present: value.MaxProps != nil},
+	}
+
+	kind := ""
+	reason := "for type " + typeName(value)
+	switch {
+	case GetGoTypeOverride(schema) != "":
+		reason = "for " + goTypeExtension
+	case isMapSchema(schema):
+		kind = openapi3.TypeObject
+	case value.Type.Permits(openapi3.TypeString):
+		kind = openapi3.TypeString
+		if !stringFormatIsPlain(value.Format) {
+			kind = ""
+			reason = "for format " + value.Format
+		}
+	case value.Type.Permits(openapi3.TypeInteger), value.Type.Permits(openapi3.TypeNumber):
+		kind = openapi3.TypeNumber
+	case value.Type.Permits(openapi3.TypeArray):
+		kind = openapi3.TypeArray
+	}
+
+	var result []ignoredValidator
+	for _, keyword := range keywords {
+		if !keyword.present {
+			continue
+		}
+		switch {
+		case keyword.kind != kind:
+			result = append(result, ignoredValidator{keyword: keyword.name, reason: reason})
+		case keyword.name == "exclusiveMinimum" && value.Min == nil:
+			result = append(result, ignoredValidator{keyword: keyword.name, reason: "without minimum"})
+		case keyword.name == "exclusiveMaximum" && value.Max == nil:
+			result = append(result, ignoredValidator{keyword: keyword.name, reason: "without maximum"})
+		}
+	}
+
+	return result
+}
+
+func typeName(schema *openapi3.Schema) string {
+	if schema.Type == nil || len(schema.Type.Slice()) == 0 {
+		return "any"
+	}
+
+	return strings.Join(schema.Type.Slice(), ", ")
+}
+
+func isExternalRef(ref string) bool {
+	return ref != "" && !strings.HasPrefix(ref, "#")
+}
@@ -0,0 +1,249 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+// isMapSchema reports whether the object schema only has additionalProperties
+// and is generated as a Go map.
+func isMapSchema(schema *openapi3.SchemaRef) bool {
+	if schema == nil || schema.Value == nil || GetGoTypeOverride(schema) != "" {
+		return false
+	}
+	if !schema.Value.Type.Permits(openapi3.TypeObject) || len(schema.Value.Properties) > 0 {
+		return false
+	}
+	additional := schema.Value.AdditionalProperties
+
+	return additional.Schema != nil || (additional.Has != nil && *additional.Has)
+}
+
+// mapValueSchema returns the schema of the map values, additionalProperties: true
+// allows any value.
+func mapValueSchema(schema *openapi3.SchemaRef) *openapi3.SchemaRef {
+	return schema.Value.AdditionalProperties.Schema
+}
+
+// isNullableElement reports whether an array item or a map value may be null,
+// such elements are generated as pointers.
+func isNullableElement(schema *openapi3.SchemaRef) bool {
+	return schema != nil && schema.Value != nil && schema.Value.Nullable
+}
+
+// needsJSONValidation reports whether values of the schema are checked by a
+// generated Validate...JSON function, which is the case for objects, maps and
+// arrays containing them at any depth.
+func needsJSONValidation(schema *openapi3.SchemaRef) bool {
+	visited := make(map[*openapi3.Schema]bool)
+	for schema != nil && schema.Value != nil && GetGoTypeOverride(schema) == "" && !isEnumSchema(schema) {
+		switch {
+		case schema.Value.Type.Permits(openapi3.TypeObject):
+			return true
+		case schema.Value.Type.Permits(openapi3.TypeArray):
+			if visited[schema.Value] {
+				// an array of itself holds no objects
+				return false
+			}
+			visited[schema.Value] = true
+			schema = schema.Value.Items
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+func (g *Generator) ProcessMapSchema(modelName string, schema *openapi3.SchemaRef) error {
+	const op = "generator.ProcessMapSchema"
+	valueSchema := mapValueSchema(schema)
+	if valueSchema == nil {
+		g.AddMapAlias(modelName, "any")
+
+		return nil
+	}
+
+	if valueSchema.Ref == "" && GetGoTypeOverride(valueSchema) == "" {
+		switch {
+		case isEnumSchema(valueSchema),
+			valueSchema.Value.Type.Permits(openapi3.TypeObject),
+			valueSchema.Value.Type.Permits(openapi3.TypeArray):
+			err := g.ProcessSchema(modelName+g.GetFieldGoName("Value", valueSchema), valueSchema)
+			if err != nil {
+				return withPointer(errors.Wrap(err, op), "additionalProperties")
+			}
+		}
+	}
+
+	valueType, err := g.GetFieldTypeFromSchema(modelName, "Value", valueSchema)
+	if err != nil {
+		return withPointer(errors.Wrap(err, op), "additionalProperties")
+	}
+	if isNullableElement(valueSchema) {
+		valueType = "*" + valueType
+	}
+	g.AddMapAlias(modelName, valueType)
+
+	return nil
+}
+
+func (g *Generator) AddMapAlias(name string, typeName string) {
+	g.SchemasFile.decls = append(g.SchemasFile.decls, &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ast.NewIdent(name),
+				Type: &ast.MapType{
+					Key:   ast.NewIdent("string"),
+					Value: ast.NewIdent(typeName),
+				},
+			},
+		},
+	})
+}
+
+// GetMapValidators returns the validate tags of a map field, the value
+// validators are applied with dive.
+func GetMapValidators(schema *openapi3.SchemaRef) []string {
+	var validateTags []string
+	if schema.Value.MinProps > 0 {
+		validateTags = append(validateTags, "min="+strconv.FormatUint(schema.Value.MinProps, 10))
+	}
+	if schema.Value.MaxProps != nil {
+		validateTags = append(validateTags, "max="+strconv.FormatUint(*schema.Value.MaxProps, 10))
+	}
+	valueSchema := mapValueSchema(schema)
+	if valueSchema == nil {
+		return validateTags
+	}
+	validateTags = append(validateTags, "dive")
+
+	return append(validateTags, GetElementValidators(valueSchema)...)
+}
+
+// GetElementValidators returns the validators of an array item or a map value,
+// which are skipped for null elements.
+func GetElementValidators(schema *openapi3.SchemaRef) []string {
+	validators := GetSchemaValidators(schema)
+	if len(validators) > 0 && isNullableElement(schema) {
+		validators = append([]string{"omitnil"}, validators...)
+	}
+
+	return validators
+}
+
+func (g *Generator) AddMapValidate(modelName string, schema *openapi3.SchemaRef) error {
+	const op = "generator.AddMapValidate"
+	valueSchema := mapValueSchema(schema)
+	var elementCheck []ast.Stmt
+	if valueSchema != nil && needsJSONValidation(valueSchema) {
+		valueType, err := g.GetFieldTypeFromSchema(modelName, "Value", valueSchema)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		elementCheck = []ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("err")},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{g.ValidateFuncCall(valueType, valueSchema.Ref, I("val"), nextDepth(), I("request"))},
+			},
+			&ast.IfStmt{
+				Cond: Ne(I("err"), I("nil")),
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(&ast.CallExpr{
+					Fun:  Sel(I("errors"), "Wrapf"),
+					Args: []ast.Expr{I("err"), Str("error validating value of key %s"), I("key")},
+				})}},
+			},
+		}
+	}
+
+	var loopBody []ast.Stmt
+	if valueSchema != nil {
+		loopBody = g.elementNullCheck(valueSchema, I("val"), "value of key %s cannot be null", I("key"), elementCheck)
+	}
+	if len(loopBody) == 0 {
+		// there is nothing to check in the values
+		g.AddValidateFuncs(modelName, "_", "_", "_", []ast.Stmt{Ret1(I("nil"))})
+		g.AddHandlersImport("encoding/json")
+
+		return nil
+	}
+
+	g.AddValidateFuncs(modelName, "jsonData", "depth", "request",
+		[]ast.Stmt{
+			g.CheckValidationDepthStmt(),
+			&ast.DeclStmt{
+				Decl: &ast.GenDecl{
+					Tok: token.VAR,
+					Specs: []ast.Spec{
+						&ast.ValueSpec{
+							Names: []*ast.Ident{I("obj")},
+							Type:  &ast.MapType{Key: I("string"), Value: Sel(I("json"), "RawMessage")},
+						},
+					},
+				},
+			},
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{
+					Fun:  Sel(I("json"), "Unmarshal"),
+					Args: []ast.Expr{I("jsonData"), Amp(I("obj"))},
+				}},
+			},
+			&ast.IfStmt{
+				Cond: Ne(I("err"), I("nil")),
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(I("err"))}},
+			},
+			&ast.RangeStmt{
+				Key:   I("key"),
+				Value: I("val"),
+				Tok:   token.DEFINE,
+				X:     I("obj"),
+				Body: &ast.BlockStmt{
+					List: loopBody,
+				},
+			},
+			Ret1(I("nil")),
+		},
+	)
+	g.AddHandlersImport("github.com/go-faster/errors")
+
+	return nil
+}
+
+// elementNullCheck rejects null array items and map values unless the element
+// schema is nullable, and runs the element validation for the other values.
+func (g *Generator) elementNullCheck(schema *openapi3.SchemaRef, value ast.Expr, message string, arg ast.Expr,
+	check []ast.Stmt,
+) []ast.Stmt {
+	isNull := &ast.CallExpr{Fun: I("containsNull"), Args: []ast.Expr{value}}
+	if isNullableElement(schema) {
+		if len(check) == 0 {
+			return nil
+		}
+
+		g.AddContainsNullIfNeeded()
+
+		return []ast.Stmt{&ast.IfStmt{
+			Cond: &ast.UnaryExpr{Op: token.NOT, X: isNull},
+			Body: &ast.BlockStmt{List: check},
+		}}
+	}
+
+	g.AddContainsNullIfNeeded()
+	g.AddHandlersImport("github.com/go-faster/errors")
+
+	return append([]ast.Stmt{&ast.IfStmt{
+		Cond: isNull,
+		Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(&ast.CallExpr{
+			Fun:  Sel(I("errors"), "Errorf"),
+			Args: []ast.Expr{Str(message), arg},
+		})}},
+	}}, check...)
+}
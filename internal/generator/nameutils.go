@@ -3,10 +3,14 @@ package generator
 import (
 	"strings"
 
+	"github.com/getkin/kin-openapi/openapi3"
+
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
+const goNameExtension = "x-go-name"
+
 var commonInitialisms = map[string]bool{
 	"ACL":   true,
 	"API":   true,
@@ -54,8 +58,12 @@ var commonInitialisms = map[string]bool{
 }
 
 func GoIdentLowercase(name string) string {
+	return goIdentLowercase(name, commonInitialisms)
+}
+
+func goIdentLowercase(name string, initialisms map[string]bool) string {
 	match := 1
-	for i := range commonInitialisms {
+	for i := range initialisms {
 		if strings.HasPrefix(name, i) {
 			match = max(match, len(i))
 		}
@@ -69,6 +77,10 @@ func GoIdentLowercase(name string) string {
 }
 
 func FormatGoLikeIdentifier(name string) string {
+	return formatGoLikeIdentifier(name, commonInitialisms)
+}
+
+func formatGoLikeIdentifier(name string, initialisms map[string]bool) string {
 	name = strings.ReplaceAll(name, "{", "")
 	name = strings.ReplaceAll(name, "}", "")
 
@@ -87,7 +99,7 @@ func FormatGoLikeIdentifier(name string) string {
 
 	result := make([]string, 0, len(items3))
 	for _, item := range items3 {
-		if commonInitialisms[upperCaser.String(item)] {
+		if initialisms[upperCaser.String(item)] {
 			result = append(result, upperCaser.String(item))
 			continue
 		}
@@ -97,3 +109,65 @@ func FormatGoLikeIdentifier(name string) string {
 
 	return strings.Join(result, "")
 }
+
+// GoName formats an OpenAPI name as an exported Go identifier using the common
+// initialisms extended with the configured ones.
+func (g *Generator) GoName(name string) string {
+	return formatGoLikeIdentifier(name, g.getInitialisms())
+}
+
+func (g *Generator) GoVarName(name string) string {
+	return goIdentLowercase(name, g.getInitialisms())
+}
+
+func (g *Generator) getInitialisms() map[string]bool {
+	if len(g.Opts.Initialisms) == 0 {
+		return commonInitialisms
+	}
+	if g.initialisms == nil {
+		upperCaser := cases.Upper(language.Und)
+		g.initialisms = make(map[string]bool, len(commonInitialisms)+len(g.Opts.Initialisms))
+		for initialism := range commonInitialisms {
+			g.initialisms[initialism] = true
+		}
+		for _, initialism := range g.Opts.Initialisms {
+			g.initialisms[upperCaser.String(initialism)] = true
+		}
+	}
+
+	return g.initialisms
+}
+
+// GetGoName returns the x-go-name of an OpenAPI object or the formatted name.
+func (g *Generator) GetGoName(name string, extensions map[string]any) string {
+	if goName, ok := extensions[goNameExtension].(string); ok && goName != "" {
+		return goName
+	}
+
+	return g.GoName(name)
+}
+
+// GetFieldGoName returns the name of a property field, x-go-name of a referenced
+// schema names the type, not the field.
+func (g *Generator) GetFieldGoName(fieldName string, schema *openapi3.SchemaRef) string {
+	if schema == nil || schema.Value == nil || schema.Ref != "" {
+		return g.GoName(fieldName)
+	}
+
+	return g.GetGoName(fieldName, schema.Value.Extensions)
+}
+
+func (g *Generator) GetParamGoName(param *openapi3.ParameterRef) string {
+	return g.GetGoName(param.Value.Name, param.Value.Extensions)
+}
+
+// GetSchemaGoName returns the type name of a component schema.
+func GetSchemaGoName(name string, schema *openapi3.SchemaRef) string {
+	if schema != nil && schema.Value != nil {
+		if goName, ok := schema.Value.Extensions[goNameExtension].(string); ok && goName != "" {
+			return goName
+		}
+	}
+
+	return name
+}
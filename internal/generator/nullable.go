@@ -0,0 +1,258 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const nullableTypeName = "Nullable"
+
+var builtinTypes = map[string]bool{
+	"string": true, "bool": true, "int": true, "float64": true,
+	"int8": true, "int16": true, "int32": true, "int64": true,
+	"uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"[]byte": true,
+}
+
+func (g *Generator) isNullableField(schema *openapi3.SchemaRef) bool {
+	return g.Opts.NullableType && schema != nil && schema.Value != nil && schema.Value.Nullable
+}
+
+func (g *Generator) GetNullableFieldType(fieldType string) string {
+	g.AddNullableTypeIfNeeded()
+	g.SchemasFile.nullableTypeArgs = appendUnique(g.SchemasFile.nullableTypeArgs, fieldType)
+
+	return nullableTypeName + "[" + fieldType + "]"
+}
+
+func appendUnique(items []string, item string) []string {
+	for _, existing := range items {
+		if existing == item {
+			return items
+		}
+	}
+
+	return append(items, item)
+}
+
+func nullableReceiver(pointer bool) *ast.Field {
+	var recvType ast.Expr = &ast.IndexExpr{X: I(nullableTypeName), Index: I("T")}
+	if pointer {
+		recvType = Star(recvType)
+	}
+
+	return Field("n", recvType, "")
+}
+
+func notSetOrNull() ast.Expr {
+	return &ast.BinaryExpr{
+		X:  &ast.UnaryExpr{Op: token.NOT, X: Sel(I("n"), "Set")},
+		Op: token.LOR,
+		Y:  Sel(I("n"), "Null"),
+	}
+}
+
+func (g *Generator) AddNullableTypeIfNeeded() {
+	if g.SchemasFile.hasNullableType {
+		return
+	}
+	g.SchemasFile.hasNullableType = true
+	g.AddSchemasImport("encoding/json")
+
+	typeParams := &ast.FieldList{List: []*ast.Field{Field("T", I("any"), "")}}
+	nullableT := &ast.IndexExpr{X: I(nullableTypeName), Index: I("T")}
+
+	g.SchemasFile.decls = append(g.SchemasFile.decls, &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name:       I(nullableTypeName),
+				TypeParams: typeParams,
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						List: []*ast.Field{
+							Field("Value", I("T"), ""),
+							Field("Set", I("bool"), ""),
+							Field("Null", I("bool"), ""),
+						},
+					},
+				},
+			},
+		},
+	})
+
+	newNullable := Func("NewNullable", nil,
+		FieldA(Field("value", I("T"), "")),
+		FieldA(Field("", nullableT, "")),
+		[]ast.Stmt{Ret1(&ast.CompositeLit{
+			Type: nullableT,
+			Elts: []ast.Expr{
+				&ast.KeyValueExpr{Key: I("Value"), Value: I("value")},
+				&ast.KeyValueExpr{Key: I("Set"), Value: I("true")},
+			},
+		})},
+	)
+	newNullable.Type.TypeParams = typeParams
+
+	newNull := Func("NewNull", nil, nil,
+		FieldA(Field("", nullableT, "")),
+		[]ast.Stmt{Ret1(&ast.CompositeLit{
+			Type: nullableT,
+			Elts: []ast.Expr{
+				&ast.KeyValueExpr{Key: I("Set"), Value: I("true")},
+				&ast.KeyValueExpr{Key: I("Null"), Value: I("true")},
+			},
+		})},
+	)
+	newNull.Type.TypeParams = typeParams
+
+	get := Func("Get", nullableReceiver(false), nil,
+		[]*ast.Field{Field("", I("T"), ""), Field("", I("bool"), "")},
+		[]ast.Stmt{Ret2(
+			Sel(I("n"), "Value"),
+			&ast.BinaryExpr{
+				X:  Sel(I("n"), "Set"),
+				Op: token.LAND,
+				Y:  &ast.UnaryExpr{Op: token.NOT, X: Sel(I("n"), "Null")},
+			},
+		)},
+	)
+
+	isZero := Func("IsZero", nullableReceiver(false), nil,
+		FieldA(Field("", I("bool"), "")),
+		[]ast.Stmt{Ret1(&ast.UnaryExpr{Op: token.NOT, X: Sel(I("n"), "Set")})},
+	)
+
+	// a typed nil pointer lets the omitnil validator skip absent and null values
+	validationValue := Func("ValidationValue", nullableReceiver(false), nil,
+		FieldA(Field("", I("any"), "")),
+		[]ast.Stmt{
+			&ast.IfStmt{
+				Cond: notSetOrNull(),
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(&ast.CallExpr{
+					Fun:  &ast.ParenExpr{X: Star(I("T"))},
+					Args: []ast.Expr{I("nil")},
+				})}},
+			},
+			Ret1(Sel(I("n"), "Value")),
+		},
+	)
+
+	marshal := Func("MarshalJSON", nullableReceiver(false), nil,
+		[]*ast.Field{Field("", &ast.ArrayType{Elt: I("byte")}, ""), Field("", I("error"), "")},
+		[]ast.Stmt{
+			&ast.IfStmt{
+				Cond: notSetOrNull(),
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret2(
+					&ast.CallExpr{Fun: &ast.ArrayType{Elt: I("byte")}, Args: []ast.Expr{Str("null")}},
+					I("nil"),
+				)}},
+			},
+			Ret1(&ast.CallExpr{Fun: Sel(I("json"), "Marshal"), Args: []ast.Expr{Sel(I("n"), "Value")}}),
+		},
+	)
+
+	unmarshal := Func("UnmarshalJSON", nullableReceiver(true),
+		FieldA(Field("data", &ast.ArrayType{Elt: I("byte")}, "")),
+		FieldA(Field("", I("error"), "")),
+		[]ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{Sel(I("n"), "Set")},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{I("true")},
+			},
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{Sel(I("n"), "Null")},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{Eq(&ast.CallExpr{Fun: I("string"), Args: []ast.Expr{I("data")}}, Str("null"))},
+			},
+			&ast.IfStmt{
+				Cond: Sel(I("n"), "Null"),
+				Body: &ast.BlockStmt{List: []ast.Stmt{
+					&ast.DeclStmt{Decl: &ast.GenDecl{
+						Tok:   token.VAR,
+						Specs: []ast.Spec{&ast.ValueSpec{Names: []*ast.Ident{I("zero")}, Type: I("T")}},
+					}},
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{Sel(I("n"), "Value")},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{I("zero")},
+					},
+					Ret1(I("nil")),
+				}},
+			},
+			Ret1(&ast.CallExpr{
+				Fun:  Sel(I("json"), "Unmarshal"),
+				Args: []ast.Expr{I("data"), Amp(Sel(I("n"), "Value"))},
+			}),
+		},
+	)
+
+	g.SchemasFile.decls = append(g.SchemasFile.decls,
+		newNullable, newNull, get, isZero, validationValue, marshal, unmarshal)
+}
+
+// AddNullableValidation registers every Nullable instantiation of the models
+// package in the handler validator, so field validators apply to the wrapped value.
+func (g *Generator) AddNullableValidation() {
+	if len(g.SchemasFile.nullableTypeArgs) == 0 {
+		return
+	}
+	g.AddHandlersImport("reflect")
+
+	registerArgs := []ast.Expr{I("nullableValue")}
+	for _, typeArg := range g.SchemasFile.nullableTypeArgs {
+		if strings.Contains(typeArg, ".") {
+			// types from other packages would need extra imports and carry no field validators
+			continue
+		}
+		// recursive fields wrap a pointer to break the cycle
+		baseType, pointer := strings.CutPrefix(typeArg, "*")
+		var typeExpr ast.Expr = I(baseType)
+		if !builtinTypes[baseType] {
+			typeExpr = Sel(I(g.GetCurrentModelsPackage()), baseType)
+		}
+		if pointer {
+			typeExpr = Star(typeExpr)
+		}
+		registerArgs = append(registerArgs, &ast.CompositeLit{
+			Type: &ast.IndexExpr{X: Sel(I(g.GetCurrentModelsPackage()), nullableTypeName), Index: typeExpr},
+		})
+	}
+
+	g.HandlersFile.validatorSetup = append(g.HandlersFile.validatorSetup, &ast.ExprStmt{X: &ast.CallExpr{
+		Fun:  Sel(I("v"), "RegisterCustomTypeFunc"),
+		Args: registerArgs,
+	}})
+
+	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func("nullableValue",
+		nil,
+		FieldA(Field("field", Sel(I("reflect"), "Value"), "")),
+		FieldA(Field("", I("any"), "")),
+		[]ast.Stmt{
+			&ast.IfStmt{
+				Init: &ast.AssignStmt{
+					Lhs: []ast.Expr{I("value"), I("ok")},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{&ast.TypeAssertExpr{
+						X: &ast.CallExpr{Fun: Sel(I("field"), "Interface")},
+						Type: &ast.InterfaceType{Methods: &ast.FieldList{List: []*ast.Field{
+							Field("ValidationValue", &ast.FuncType{
+								Params:  &ast.FieldList{},
+								Results: &ast.FieldList{List: FieldA(Field("", I("any"), ""))},
+							}, ""),
+						}}},
+					}},
+				},
+				Cond: I("ok"),
+				Body: &ast.BlockStmt{List: []ast.Stmt{
+					Ret1(&ast.CallExpr{Fun: Sel(I("value"), "ValidationValue")}),
+				}},
+			},
+			Ret1(I("nil")),
+		},
+	))
+}
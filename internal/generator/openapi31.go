@@ -0,0 +1,332 @@
+package generator
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+	"github.com/oasdiff/yaml"
+)
+
+// unsupportedKeywords are JSON Schema 2020-12 keywords of OpenAPI 3.1 that have
+// no equivalent in the generated code.
+var unsupportedKeywords = map[string]bool{
+	"$defs":                 true,
+	"$id":                   true,
+	"$schema":               true,
+	"$anchor":               true,
+	"$dynamicRef":           true,
+	"$dynamicAnchor":        true,
+	"$vocabulary":           true,
+	"prefixItems":           true,
+	"contains":              true,
+	"minContains":           true,
+	"maxContains":           true,
+	"if":                    true,
+	"then":                  true,
+	"else":                  true,
+	"dependentSchemas":      true,
+	"dependentRequired":     true,
+	"patternProperties":     true,
+	"propertyNames":         true,
+	"unevaluatedItems":      true,
+	"unevaluatedProperties": true,
+}
+
+// schemaKeywords hold a single schema in a schema or in another object.
+var schemaKeywords = map[string]bool{
+	"schema":               true,
+	"items":                true,
+	"additionalProperties": true,
+	"not":                  true,
+}
+
+// schemaListKeywords hold lists of schemas.
+var schemaListKeywords = map[string]bool{
+	"allOf": true,
+	"oneOf": true,
+	"anyOf": true,
+}
+
+func isOpenAPI31(document map[string]any) bool {
+	version, _ := document["openapi"].(string)
+
+	return strings.HasPrefix(version, "3.1")
+}
+
+// ConvertOpenAPI31 rewrites an OpenAPI 3.1 document into the OpenAPI 3.0 form
+// read by the loader. Documents of other versions are returned as is.
+func ConvertOpenAPI31(data []byte) ([]byte, bool, error) {
+	const op = "generator.ConvertOpenAPI31"
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, false, errors.Wrap(err, op)
+	}
+	var document map[string]any
+	err = json.Unmarshal(jsonData, &document)
+	if err != nil || !isOpenAPI31(document) {
+		// the loader reports malformed documents
+		return data, false, nil
+	}
+
+	document["openapi"] = "3.0.3"
+	delete(document, "jsonSchemaDialect")
+	if _, ok := document["webhooks"]; ok {
+		slog.Warn("webhooks are not generated")
+		delete(document, "webhooks")
+	}
+	if _, ok := document["paths"]; !ok {
+		// paths are optional since 3.1
+		document["paths"] = map[string]any{}
+	}
+	if info, ok := document["info"].(map[string]any); ok {
+		delete(info, "summary")
+		if license, ok := info["license"].(map[string]any); ok {
+			delete(license, "identifier")
+		}
+	}
+
+	converted, err := convert31Fragment(document)
+	if err != nil {
+		return nil, false, errors.Wrap(err, op)
+	}
+
+	return converted, true, nil
+}
+
+func convert31Fragment(document map[string]any) ([]byte, error) {
+	err := convert31Node(document, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(document)
+}
+
+// ReadOpenAPI31FromURI returns a reader of the files referenced by an OpenAPI
+// 3.1 document, which converts them like the document itself.
+func ReadOpenAPI31FromURI(read openapi3.ReadFromURIFunc) openapi3.ReadFromURIFunc {
+	return func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		data, err := read(loader, location)
+		if err != nil {
+			return nil, err
+		}
+		jsonData, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		var document map[string]any
+		err = json.Unmarshal(jsonData, &document)
+		if err != nil {
+			return data, nil
+		}
+		var converted []byte
+		if _, ok := document["openapi"]; ok {
+			converted, _, err = ConvertOpenAPI31(data)
+		} else {
+			// fragments without a version share the version of the referencing document
+			converted, err = convert31Fragment(document)
+		}
+		var located *pointerError
+		if errors.As(err, &located) {
+			return nil, Diagnostics{newDiagnostic(location.String(), data, err)}
+		}
+
+		return converted, err
+	}
+}
+
+func convert31Node(node any, segments []string, schema bool) error {
+	switch node := node.(type) {
+	case map[string]any:
+		if schema {
+			err := convert31Schema(node)
+			if err != nil {
+				return withPointer(err, segments...)
+			}
+		} else if _, ok := node["$ref"]; ok {
+			// references outside of schemas may have a summary and a description since 3.1
+			for key := range node {
+				if key != "$ref" {
+					delete(node, key)
+				}
+			}
+		}
+		for _, key := range sortedKeys(node) {
+			switch {
+			case schema && key == "properties", !schema && key == "schemas":
+				properties, ok := node[key].(map[string]any)
+				if !ok {
+					continue
+				}
+				for _, name := range sortedKeys(properties) {
+					err := convert31Node(properties[name], childSegments(segments, key, name), true)
+					if err != nil {
+						return err
+					}
+				}
+			case schemaKeywords[key]:
+				err := convert31Node(node[key], childSegments(segments, key), true)
+				if err != nil {
+					return err
+				}
+			case schemaListKeywords[key] && schema:
+				parts, ok := node[key].([]any)
+				if !ok {
+					continue
+				}
+				for i, part := range parts {
+					err := convert31Node(part, childSegments(segments, key, strconv.Itoa(i)), true)
+					if err != nil {
+						return err
+					}
+				}
+			case key == "example" || key == "value":
+				// example values are data, not OpenAPI objects
+				continue
+			case !schema:
+				err := convert31Node(node[key], childSegments(segments, key), false)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	case []any:
+		for i, item := range node {
+			err := convert31Node(item, childSegments(segments, strconv.Itoa(i)), schema)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// convert31Schema maps the JSON Schema keywords of a schema object onto their
+// OpenAPI 3.0 equivalents and rejects the ones without any.
+func convert31Schema(schema map[string]any) error {
+	for _, key := range sortedKeys(schema) {
+		if unsupportedKeywords[key] {
+			return withPointer(errors.Errorf("unsupported OpenAPI 3.1 keyword %s", key), key)
+		}
+	}
+
+	if schema["type"] == openapi3.TypeNull {
+		return withPointer(errors.New("type null without another type is not supported"), "type")
+	}
+	if types, ok := schema["type"].([]any); ok {
+		var remaining []string
+		for _, item := range types {
+			name, _ := item.(string)
+			if name == openapi3.TypeNull {
+				schema["nullable"] = true
+
+				continue
+			}
+			remaining = append(remaining, name)
+		}
+		switch len(remaining) {
+		case 0:
+			return withPointer(errors.New("type null without another type is not supported"), "type")
+		case 1:
+			schema["type"] = remaining[0]
+		default:
+			return withPointer(errors.Errorf("multiple types %s are not supported", strings.Join(remaining, ", ")), "type")
+		}
+	}
+	if value, ok := schema["const"]; ok {
+		schema["enum"] = []any{value}
+		if _, ok := schema["type"]; !ok {
+			schema["type"] = constType(value)
+		}
+		delete(schema, "const")
+	}
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		// a union with null is the 3.1 way to make a reference nullable
+		parts, ok := schema[keyword].([]any)
+		if !ok || len(parts) != 2 {
+			continue
+		}
+		for i, part := range parts {
+			if part, ok := part.(map[string]any); ok && len(part) == 1 && part["type"] == openapi3.TypeNull {
+				delete(schema, keyword)
+				schema["allOf"] = []any{parts[1-i]}
+				schema["nullable"] = true
+
+				break
+			}
+		}
+	}
+	if examples, ok := schema["examples"].([]any); ok {
+		if _, ok := schema["example"]; !ok && len(examples) > 0 {
+			schema["example"] = examples[0]
+		}
+		delete(schema, "examples")
+	}
+	for keyword, bound := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+		value, ok := schema[keyword].(float64)
+		if !ok {
+			continue
+		}
+		// the stricter of both bounds is kept when both keywords are present
+		if inclusive, ok := schema[bound].(float64); ok &&
+			(bound == "minimum" && inclusive > value || bound == "maximum" && inclusive < value) {
+			delete(schema, keyword)
+
+			continue
+		}
+		schema[bound] = value
+		schema[keyword] = true
+	}
+	if encoding, ok := schema["contentEncoding"].(string); ok {
+		if _, ok := schema["format"]; !ok && encoding == "base64" {
+			schema["format"] = "byte"
+		}
+		delete(schema, "contentEncoding")
+	}
+	delete(schema, "contentMediaType")
+	delete(schema, "$comment")
+
+	if ref, ok := schema["$ref"]; ok && len(schema) > 1 {
+		// siblings of $ref are allowed since 3.1, allOf keeps them in 3.0
+		delete(schema, "$ref")
+		schema["allOf"] = []any{map[string]any{"$ref": ref}}
+	}
+
+	return nil
+}
+
+func constType(value any) string {
+	switch value := value.(type) {
+	case bool:
+		return openapi3.TypeBoolean
+	case float64:
+		if value == float64(int64(value)) {
+			return openapi3.TypeInteger
+		}
+
+		return openapi3.TypeNumber
+	}
+
+	return openapi3.TypeString
+}
+
+func sortedKeys[V any](node map[string]V) []string {
+	keys := make([]string, 0, len(node))
+	for key := range node {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,164 @@
+package options
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-faster/errors"
+	"github.com/oasdiff/yaml"
+)
+
+const (
+	DefaultConfigFile = "codegen.yaml"
+
+	RouterChi       = "chi"
+	GeneratorServer = "server"
+)
+
+var (
+	supportedRouters    = []string{RouterChi}
+	supportedGenerators = []string{GeneratorServer}
+)
+
+// Settings are the options which may be set for all specs and overridden for
+// a single one in the config file.
+type Settings struct {
+	Output        string   `json:"output,omitempty"`
+	PackagePrefix string   `json:"package-prefix,omitempty"`
+	Package       string   `json:"package,omitempty"`
+	ModelsPackage string   `json:"models-package,omitempty"`
+	HandlersPath  string   `json:"handlers-path,omitempty"`
+	ModelsPath    string   `json:"models-path,omitempty"`
+	Pointers      *bool    `json:"pointers,omitempty"`
+	Router        string   `json:"router,omitempty"`
+	Generate      []string `json:"generate,omitempty"`
+}
+
+type SpecConfig struct {
+	File string `json:"file"`
+	Settings
+}
+
+// Config is the content of a codegen.yaml file.
+type Config struct {
+	Settings
+	Specs []SpecConfig `json:"specs"`
+}
+
+// ReadConfig reads and validates a config file, the spec files and the output
+// directories in it are relative to the directory of the config file.
+func ReadConfig(fileName string) (*Config, error) {
+	const op = "options.ReadConfig"
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	var config Config
+	err = yaml.Unmarshal(data, &config, func(decoder *json.Decoder) *json.Decoder {
+		decoder.DisallowUnknownFields()
+
+		return decoder
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: invalid config %s", op, fileName)
+	}
+	err = config.Validate()
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: invalid config %s", op, fileName)
+	}
+
+	dir := filepath.Dir(fileName)
+	config.Output = relativeTo(dir, config.Output)
+	for i := range config.Specs {
+		config.Specs[i].File = relativeTo(dir, config.Specs[i].File)
+		config.Specs[i].Output = relativeTo(dir, config.Specs[i].Output)
+	}
+
+	return &config, nil
+}
+
+func relativeTo(dir string, fileName string) string {
+	if fileName == "" || filepath.IsAbs(fileName) {
+		return fileName
+	}
+
+	return filepath.Join(dir, fileName)
+}
+
+func (c *Config) Validate() error {
+	if c.Package != "" || c.ModelsPackage != "" || c.HandlersPath != "" || c.ModelsPath != "" {
+		return errors.New("package, models-package, handlers-path and models-path may only be set for a single spec")
+	}
+	err := c.Settings.Validate()
+	if err != nil {
+		return err
+	}
+
+	files := make(map[string]bool, len(c.Specs))
+	for i, spec := range c.Specs {
+		if spec.File == "" {
+			return errors.Errorf("specs[%d]: file is required", i)
+		}
+		if files[filepath.Clean(spec.File)] {
+			return errors.Errorf("specs[%d]: file %s is listed more than once", i, spec.File)
+		}
+		files[filepath.Clean(spec.File)] = true
+		err = spec.Validate()
+		if err != nil {
+			return errors.Wrapf(err, "specs[%d] (%s)", i, spec.File)
+		}
+	}
+
+	return nil
+}
+
+func (s *Settings) Validate() error {
+	if s.Package != "" && !isPackageName(s.Package) {
+		return errors.Errorf("package %q is not a valid Go package name", s.Package)
+	}
+	if s.ModelsPackage != "" && !isPackageName(s.ModelsPackage) {
+		return errors.Errorf("models-package %q is not a valid Go package name", s.ModelsPackage)
+	}
+	if s.HandlersPath != "" && !isLocalPath(s.HandlersPath) {
+		return errors.Errorf("handlers-path %q must be a relative path inside the output directory", s.HandlersPath)
+	}
+	if s.ModelsPath != "" && !isLocalPath(s.ModelsPath) {
+		return errors.Errorf("models-path %q must be a relative path inside the output directory", s.ModelsPath)
+	}
+	if s.Router != "" && !slices.Contains(supportedRouters, s.Router) {
+		return errors.Errorf("unsupported router %q, supported routers: %s",
+			s.Router, strings.Join(supportedRouters, ", "))
+	}
+	if s.Generate != nil && len(s.Generate) == 0 {
+		return errors.New("generate must list at least one generator")
+	}
+	for _, generator := range s.Generate {
+		if !slices.Contains(supportedGenerators, generator) {
+			return errors.Errorf("unsupported generator %q, supported generators: %s",
+				generator, strings.Join(supportedGenerators, ", "))
+		}
+	}
+
+	return nil
+}
+
+func isPackageName(name string) bool {
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r == '_':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return name != ""
+}
+
+func isLocalPath(value string) bool {
+	return filepath.IsLocal(value) && filepath.Clean(value) != "."
+}
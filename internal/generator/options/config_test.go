@@ -0,0 +1,125 @@
+package options_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jolfzverb/codegen/internal/generator/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	fileName := filepath.Join(t.TempDir(), "codegen.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte(content), 0o600))
+
+	return fileName
+}
+
+func TestParseOptionsConfig(t *testing.T) {
+	config := writeConfig(t, `output: internal
+package-prefix: github.com/org/service/internal
+specs:
+  - file: api/a_pi.yaml
+    package: api
+    pointers: true
+    router: chi
+    generate: [server]
+  - file: api/def.yml
+    output: pkg
+`)
+	dir := filepath.Dir(config)
+
+	opts, err := options.ParseOptions([]string{"-config", config})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "api/a_pi.yaml"), filepath.Join(dir, "api/def.yml")}, opts.YAMLFiles)
+	assert.Equal(t, filepath.Join(dir, "internal"), opts.DirPrefix)
+	assert.Equal(t, []string{options.GeneratorServer}, opts.Generators)
+
+	api := opts.ForFile(filepath.Join(dir, "api/a_pi.yaml"))
+	assert.Equal(t, "api", api.PackageName)
+	assert.Equal(t, options.RouterChi, api.Router)
+	assert.True(t, api.RequiredFieldsArePointers)
+	assert.Equal(t, "github.com/org/service/internal", api.PackagePrefix)
+
+	def := opts.ForFile(filepath.Join(dir, "api/def.yml"))
+	assert.Empty(t, def.PackageName)
+	assert.False(t, def.RequiredFieldsArePointers)
+	assert.Equal(t, filepath.Join(dir, "pkg"), def.DirPrefix)
+
+	opts, err = options.ParseOptions([]string{"-config", config, "-d", "out", "-pointers=false", "other.yaml"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"other.yaml"}, opts.YAMLFiles)
+	api = opts.ForFile(filepath.Join(dir, "api/a_pi.yaml"))
+	assert.Equal(t, "out", api.DirPrefix)
+	assert.False(t, api.RequiredFieldsArePointers)
+}
+
+func TestParseOptionsInvalidConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		config   string
+		expected string
+	}{
+		{
+			name:     "unknown field",
+			config:   "specs:\n  - file: a.yaml\n    pointer: true\n",
+			expected: `unknown field "pointer"`,
+		},
+		{
+			name:     "missing file",
+			config:   "specs:\n  - output: internal\n",
+			expected: "specs[0]: file is required",
+		},
+		{
+			name:     "duplicate file",
+			config:   "specs:\n  - file: a.yaml\n  - file: ./a.yaml\n",
+			expected: "specs[1]: file ./a.yaml is listed more than once",
+		},
+		{
+			name:     "router",
+			config:   "specs:\n  - file: a.yaml\n    router: gin\n",
+			expected: `specs[0] (a.yaml): unsupported router "gin", supported routers: chi`,
+		},
+		{
+			name:     "generator",
+			config:   "generate: [server, client]\nspecs:\n  - file: a.yaml\n",
+			expected: `unsupported generator "client", supported generators: server`,
+		},
+		{
+			name:     "no generators",
+			config:   "specs:\n  - file: a.yaml\n    generate: []\n",
+			expected: "specs[0] (a.yaml): generate must list at least one generator",
+		},
+		{
+			name:     "package name",
+			config:   "specs:\n  - file: a.yaml\n    package: my-api\n",
+			expected: `package "my-api" is not a valid Go package name`,
+		},
+		{
+			name:     "models path outside of output",
+			config:   "specs:\n  - file: a.yaml\n    models-path: ../models\n",
+			expected: `models-path "../models" must be a relative path inside the output directory`,
+		},
+		{
+			name:     "package for all specs",
+			config:   "package: api\nspecs:\n  - file: a.yaml\n",
+			expected: "package, models-package, handlers-path and models-path may only be set for a single spec",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := options.ParseOptions([]string{"-config", writeConfig(t, tc.config)})
+			assert.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
+
+func TestParseOptionsInvalidFlags(t *testing.T) {
+	_, err := options.ParseOptions([]string{"-router", "gin", "a.yaml"})
+	assert.ErrorContains(t, err, `unsupported router "gin", supported routers: chi`)
+
+	_, err = options.ParseOptions([]string{"-generate", "server,mocks", "a.yaml"})
+	assert.ErrorContains(t, err, `unsupported generator "mocks", supported generators: server`)
+}
@@ -2,10 +2,15 @@ package options
 
 import (
 	"flag"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/go-faster/errors"
 )
 
+const DefaultMaxValidationDepth = 64
+
 type Options struct {
 	PackagePrefix             string
 	DirPrefix                 string
@@ -13,19 +18,114 @@ type Options struct {
 	RequiredFieldsArePointers bool
 	AllowDeleteWithBody       bool
 	AllowRemoteAddrParam      bool
+	DefaultFieldsAreValues    bool
+	NullableType              bool
+	Initialisms               []string
+	MaxValidationDepth        int
+	// Check compares the generated code with the files on disk instead of writing it.
+	Check bool
+	// Force generates all the spec files, even the ones which are unchanged
+	// since the last run.
+	Force bool
+	// PackageName replaces the package name derived from the spec file name.
+	PackageName       string
+	ModelsPackageName string
+	// HandlersPath and ModelsPath are the directories of the generated code
+	// relative to DirPrefix and to PackagePrefix.
+	HandlersPath string
+	ModelsPath   string
+	Router       string
+	Generators   []string
+
+	// Specs are the settings of single spec files from the config file.
+	Specs map[string]Settings
+	// flags are the command line flags, which take precedence over the config file.
+	flags map[string]bool
 }
 
 func GetOptions() (*Options, error) {
+	return ParseOptions(os.Args[1:])
+}
+
+// ParseOptions parses the command line arguments. The settings of the config
+// file given with -config, or of codegen.yaml in the working directory, apply to
+// the flags which are not set.
+func ParseOptions(args []string) (*Options, error) {
+	return ParseCommandOptions(args, nil)
+}
+
+// ParseCommandOptions parses the command line arguments like ParseOptions,
+// register adds the flags of a command.
+func ParseCommandOptions(args []string, register func(flags *flag.FlagSet)) (*Options, error) {
+	const op = "options.ParseOptions"
 	opts := Options{}
+	flags := flag.NewFlagSet("codegen", flag.ContinueOnError)
+	if register != nil {
+		register(flags)
+	}
 
-	flag.StringVar(&opts.DirPrefix, "d", "internal", "Directory prefix for generated files")
-	flag.StringVar(&opts.PackagePrefix, "p", "internal", "Package prefix for imports")
-	flag.BoolVar(&opts.RequiredFieldsArePointers, "pointers", false, "Generate required fields as pointers")
-	flag.BoolVar(&opts.AllowDeleteWithBody, "allow-delete-with-body", false, "Allow DELETE operations with a body")
-	flag.BoolVar(&opts.AllowRemoteAddrParam, "allow-remote-addr-param", false, "Allow RemoteAddr fake parameter")
+	flags.StringVar(&opts.DirPrefix, "d", "internal", "Directory prefix for generated files")
+	flags.StringVar(&opts.PackagePrefix, "p", "internal", "Package prefix for imports")
+	flags.BoolVar(&opts.RequiredFieldsArePointers, "pointers", false, "Generate required fields as pointers")
+	flags.BoolVar(&opts.AllowDeleteWithBody, "allow-delete-with-body", false, "Allow DELETE operations with a body")
+	flags.BoolVar(&opts.AllowRemoteAddrParam, "allow-remote-addr-param", false, "Allow RemoteAddr fake parameter")
+	flags.BoolVar(&opts.DefaultFieldsAreValues, "default-values", false,
+		"Generate optional fields with default values as non-pointers")
+	flags.BoolVar(&opts.NullableType, "nullable-type", false,
+		"Generate nullable fields as Nullable[T] telling absent and null values apart")
+	flags.IntVar(&opts.MaxValidationDepth, "max-depth", DefaultMaxValidationDepth,
+		"Maximum nesting depth of request bodies checked by the JSON validators")
+	flags.BoolVar(&opts.Check, "check", false,
+		"Check that the generated files are up to date without writing them")
+	flags.BoolVar(&opts.Force, "force", false, "Generate all the spec files, even the unchanged ones")
+	flags.StringVar(&opts.Router, "router", RouterChi, "Router of the generated handlers")
 
-	flag.Parse()
-	opts.YAMLFiles = flag.Args()
+	var initialisms string
+	flags.StringVar(&initialisms, "initialisms", "",
+		"Comma separated initialisms kept upper case in Go names in addition to the common ones")
+	generators := GeneratorServer
+	flags.StringVar(&generators, "generate", generators, "Comma separated generators to run")
+	var configFile string
+	flags.StringVar(&configFile, "config", "", "Config file, "+DefaultConfigFile+" is used if it exists")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	opts.YAMLFiles = flags.Args()
+	if initialisms != "" {
+		opts.Initialisms = strings.Split(initialisms, ",")
+	}
+	opts.Generators = strings.Split(generators, ",")
+	opts.flags = make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		opts.flags[f.Name] = true
+	})
+	err = (&Settings{Router: opts.Router, Generate: opts.Generators}).Validate()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if configFile == "" {
+		if _, err := os.Stat(DefaultConfigFile); err == nil {
+			configFile = DefaultConfigFile
+		}
+	}
+	if configFile != "" {
+		config, err := ReadConfig(configFile)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		opts.applySettings(config.Settings)
+		opts.Specs = make(map[string]Settings, len(config.Specs))
+		for _, spec := range config.Specs {
+			opts.Specs[filepath.Clean(spec.File)] = spec.Settings
+			if len(flags.Args()) == 0 {
+				// the files given as arguments are generated instead of all the specs
+				opts.YAMLFiles = append(opts.YAMLFiles, spec.File)
+			}
+		}
+	}
 
 	if len(opts.YAMLFiles) == 0 {
 		return nil, errors.New("at least one file must be provided")
@@ -33,3 +133,44 @@ func GetOptions() (*Options, error) {
 
 	return &opts, nil
 }
+
+// ForFile returns the options of a spec file with the settings of the file from
+// the config file applied.
+func (o *Options) ForFile(fileName string) *Options {
+	result := *o
+	if settings, ok := o.Specs[filepath.Clean(fileName)]; ok {
+		result.applySettings(settings)
+	}
+
+	return &result
+}
+
+func (o *Options) applySettings(settings Settings) {
+	if settings.Output != "" && !o.flags["d"] {
+		o.DirPrefix = settings.Output
+	}
+	if settings.PackagePrefix != "" && !o.flags["p"] {
+		o.PackagePrefix = settings.PackagePrefix
+	}
+	if settings.Package != "" {
+		o.PackageName = settings.Package
+	}
+	if settings.ModelsPackage != "" {
+		o.ModelsPackageName = settings.ModelsPackage
+	}
+	if settings.HandlersPath != "" {
+		o.HandlersPath = settings.HandlersPath
+	}
+	if settings.ModelsPath != "" {
+		o.ModelsPath = settings.ModelsPath
+	}
+	if settings.Pointers != nil && !o.flags["pointers"] {
+		o.RequiredFieldsArePointers = *settings.Pointers
+	}
+	if settings.Router != "" && !o.flags["router"] {
+		o.Router = settings.Router
+	}
+	if settings.Generate != nil && !o.flags["generate"] {
+		o.Generators = settings.Generate
+	}
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"bytes"
+	"go/format"
+
+	"github.com/go-faster/errors"
+	"github.com/jolfzverb/codegen/plugin"
+)
+
+// PluginSpec describes the current spec file and its generated code to the
+// plugins.
+func (g *Generator) PluginSpec() *plugin.Spec {
+	spec := &plugin.Spec{
+		File:           g.CurrentYAMLFile,
+		Document:       g.yaml,
+		Package:        g.layout.Package,
+		ModelsPackage:  g.layout.ModelsPackage,
+		HandlersDir:    g.layout.HandlersDir,
+		ModelsDir:      g.layout.ModelsDir,
+		HandlersImport: g.layout.HandlersImport,
+		ModelsImport:   g.layout.ModelsImport,
+		Models:         make(map[string]string),
+		Operations:     g.HandlersFile.operations,
+	}
+	if g.yaml.Components != nil {
+		for name, schema := range g.yaml.Components.Schemas {
+			spec.Models[name] = GetSchemaGoName(name, schema)
+		}
+	}
+
+	return spec
+}
+
+// RenderPluginFiles adds the files generated by the plugins for the current
+// spec file to files.
+func (g *Generator) RenderPluginFiles(files map[string][]byte) error {
+	const op = "generator.RenderPluginFiles"
+	if len(g.Plugins) == 0 {
+		return nil
+	}
+
+	spec := g.PluginSpec()
+	for _, p := range g.Plugins {
+		pluginFiles, err := p.Generate(spec)
+		if err != nil {
+			return errors.Wrapf(err, "%s: plugin %s", op, p.Name())
+		}
+		for _, file := range pluginFiles {
+			if _, ok := files[file.Path]; ok {
+				return errors.Errorf("%s: plugin %s: file %s is generated more than once", op, p.Name(), file.Path)
+			}
+			output := &bytes.Buffer{}
+			output.WriteString(generatedHeader)
+			err = format.Node(output, PositionDocComments(file.File), file.File)
+			if err != nil {
+				return errors.Wrapf(err, "%s: plugin %s: file %s", op, p.Name(), file.Path)
+			}
+			files[file.Path] = output.Bytes()
+		}
+	}
+
+	return nil
+}
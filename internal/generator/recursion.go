@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/jolfzverb/codegen/internal/generator/options"
+)
+
+const maxValidationDepthName = "maxValidationDepth"
+
+// isValueField reports whether an object property is generated as a value
+// rather than as a pointer.
+func (g *Generator) isValueField(required bool, schema *openapi3.SchemaRef) bool {
+	if g.isNullableField(schema) {
+		// Nullable[T] holds the value itself
+		return true
+	}
+	if g.SchemasFile.requiredFieldsArePointers {
+		return false
+	}
+
+	return required || g.hasValueDefault(schema) || skipOptionalPointer(schema)
+}
+
+// embedsSchema reports whether the Go type of the schema contains the owner
+// schema by value through its properties. Slices and maps are indirections and
+// break such chains.
+func (g *Generator) embedsSchema(schema *openapi3.SchemaRef, owner *openapi3.Schema) bool {
+	visited := make(map[*openapi3.Schema]bool)
+	var embeds func(schema *openapi3.SchemaRef) bool
+	embeds = func(schema *openapi3.SchemaRef) bool {
+		if schema == nil || schema.Value == nil || GetGoTypeOverride(schema) != "" {
+			return false
+		}
+		value := g.canonicalSchema(schema)
+		if value == owner {
+			return true
+		}
+		if visited[value] {
+			return false
+		}
+		visited[value] = true
+
+		required := make(map[string]bool, len(value.Required))
+		for _, name := range value.Required {
+			required[name] = true
+		}
+		for name, property := range value.Properties {
+			if g.isValueField(required[name], property) && embeds(property) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return embeds(schema)
+}
+
+// canonicalSchema returns the component a local reference points to, as
+// flattening allOf may replace the referenced value with a copy.
+func (g *Generator) canonicalSchema(schema *openapi3.SchemaRef) *openapi3.Schema {
+	name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/")
+	if !ok || g.yaml == nil || g.yaml.Components == nil {
+		return schema.Value
+	}
+	if component := g.yaml.Components.Schemas[name]; component != nil && component.Value != nil {
+		return component.Value
+	}
+
+	return schema.Value
+}
+
+// isRecursiveRef reports whether a referenced property would embed the object
+// owning it by value, which Go does not allow, so the field has to be a pointer.
+func (g *Generator) isRecursiveRef(owner *openapi3.Schema, required bool, schema *openapi3.SchemaRef) bool {
+	return schema.Ref != "" && g.isValueField(required, schema) && g.embedsSchema(schema, owner)
+}
+
+func nextDepth() ast.Expr {
+	return &ast.BinaryExpr{X: I("depth"), Op: token.ADD, Y: &ast.BasicLit{Kind: token.INT, Value: "1"}}
+}
+
+// CheckValidationDepthStmt returns the guard stopping the JSON validation of
+// deeply nested recursive values.
+func (g *Generator) CheckValidationDepthStmt() ast.Stmt {
+	g.AddMaxValidationDepthIfNeeded()
+	g.AddHandlersImport("github.com/go-faster/errors")
+
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{X: I("depth"), Op: token.GTR, Y: I(maxValidationDepthName)},
+		Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(&ast.CallExpr{
+			Fun:  Sel(I("errors"), "New"),
+			Args: []ast.Expr{Str("maximum nesting depth exceeded")},
+		})}},
+	}
+}
+
+func (g *Generator) AddMaxValidationDepthIfNeeded() {
+	if g.HandlersFile.hasMaxValidationDepth {
+		return
+	}
+	g.HandlersFile.hasMaxValidationDepth = true
+
+	maxDepth := g.Opts.MaxValidationDepth
+	if maxDepth <= 0 {
+		maxDepth = options.DefaultMaxValidationDepth
+	}
+	g.HandlersFile.varDecls = append(g.HandlersFile.varDecls, &ast.GenDecl{
+		Tok: token.CONST,
+		Specs: []ast.Spec{&ast.ValueSpec{
+			Names:  []*ast.Ident{I(maxValidationDepthName)},
+			Values: []ast.Expr{&ast.BasicLit{Kind: token.INT, Value: strconv.Itoa(maxDepth)}},
+		}},
+	})
+}
+
+// AddValidateFuncs adds the JSON validation of a model: the exported function
+// starts the validation and the unexported one recurses with the depth and
+// tells request bodies, where read-only fields are rejected, apart.
+func (g *Generator) AddValidateFuncs(modelName string, jsonName string, depthName string, requestName string,
+	body []ast.Stmt,
+) {
+	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls,
+		Func("Validate"+modelName+"JSON",
+			nil,
+			[]*ast.Field{
+				Field("jsonData", Sel(I("json"), "RawMessage"), ""),
+			},
+			[]*ast.Field{
+				Field("", I("error"), ""),
+			},
+			[]ast.Stmt{
+				Ret1(&ast.CallExpr{
+					Fun:  I("validate" + modelName + "JSON"),
+					Args: []ast.Expr{I("jsonData"), &ast.BasicLit{Kind: token.INT, Value: "0"}, I("false")},
+				}),
+			},
+		),
+		Func("validate"+modelName+"JSON",
+			nil,
+			[]*ast.Field{
+				Field(jsonName, Sel(I("json"), "RawMessage"), ""),
+				Field(depthName, I("int"), ""),
+				Field(requestName, I("bool"), ""),
+			},
+			[]*ast.Field{
+				Field("", I("error"), ""),
+			},
+			body,
+		),
+	)
+}
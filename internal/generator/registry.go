@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+)
+
+// registry is shared by the generation contexts of a run. It is only changed
+// while the spec files are loaded, which happens one file after another, and
+// read while they are generated concurrently.
+type registry struct {
+	// loaders cache the spec files and the files they reference, keyed by
+	// whether the documents are converted from OpenAPI 3.1.
+	loaders map[bool]*openapi3.Loader
+	// layoutOwners are the spec files generated into each directory.
+	layoutOwners map[string]string
+}
+
+func newRegistry() *registry {
+	return &registry{
+		loaders:      make(map[bool]*openapi3.Loader),
+		layoutOwners: make(map[string]string),
+	}
+}
+
+func (r *registry) loader(openAPI31 bool, read openapi3.ReadFromURIFunc) *openapi3.Loader {
+	loader, ok := r.loaders[openAPI31]
+	if ok {
+		return loader
+	}
+	if openAPI31 {
+		read = ReadOpenAPI31FromURI(read)
+	}
+	loader = &openapi3.Loader{Context: context.Background(), IsExternalRefsAllowed: true, ReadFromURIFunc: read}
+	r.loaders[openAPI31] = loader
+
+	return loader
+}
+
+// claimLayout makes sure that every directory only holds the code of a single
+// spec file.
+func (r *registry) claimLayout(yamlFilePath string, layout Layout) error {
+	if layout.HandlersDir == layout.ModelsDir {
+		return errors.Errorf("handlers and models of %s are generated into the same directory %s",
+			yamlFilePath, layout.HandlersDir)
+	}
+	for _, dir := range []string{layout.HandlersDir, layout.ModelsDir} {
+		if owner, ok := r.layoutOwners[dir]; ok && owner != yamlFilePath {
+			return errors.Errorf("%s and %s are generated into the same directory %s", owner, yamlFilePath, dir)
+		}
+		r.layoutOwners[dir] = yamlFilePath
+	}
+
+	return nil
+}
+
+// parallel calls fn for 0..n-1 on at most GOMAXPROCS goroutines and waits for
+// them, no new calls are started once ctx is done.
+func parallel(ctx context.Context, n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, runtime.GOMAXPROCS(0))
+	for i := range n {
+		if ctx.Err() != nil {
+			break
+		}
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			fn(i)
+		}()
+	}
+	wg.Wait()
+}
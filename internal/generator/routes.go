@@ -0,0 +1,26 @@
+package generator
+
+// Route is an operation of a generated spec file.
+type Route struct {
+	File        string
+	Method      string
+	Path        string
+	OperationID string
+	// Handler is the name of the generated handler interface.
+	Handler string
+}
+
+func (g *Generator) routes() []Route {
+	result := make([]Route, 0, len(g.HandlersFile.operations))
+	for _, operation := range g.HandlersFile.operations {
+		result = append(result, Route{
+			File:        g.CurrentYAMLFile,
+			Method:      operation.Method,
+			Path:        operation.Path,
+			OperationID: operation.Operation.OperationID,
+			Handler:     operation.BaseName + "Handler",
+		})
+	}
+
+	return result
+}
@@ -5,6 +5,7 @@ import (
 	"go/format"
 	"go/token"
 	"io"
+	"log/slog"
 	"sort"
 	"strings"
 
@@ -15,8 +16,16 @@ import (
 type SchemasFile struct {
 	requiredFieldsArePointers bool
 	packageImports            []string
-	decls                     []*ast.GenDecl
-	generatedModels           map[string]bool
+	decls                     []ast.Decl
+	// generatedModels are the schemas of the generated types, keyed by type name,
+	// the schema is nil for the models of the operations.
+	generatedModels      map[string]*openapi3.Schema
+	hasNullableType      bool
+	nullableTypeArgs     []string
+	hasDateType          bool
+	hasURLType           bool
+	usesCustomValidators bool
+	hasExternalRefs      bool
 }
 
 type SchemaStruct struct {
@@ -30,12 +39,13 @@ type SchemaField struct {
 	TagJSON     []string
 	TagValidate []string
 	Required    bool
+	Doc         []string
 }
 
 func (g *Generator) NewSchemasFile() {
 	g.SchemasFile = &SchemasFile{
 		requiredFieldsArePointers: g.Opts.RequiredFieldsArePointers,
-		generatedModels:           make(map[string]bool),
+		generatedModels:           make(map[string]*openapi3.Schema),
 	}
 }
 
@@ -77,7 +87,7 @@ func (g *Generator) GenerateImportsSpecsSchemas(imp []string) ([]*ast.ImportSpec
 func (g *Generator) WriteSchemasToOutput(output io.Writer) error {
 	const op = "generator.SchemasFile.WriteToOutput"
 	// go/ast package is great!
-	_, err := output.Write([]byte("// Code generated by github.com/jolfzverb/codegen; DO NOT EDIT.\n\n"))
+	_, err := output.Write([]byte(generatedHeader))
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
@@ -85,7 +95,7 @@ func (g *Generator) WriteSchemasToOutput(output io.Writer) error {
 	importSpecs, declSpecs := g.GenerateImportsSpecs(g.SchemasFile.packageImports)
 
 	file := &ast.File{
-		Name:    ast.NewIdent(g.PackageName + "models"),
+		Name:    ast.NewIdent(g.GetCurrentModelsPackage()),
 		Imports: importSpecs,
 		Decls:   []ast.Decl{},
 	}
@@ -97,11 +107,9 @@ func (g *Generator) WriteSchemasToOutput(output io.Writer) error {
 		})
 	}
 
-	for _, decl := range g.SchemasFile.decls {
-		file.Decls = append(file.Decls, decl)
-	}
+	file.Decls = append(file.Decls, g.SchemasFile.decls...)
 
-	err = format.Node(output, token.NewFileSet(), file)
+	err = format.Node(output, PositionDocComments(file), file)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
@@ -112,6 +120,9 @@ func (g *Generator) WriteSchemasToOutput(output io.Writer) error {
 func (g *Generator) AddSchema(model SchemaStruct) {
 	fieldList := make([]*ast.Field, 0, len(model.Fields))
 	for _, field := range model.Fields {
+		if usesCustomValidator(field.TagValidate) {
+			g.SchemasFile.usesCustomValidators = true
+		}
 		jsonTags := strings.Join(field.TagJSON, ",")
 		validateTags := strings.Join(field.TagValidate, ",")
 
@@ -142,6 +153,7 @@ func (g *Generator) AddSchema(model SchemaStruct) {
 			}
 		}
 		fieldList = append(fieldList, &ast.Field{
+			Doc:   Doc(field.Doc),
 			Names: []*ast.Ident{ast.NewIdent(field.Name)},
 			Type:  typeExpr,
 			Tag:   tag,
@@ -191,11 +203,24 @@ func (g *Generator) AddSliceAlias(name string, typeName string) {
 	})
 }
 
+// CheckFieldNames fails when several properties of the model have the same Go name.
+func CheckFieldNames(model SchemaStruct) error {
+	names := make(map[string]bool, len(model.Fields))
+	for _, field := range model.Fields {
+		if names[field.Name] {
+			return errors.Errorf("model %s has several fields with the Go name %s", model.Name, field.Name)
+		}
+		names[field.Name] = true
+	}
+
+	return nil
+}
+
 func (g *Generator) AddParamsModel(baseName string, paramType string, params openapi3.Parameters) error {
 	const op = "generator.AddParamsModel"
 	fields := make([]SchemaField, 0, len(params))
 	for _, param := range params {
-		name := FormatGoLikeIdentifier(param.Value.Name)
+		name := g.GetParamGoName(param)
 		if !param.Value.Schema.Value.Type.Permits(openapi3.TypeString) {
 			return errors.New("only string type parameters are supported for " + paramType + " parameters")
 		}
@@ -210,13 +235,22 @@ func (g *Generator) AddParamsModel(baseName string, paramType string, params ope
 		}
 
 		validateTags = append(validateTags, GetSchemaValidators(param.Value.Schema)...)
-		fieldType, err := g.GetFieldTypeFromSchema(name, "", param.Value.Schema)
+		var fieldType string
+		var err error
+		if param.Value.Schema.Ref == "" && GetGoTypeOverride(param.Value.Schema) == "" &&
+			isEnumSchema(param.Value.Schema) {
+			fieldType = baseName + paramType + name
+			err = g.ProcessSchema(fieldType, param.Value.Schema)
+		} else {
+			fieldType, err = g.GetFieldTypeFromSchema(name, "", param.Value.Schema)
+		}
 		if err != nil {
 			return errors.Wrap(err, op)
 		}
 		required := false
 		if !g.SchemasFile.requiredFieldsArePointers {
-			required = param.Value.Required
+			required = param.Value.Required || g.hasValueDefault(param.Value.Schema) ||
+				skipOptionalPointer(param.Value.Schema)
 		}
 		field := SchemaField{
 			Name:        name,
@@ -224,6 +258,7 @@ func (g *Generator) AddParamsModel(baseName string, paramType string, params ope
 			TagJSON:     jsonTags,
 			TagValidate: validateTags,
 			Required:    required,
+			Doc:         DocLines(param.Value.Description, param.Value.Schema, param.Value.Deprecated),
 		}
 		fields = append(fields, field)
 	}
@@ -232,6 +267,14 @@ func (g *Generator) AddParamsModel(baseName string, paramType string, params ope
 		Name:   baseName + paramType,
 		Fields: fields,
 	}
+	err := CheckFieldNames(model)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	err = g.claimOperationModel(model.Name)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
 	g.AddSchema(model)
 
 	return nil
@@ -261,7 +304,8 @@ func (g *Generator) AddHeadersModel(baseName string, headers openapi3.Headers) e
 		}
 
 		validateTags = append(validateTags, GetSchemaValidators(header.Value.Schema)...)
-		fieldType, err := g.GetFieldTypeFromSchema(FormatGoLikeIdentifier(name), "", header.Value.Schema)
+		goName := g.GetGoName(name, header.Value.Extensions)
+		fieldType, err := g.GetFieldTypeFromSchema(goName, "", header.Value.Schema)
 		if err != nil {
 			return errors.Wrap(err, op)
 		}
@@ -270,11 +314,12 @@ func (g *Generator) AddHeadersModel(baseName string, headers openapi3.Headers) e
 			required = header.Value.Required
 		}
 		field := SchemaField{
-			Name:        FormatGoLikeIdentifier(name),
+			Name:        goName,
 			Type:        fieldType,
 			TagJSON:     jsonTags,
 			TagValidate: validateTags,
 			Required:    required,
+			Doc:         DocLines(header.Value.Description, header.Value.Schema, header.Value.Deprecated),
 		}
 		fields = append(fields, field)
 	}
@@ -283,6 +328,14 @@ func (g *Generator) AddHeadersModel(baseName string, headers openapi3.Headers) e
 		Name:   baseName + "Headers",
 		Fields: fields,
 	}
+	err := CheckFieldNames(model)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	err = g.claimOperationModel(model.Name)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
 	g.AddSchema(model)
 
 	return nil
@@ -309,22 +362,13 @@ func (g *Generator) AddSchemasImport(path string) {
 	g.SchemasFile.packageImports = append(g.SchemasFile.packageImports, path)
 }
 
-func (g *Generator) GetStringType(format string) string {
-	if format == "date-time" {
-		g.AddSchemasImport("time")
-		return "time.Time"
-	}
-	if format == "decimal" {
-		g.AddSchemasImport("github.com/shopspring/decimal")
-		return "decimal.Decimal"
-	}
-
-	return "string"
-}
-
 func (g *Generator) GetDerefFieldTypeFromSchema(modelName string, fieldName string,
 	fieldSchema *openapi3.SchemaRef,
 ) (string, error) {
+	if fieldName != "" && fieldSchema.Ref == "" && isEnumSchema(fieldSchema) {
+		return modelName + g.GetFieldGoName(fieldName, fieldSchema), nil
+	}
+
 	var fieldType string
 	switch {
 	case fieldSchema.Value.Type.Permits(openapi3.TypeString):
@@ -337,11 +381,11 @@ func (g *Generator) GetDerefFieldTypeFromSchema(modelName string, fieldName stri
 		fieldType = "bool"
 	case fieldSchema.Value.Type.Permits(openapi3.TypeObject):
 		if fieldSchema.Ref == "" {
-			fieldType = modelName + FormatGoLikeIdentifier(fieldName)
+			fieldType = modelName + g.GetFieldGoName(fieldName, fieldSchema)
 		}
 	case fieldSchema.Value.Type.Permits(openapi3.TypeArray):
 		if fieldSchema.Ref == "" {
-			fieldType = modelName + FormatGoLikeIdentifier(fieldName)
+			fieldType = modelName + g.GetFieldGoName(fieldName, fieldSchema)
 		}
 	default:
 		return "", errors.New("unsupported schema type of field " + fieldName)
@@ -354,12 +398,15 @@ func (g *Generator) GetFieldTypeFromSchema(modelName string, fieldName string,
 	fieldSchema *openapi3.SchemaRef,
 ) (string, error) {
 	if fieldSchema.Ref != "" {
-		typeName, importPath := g.ParseRefTypeName(fieldSchema.Ref)
+		typeName, importPath := g.ParseSchemaRefTypeName(fieldSchema)
 		if importPath != "" {
 			g.AddSchemasImport(importPath)
 		}
 		return typeName, nil
 	}
+	if goType := g.GetGoTypeOverrideForSchemas(fieldSchema); goType != "" {
+		return goType, nil
+	}
 	fieldType, err := g.GetDerefFieldTypeFromSchema(modelName, fieldName, fieldSchema)
 	if err != nil {
 		return "", errors.Wrapf(err, "GetFieldTypeFromSchema for field %s", fieldName)
@@ -376,70 +423,129 @@ func (g *Generator) ProcessObjectSchema(modelName string, schema *openapi3.Schem
 
 	requiredFields := make(map[string]bool)
 	for _, fieldName := range schema.Value.Required {
+		if isDirectionalField(schema.Value.Properties[fieldName]) {
+			// the same model is used for requests and responses
+			continue
+		}
 		requiredFields[fieldName] = true
 	}
 
+	var defaults []defaultField
 	keys := make([]string, 0, len(schema.Value.Properties))
 	for key := range schema.Value.Properties {
 		keys = append(keys, key)
 	}
 	sort.Strings(keys)
+	if len(keys) > 0 && schema.Value.AdditionalProperties.Schema != nil {
+		slog.Warn("additionalProperties of an object with properties are ignored", slog.String("model", modelName))
+	}
 	for _, fieldName := range keys {
 		fieldSchema := schema.Value.Properties[fieldName]
+		goFieldName := g.GetFieldGoName(fieldName, fieldSchema)
 		var jsonTags []string
 		var validateTags []string
+		// fields filled from defaults are always marshalled, otherwise a zero value
+		// would be replaced by the default on the other side
+		valueDefault := !requiredFields[fieldName] && !g.SchemasFile.requiredFieldsArePointers &&
+			g.hasValueDefault(fieldSchema)
 		jsonTags = append(jsonTags, fieldName)
 		if !requiredFields[fieldName] {
-			jsonTags = append(jsonTags, "omitempty")
+			switch {
+			case g.isNullableField(fieldSchema), skipOptionalPointer(fieldSchema):
+				jsonTags = append(jsonTags, "omitzero")
+			case !valueDefault:
+				jsonTags = append(jsonTags, "omitempty")
+			}
 			validateTags = append(validateTags, "omitempty")
 		}
 
-		if fieldSchema.Ref == "" {
+		if fieldSchema.Ref == "" && GetGoTypeOverride(fieldSchema) == "" {
 			switch {
-			case fieldSchema.Value.Type.Permits(openapi3.TypeObject):
-				err := g.ProcessSchema(modelName+FormatGoLikeIdentifier(fieldName), fieldSchema)
+			case isEnumSchema(fieldSchema),
+				fieldSchema.Value.Type.Permits(openapi3.TypeObject),
+				fieldSchema.Value.Type.Permits(openapi3.TypeArray):
+				err := g.ProcessSchema(modelName+goFieldName, fieldSchema)
 				if err != nil {
-					return errors.Wrap(err, op)
-				}
-			case fieldSchema.Value.Type.Permits(openapi3.TypeArray):
-				err := g.ProcessSchema(modelName+FormatGoLikeIdentifier(fieldName), fieldSchema)
-				if err != nil {
-					return errors.Wrap(err, op)
+					return withPointer(errors.Wrap(err, op), "properties", fieldName)
 				}
 			}
 		}
 
 		if fieldSchema.Ref != "" {
-			_, importPath := g.ParseRefTypeName(fieldSchema.Ref)
+			_, importPath := g.ParseSchemaRefTypeName(fieldSchema)
 			if importPath != "" {
 				g.AddSchemasImport(importPath)
 			}
 		}
 
-		validateTags = append(validateTags, GetSchemaValidators(fieldSchema)...)
+		validators := GetSchemaValidators(fieldSchema)
+		if requiredFields[fieldName] && g.isNullableField(fieldSchema) && len(validators) > 0 {
+			// required fields may still be null
+			validateTags = append(validateTags, "omitnil")
+		}
+		validateTags = append(validateTags, validators...)
 
 		fieldType, err := g.GetFieldTypeFromSchema(modelName, fieldName, fieldSchema)
 		if err != nil {
-			return errors.Wrapf(err, op)
+			return withPointer(errors.Wrap(err, op), "properties", fieldName)
 		}
 		required := false
 		if !g.SchemasFile.requiredFieldsArePointers {
-			required = requiredFields[fieldName]
+			required = requiredFields[fieldName] || valueDefault || skipOptionalPointer(fieldSchema)
+		}
+		recursive := g.isRecursiveRef(schema.Value, requiredFields[fieldName], fieldSchema)
+		if recursive {
+			required = false
+		}
+		if g.isNullableField(fieldSchema) {
+			if recursive {
+				fieldType = "*" + fieldType
+			}
+			fieldType = g.GetNullableFieldType(fieldType)
+			required = true
+		}
+		if !requiredFields[fieldName] && !g.isNullableField(fieldSchema) {
+			defaultValue := GetDefaultValueExpr(fieldSchema)
+			if defaultValue != nil {
+				defaults = append(defaults, defaultField{
+					Name:    goFieldName,
+					Type:    fieldType,
+					Value:   defaultValue,
+					IsValue: required,
+				})
+			}
 		}
 		field := SchemaField{
-			Name:        FormatGoLikeIdentifier(fieldName),
+			Name:        goFieldName,
 			Type:        fieldType,
 			TagJSON:     jsonTags,
 			TagValidate: validateTags,
 			Required:    required,
 		}
+		if fieldSchema.Ref == "" {
+			// referenced schemas are documented on their types
+			field.Doc = DocLines("", fieldSchema, false)
+		}
 		model.Fields = append(model.Fields, field)
 	}
+	err := CheckFieldNames(model)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
 	g.AddSchema(model)
+	if len(defaults) > 0 {
+		g.AddUnmarshalWithDefaults(modelName, defaults)
+	}
 
 	return nil
 }
 
+// isDirectionalField reports whether the property is read-only, so it is absent
+// in requests, or write-only, so it is absent in responses.
+func isDirectionalField(schema *openapi3.SchemaRef) bool {
+	return schema != nil && schema.Value != nil && (schema.Value.ReadOnly || schema.Value.WriteOnly)
+}
+
 func (g *Generator) ProcessTypeAlias(modelName string, schema *openapi3.SchemaRef) error {
 	const op = "generator.ProcessTypeAlias"
 	typeName, err := g.GetDerefFieldTypeFromSchema(modelName, "", schema)
@@ -456,24 +562,21 @@ func (g *Generator) ProcessArraySchema(modelName string, schema *openapi3.Schema
 	const op = "generator.ProcessArraySchema"
 	var elemType string
 
-	if schema.Value.Items.Ref == "" {
+	if schema.Value.Items.Ref == "" && GetGoTypeOverride(schema.Value.Items) == "" {
 		itemsSchema := schema.Value.Items
 		switch {
-		case itemsSchema.Value.Type.Permits(openapi3.TypeObject):
-			err := g.ProcessSchema(modelName+"Item", itemsSchema)
+		case isEnumSchema(itemsSchema),
+			itemsSchema.Value.Type.Permits(openapi3.TypeObject),
+			itemsSchema.Value.Type.Permits(openapi3.TypeArray):
+			err := g.ProcessSchema(modelName+g.GetFieldGoName("Item", itemsSchema), itemsSchema)
 			if err != nil {
-				return errors.Wrap(err, op)
-			}
-		case itemsSchema.Value.Type.Permits(openapi3.TypeArray):
-			err := g.ProcessSchema(modelName+"Item", itemsSchema)
-			if err != nil {
-				return errors.Wrap(err, op)
+				return withPointer(errors.Wrap(err, op), "items")
 			}
 		}
 	}
 
 	if schema.Value.Items.Ref != "" {
-		_, importPath := g.ParseRefTypeName(schema.Value.Items.Ref)
+		_, importPath := g.ParseSchemaRefTypeName(schema.Value.Items)
 		if importPath != "" {
 			g.AddSchemasImport(importPath)
 		}
@@ -481,9 +584,12 @@ func (g *Generator) ProcessArraySchema(modelName string, schema *openapi3.Schema
 
 	elemType, err := g.GetFieldTypeFromSchema(modelName, "Item", schema.Value.Items)
 	if err != nil {
-		return errors.Wrapf(err, op)
+		return withPointer(errors.Wrap(err, op), "items")
 	}
 
+	if isNullableElement(schema.Value.Items) {
+		elemType = "*" + elemType
+	}
 	g.AddSliceAlias(modelName, elemType)
 
 	return nil
@@ -495,12 +601,65 @@ func (g *Generator) ProcessSchema(modelName string, schema *openapi3.SchemaRef)
 		return nil
 	}
 
-	if g.SchemasFile.generatedModels[modelName] {
+	const op = "generator.ProcessSchema"
+	if owner, ok := g.SchemasFile.generatedModels[modelName]; ok {
+		if owner != schema.Value {
+			return errors.Wrap(typeNameCollision(modelName), op)
+		}
+
 		return nil
 	}
-	g.SchemasFile.generatedModels[modelName] = true
-	const op = "generator.ProcessSchema"
+	g.SchemasFile.generatedModels[modelName] = schema.Value
+	err := g.processSchemaByType(modelName, schema)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	g.SetTypeDoc(modelName, DocLines("", schema, false))
+
+	return nil
+}
+
+// claimOperationModel records the name of a model of an operation, it fails
+// when a schema or another operation already generates a type with the name.
+func (g *Generator) claimOperationModel(modelName string) error {
+	if _, ok := g.SchemasFile.generatedModels[modelName]; ok {
+		return typeNameCollision(modelName)
+	}
+	g.SchemasFile.generatedModels[modelName] = nil
+
+	return nil
+}
+
+func typeNameCollision(modelName string) error {
+	return errors.Errorf("the Go type %s is generated for several schemas or operations, rename one of them with %s",
+		modelName, goNameExtension)
+}
+
+func (g *Generator) processSchemaByType(modelName string, schema *openapi3.SchemaRef) error {
+	const op = "generator.processSchemaByType"
 	switch {
+	case GetGoTypeOverride(schema) != "":
+		g.ProcessGoTypeSchema(modelName, schema)
+
+		return nil
+	case isEnumSchema(schema):
+		err := g.ProcessEnumSchema(modelName, schema)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+
+		return nil
+	case isMapSchema(schema):
+		err := g.ProcessMapSchema(modelName, schema)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		err = g.AddMapValidate(modelName, schema)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+
+		return nil
 	case schema.Value.Type.Permits(openapi3.TypeObject):
 		err := g.ProcessObjectSchema(modelName, schema)
 		if err != nil {
@@ -517,13 +676,10 @@ func (g *Generator) ProcessSchema(modelName string, schema *openapi3.SchemaRef)
 		if err != nil {
 			return errors.Wrap(err, op)
 		}
-		if schema.Value.Items != nil {
-			itemsType := g.getMostNestedArrayItemType(schema.Value.Items)
-			if itemsType != nil && itemsType.Permits(openapi3.TypeObject) {
-				err = g.AddArrayValidate(modelName, schema)
-				if err != nil {
-					return errors.Wrap(err, op)
-				}
+		if needsJSONValidation(schema.Value.Items) {
+			err = g.AddArrayValidate(modelName, schema)
+			if err != nil {
+				return errors.Wrap(err, op)
 			}
 		}
 
@@ -561,16 +717,6 @@ func (g *Generator) ProcessSchema(modelName string, schema *openapi3.SchemaRef)
 	return errors.Errorf("unsupported schema type %s for model %s", schema.Value.Type, modelName)
 }
 
-func (g *Generator) getMostNestedArrayItemType(schema *openapi3.SchemaRef) *openapi3.Types {
-	for schema != nil && schema.Value.Type.Permits(openapi3.TypeArray) {
-		schema = schema.Value.Items
-	}
-	if schema == nil {
-		return nil
-	}
-	return schema.Value.Type
-}
-
 func (g *Generator) GenerateRequestModel(baseName string, contentType string, pathParams openapi3.Parameters,
 	queryParams openapi3.Parameters, headers openapi3.Parameters, cookieParams openapi3.Parameters,
 	body *openapi3.RequestBodyRef,
@@ -622,7 +768,7 @@ func (g *Generator) GenerateRequestModel(baseName string, contentType string, pa
 
 			if content.Schema.Ref != "" {
 				var importPath string
-				typeName, importPath = g.ParseRefTypeName(content.Schema.Ref)
+				typeName, importPath = g.ParseSchemaRefTypeName(content.Schema)
 				if importPath != "" {
 					g.AddSchemasImport(importPath)
 				}
@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-faster/errors"
+	"github.com/oasdiff/yaml"
+)
+
+// ReadSwagger2 returns the Swagger 2.0 document in data, or nil for documents of
+// other versions.
+func ReadSwagger2(data []byte) (*openapi2.T, error) {
+	const op = "generator.ReadSwagger2"
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	var header struct {
+		Swagger string `json:"swagger"`
+	}
+	err = json.Unmarshal(jsonData, &header)
+	if err != nil || header.Swagger != "2.0" {
+		// the loader reports malformed documents
+		return nil, nil
+	}
+
+	var document openapi2.T
+	err = json.Unmarshal(jsonData, &document)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return &document, nil
+}
+
+// ConvertSwagger2 converts a Swagger 2.0 document to OpenAPI 3. Body parameters
+// become request bodies, definitions become component schemas, and consumes and
+// produces become the content types of the request bodies and the responses.
+// FormData parameters would become form request bodies, which are not
+// generated, they are rejected by checkFormData before the conversion.
+func ConvertSwagger2(loader *openapi3.Loader, document *openapi2.T, location *url.URL) (*openapi3.T, error) {
+	const op = "generator.ConvertSwagger2"
+	// bodies without consumes are JSON, the generated handlers only read and write JSON
+	document.Consumes = jsonMediaTypes(document.Consumes)
+	if len(document.Consumes) == 0 {
+		document.Consumes = []string{applicationJSONCT}
+	}
+	document.Produces = jsonMediaTypes(document.Produces)
+	for _, pathItem := range document.Paths {
+		for _, operation := range pathItem.Operations() {
+			operation.Consumes = jsonMediaTypes(operation.Consumes)
+			operation.Produces = jsonMediaTypes(operation.Produces)
+		}
+	}
+
+	converted, err := openapi2conv.ToV3WithLoader(document, loader, location)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	return converted, nil
+}
+
+// jsonMediaTypes keeps only application/json in the media types which list it
+// among others.
+func jsonMediaTypes(mediaTypes []string) []string {
+	if len(mediaTypes) <= 1 || !slices.Contains(mediaTypes, applicationJSONCT) {
+		return mediaTypes
+	}
+	slog.Warn("only application/json is generated", slog.Any("mediaTypes", mediaTypes))
+
+	return []string{applicationJSONCT}
+}
+
+// checkFormData records a diagnostic for every formData parameter of the
+// Swagger 2.0 document, the generated handlers only read JSON request bodies.
+func (g *Generator) checkFormData(document *openapi2.T) {
+	check := func(params openapi2.Parameters, segments ...string) {
+		for i, param := range params {
+			if param == nil {
+				continue
+			}
+			if name, ok := strings.CutPrefix(param.Ref, "#/parameters/"); ok && document.Parameters[name] != nil {
+				param = document.Parameters[name]
+			}
+			if param.In == "formData" {
+				g.addDiagnostic(errors.Errorf("formData parameter %s is not supported, only JSON request bodies are generated",
+					param.Name), append(segments, "parameters", strconv.Itoa(i))...)
+			}
+		}
+	}
+	for _, pathName := range sortedKeys(document.Paths) {
+		pathItem := document.Paths[pathName]
+		if pathItem == nil {
+			continue
+		}
+		check(pathItem.Parameters, "paths", pathName)
+		operations := pathItem.Operations()
+		for _, method := range sortedKeys(operations) {
+			check(operations[method].Parameters, "paths", pathName, strings.ToLower(method))
+		}
+	}
+}
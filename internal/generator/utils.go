@@ -9,10 +9,47 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
+func GetNumberValidators(schema *openapi3.SchemaRef) []string {
+	var validateTags []string
+	if schema.Value.Min != nil {
+		tag := "min="
+		if schema.Value.ExclusiveMin {
+			tag = "gt="
+		}
+		validateTags = append(validateTags, tag+fmt.Sprint(*schema.Value.Min))
+	} else if schema.Value.ExclusiveMin {
+		slog.Warn("exclusiveMinimum without minimum is ignored")
+	}
+	if schema.Value.Max != nil {
+		tag := "max="
+		if schema.Value.ExclusiveMax {
+			tag = "lt="
+		}
+		validateTags = append(validateTags, tag+fmt.Sprint(*schema.Value.Max))
+	} else if schema.Value.ExclusiveMax {
+		slog.Warn("exclusiveMaximum without maximum is ignored")
+	}
+	if schema.Value.MultipleOf != nil {
+		validateTags = append(validateTags, multipleOfValidatorTag+"="+fmt.Sprint(*schema.Value.MultipleOf))
+	}
+
+	return validateTags
+}
+
 func GetSchemaValidators(schema *openapi3.SchemaRef) []string {
+	if GetGoTypeOverride(schema) != "" {
+		// the validators may not apply to a custom type
+		return nil
+	}
 	var validateTags []string
 	switch {
+	case isMapSchema(schema):
+		validateTags = append(validateTags, GetMapValidators(schema)...)
 	case schema.Value.Type.Permits(openapi3.TypeString):
+		if !stringFormatIsPlain(schema.Value.Format) {
+			// string validators do not apply to the parsed Go type
+			break
+		}
 		if schema.Value.MinLength > 0 {
 			validateTags = append(validateTags, "min="+strconv.FormatUint(schema.Value.MinLength, 10))
 		}
@@ -20,7 +57,7 @@ func GetSchemaValidators(schema *openapi3.SchemaRef) []string {
 			validateTags = append(validateTags, "max="+strconv.FormatUint(*schema.Value.MaxLength, 10))
 		}
 		if schema.Value.Pattern != "" {
-			slog.Warn("pattern validator is not supported", slog.String("pattern", schema.Value.Pattern))
+			validateTags = append(validateTags, patternValidatorTag+"="+EscapeValidatorParam(schema.Value.Pattern))
 		}
 		if len(schema.Value.Enum) > 0 {
 			enumStrValues := make([]string, 0, len(schema.Value.Enum))
@@ -40,33 +77,10 @@ func GetSchemaValidators(schema *openapi3.SchemaRef) []string {
 			joinedEnum := strings.Join(enumStrValues, " ")
 			validateTags = append(validateTags, "oneof="+joinedEnum)
 		}
-		switch schema.Value.Format {
-		case "ip":
-			validateTags = append(validateTags, "ip")
-		case "ipv4":
-			validateTags = append(validateTags, "ipv4")
-		case "ipv6":
-			validateTags = append(validateTags, "ipv6")
-		case "email":
-			validateTags = append(validateTags, "email")
-		}
+		validateTags = append(validateTags, GetStringFormatValidators(schema.Value.Format)...)
 
 	case schema.Value.Type.Permits(openapi3.TypeInteger):
-		if schema.Value.Min != nil {
-			validateTags = append(validateTags, "min="+fmt.Sprint(*schema.Value.Min))
-		}
-		if schema.Value.Max != nil {
-			validateTags = append(validateTags, "max="+fmt.Sprint(*schema.Value.Max))
-		}
-		if schema.Value.MultipleOf != nil {
-			slog.Warn("multipleOf validator is not supported")
-		}
-		if schema.Value.ExclusiveMax {
-			slog.Warn("exclusiveMax validator is not supported")
-		}
-		if schema.Value.ExclusiveMin {
-			slog.Warn("exclusiveMin validator is not supported")
-		}
+		validateTags = append(validateTags, GetNumberValidators(schema)...)
 		if len(schema.Value.Enum) > 0 {
 			enumStrValues := make([]string, 0, len(schema.Value.Enum))
 			for _, enumValue := range schema.Value.Enum {
@@ -78,21 +92,7 @@ func GetSchemaValidators(schema *openapi3.SchemaRef) []string {
 		}
 
 	case schema.Value.Type.Permits(openapi3.TypeNumber):
-		if schema.Value.Min != nil {
-			validateTags = append(validateTags, "min="+fmt.Sprint(*schema.Value.Min))
-		}
-		if schema.Value.Max != nil {
-			validateTags = append(validateTags, "max="+fmt.Sprint(*schema.Value.Max))
-		}
-		if schema.Value.MultipleOf != nil {
-			slog.Warn("multipleOf validator is not supported")
-		}
-		if schema.Value.ExclusiveMax {
-			slog.Warn("exclusiveMax validator is not supported")
-		}
-		if schema.Value.ExclusiveMin {
-			slog.Warn("exclusiveMin validator is not supported")
-		}
+		validateTags = append(validateTags, GetNumberValidators(schema)...)
 		if len(schema.Value.Enum) > 0 {
 			enumStrValues := make([]string, 0, len(schema.Value.Enum))
 			for _, enumValue := range schema.Value.Enum {
@@ -114,7 +114,7 @@ func GetSchemaValidators(schema *openapi3.SchemaRef) []string {
 			validateTags = append(validateTags, "unique")
 		}
 		validateTags = append(validateTags, "dive")
-		itemsValidators := GetSchemaValidators(schema.Value.Items)
+		itemsValidators := GetElementValidators(schema.Value.Items)
 		validateTags = append(validateTags, itemsValidators...)
 	}
 
@@ -0,0 +1,205 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+const (
+	patternValidatorTag    = "pattern"
+	multipleOfValidatorTag = "multipleOf"
+)
+
+// EscapeValidatorParam makes a value safe to be used as a validator tag param
+// inside a struct tag: validator separators are replaced by their utf8 codes and
+// the value is escaped the way reflect.StructTag unquotes it.
+func EscapeValidatorParam(param string) string {
+	param = strings.ReplaceAll(param, ",", "0x2C")
+	param = strings.ReplaceAll(param, "|", "0x7C")
+	quoted := strconv.Quote(param)
+	quoted = strings.ReplaceAll(quoted, "`", `\x60`)
+
+	return quoted[1 : len(quoted)-1]
+}
+
+func usesCustomValidator(validateTags []string) bool {
+	for _, tag := range validateTags {
+		if strings.HasPrefix(tag, patternValidatorTag+"=") || strings.HasPrefix(tag, multipleOfValidatorTag+"=") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g *Generator) AddCustomValidatorsIfNeeded() {
+	// models from other files may use custom validators as well
+	if !g.SchemasFile.usesCustomValidators && !g.SchemasFile.hasExternalRefs {
+		return
+	}
+	g.AddHandlersImport("math")
+	g.AddHandlersImport("regexp")
+	g.AddHandlersImport("strconv")
+	g.AddHandlersImport("sync")
+
+	for _, validation := range [][2]string{
+		{patternValidatorTag, "validatePattern"},
+		{multipleOfValidatorTag, "validateMultipleOf"},
+	} {
+		g.HandlersFile.validatorSetup = append(g.HandlersFile.validatorSetup, &ast.AssignStmt{
+			Lhs: []ast.Expr{I("_")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:  Sel(I("v"), "RegisterValidation"),
+				Args: []ast.Expr{Str(validation[0]), I(validation[1])},
+			}},
+		})
+	}
+
+	g.HandlersFile.varDecls = append(g.HandlersFile.varDecls, &ast.GenDecl{
+		Tok: token.VAR,
+		Specs: []ast.Spec{&ast.ValueSpec{
+			Names: []*ast.Ident{I("patternsCache")},
+			Type:  Sel(I("sync"), "Map"),
+		}},
+	})
+
+	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func("validatePattern",
+		nil,
+		FieldA(Field("fl", Sel(I("validator"), "FieldLevel"), "")),
+		FieldA(Field("", I("bool"), "")),
+		[]ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("pattern")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{Fun: Sel(I("fl"), "Param")}},
+			},
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("cached"), I("ok")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{Fun: Sel(I("patternsCache"), "Load"), Args: []ast.Expr{I("pattern")}}},
+			},
+			&ast.IfStmt{
+				Cond: &ast.UnaryExpr{Op: token.NOT, X: I("ok")},
+				Body: &ast.BlockStmt{List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{I("compiled"), I("err")},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{&ast.CallExpr{Fun: Sel(I("regexp"), "Compile"), Args: []ast.Expr{I("pattern")}}},
+					},
+					&ast.IfStmt{
+						Cond: Ne(I("err"), I("nil")),
+						Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(I("false"))}},
+					},
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{I("cached"), I("_")},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{&ast.CallExpr{
+							Fun:  Sel(I("patternsCache"), "LoadOrStore"),
+							Args: []ast.Expr{I("pattern"), I("compiled")},
+						}},
+					},
+				}},
+			},
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("re"), I("ok")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.TypeAssertExpr{X: I("cached"), Type: Star(Sel(I("regexp"), "Regexp"))}},
+			},
+			Ret1(&ast.BinaryExpr{
+				X:  I("ok"),
+				Op: token.LAND,
+				Y: &ast.CallExpr{
+					Fun: Sel(I("re"), "MatchString"),
+					Args: []ast.Expr{&ast.CallExpr{
+						Fun: Sel(&ast.CallExpr{Fun: Sel(I("fl"), "Field")}, "String"),
+					}},
+				},
+			}),
+		},
+	))
+
+	fieldCall := func(method string) ast.Expr {
+		return &ast.CallExpr{Fun: Sel(I("field"), method)}
+	}
+	g.HandlersFile.restDecls = append(g.HandlersFile.restDecls, Func("validateMultipleOf",
+		nil,
+		FieldA(Field("fl", Sel(I("validator"), "FieldLevel"), "")),
+		FieldA(Field("", I("bool"), "")),
+		[]ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("divisor"), I("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{
+					Fun:  Sel(I("strconv"), "ParseFloat"),
+					Args: []ast.Expr{&ast.CallExpr{Fun: Sel(I("fl"), "Param")}, &ast.BasicLit{Kind: token.INT, Value: "64"}},
+				}},
+			},
+			&ast.IfStmt{
+				Cond: &ast.BinaryExpr{
+					X:  Ne(I("err"), I("nil")),
+					Op: token.LOR,
+					Y:  Eq(I("divisor"), &ast.BasicLit{Kind: token.INT, Value: "0"}),
+				},
+				Body: &ast.BlockStmt{List: []ast.Stmt{Ret1(I("false"))}},
+			},
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("field")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.CallExpr{Fun: Sel(I("fl"), "Field")}},
+			},
+			&ast.DeclStmt{Decl: &ast.GenDecl{
+				Tok:   token.VAR,
+				Specs: []ast.Spec{&ast.ValueSpec{Names: []*ast.Ident{I("value")}, Type: I("float64")}},
+			}},
+			&ast.SwitchStmt{Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.CaseClause{
+					List: []ast.Expr{fieldCall("CanInt")},
+					Body: []ast.Stmt{&ast.AssignStmt{
+						Lhs: []ast.Expr{I("value")},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{&ast.CallExpr{Fun: I("float64"), Args: []ast.Expr{fieldCall("Int")}}},
+					}},
+				},
+				&ast.CaseClause{
+					List: []ast.Expr{fieldCall("CanUint")},
+					Body: []ast.Stmt{&ast.AssignStmt{
+						Lhs: []ast.Expr{I("value")},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{&ast.CallExpr{Fun: I("float64"), Args: []ast.Expr{fieldCall("Uint")}}},
+					}},
+				},
+				&ast.CaseClause{
+					List: []ast.Expr{fieldCall("CanFloat")},
+					Body: []ast.Stmt{&ast.AssignStmt{
+						Lhs: []ast.Expr{I("value")},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{fieldCall("Float")},
+					}},
+				},
+				&ast.CaseClause{
+					Body: []ast.Stmt{Ret1(I("false"))},
+				},
+			}}},
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{I("quotient")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{&ast.BinaryExpr{X: I("value"), Op: token.QUO, Y: I("divisor")}},
+			},
+			Ret1(&ast.BinaryExpr{
+				X: &ast.CallExpr{
+					Fun: Sel(I("math"), "Abs"),
+					Args: []ast.Expr{&ast.BinaryExpr{
+						X:  I("quotient"),
+						Op: token.SUB,
+						Y:  &ast.CallExpr{Fun: Sel(I("math"), "Round"), Args: []ast.Expr{I("quotient")}},
+					}},
+				},
+				Op: token.LSS,
+				Y:  &ast.BasicLit{Kind: token.FLOAT, Value: "1e-9"},
+			}),
+		},
+	))
+}
@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/jolfzverb/codegen/internal/generator/options"
+)
+
+const (
+	DefaultWatchInterval = 300 * time.Millisecond
+	DefaultWatchDebounce = 200 * time.Millisecond
+)
+
+// Watcher generates the spec files and then generates again the ones whose
+// spec file or referenced files change. The files are polled, a burst of
+// changes is generated once when the files stay unchanged for Debounce.
+type Watcher struct {
+	Opts     *options.Options
+	Interval time.Duration
+	Debounce time.Duration
+	// Report is called after every generation with the spec files generated and
+	// the error of the generation.
+	Report func(files []string, err error)
+
+	// inputs are the files read by each spec file.
+	inputs map[string][]string
+	states map[string]fileState
+}
+
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func NewWatcher(opts *options.Options) *Watcher {
+	return &Watcher{
+		Opts:     opts,
+		Interval: DefaultWatchInterval,
+		Debounce: DefaultWatchDebounce,
+		Report:   func([]string, error) {},
+		inputs:   make(map[string][]string),
+		states:   make(map[string]fileState),
+	}
+}
+
+// Watch generates the spec files and watches them until ctx is done.
+func (w *Watcher) Watch(ctx context.Context) error {
+	w.generate(ctx, w.Opts.YAMLFiles)
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	changed := make(map[string]bool)
+	var lastChange time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			for fileName, state := range w.states {
+				current := statFile(fileName)
+				if current != state {
+					w.states[fileName] = current
+					changed[fileName] = true
+					lastChange = now
+				}
+			}
+			if len(changed) == 0 || now.Sub(lastChange) < w.Debounce {
+				continue
+			}
+			w.generate(ctx, w.affected(changed))
+			clear(changed)
+		}
+	}
+}
+
+// affected returns the spec files which read one of the changed files.
+func (w *Watcher) affected(changed map[string]bool) []string {
+	var result []string
+	for yamlFilePath, inputs := range w.inputs {
+		for _, input := range inputs {
+			if changed[input] {
+				result = append(result, yamlFilePath)
+
+				break
+			}
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+func (w *Watcher) generate(ctx context.Context, yamlFiles []string) {
+	if len(yamlFiles) == 0 {
+		return
+	}
+	opts := *w.Opts
+	opts.YAMLFiles = yamlFiles
+	gen := NewGenerator(&opts)
+	err := gen.Generate(ctx)
+	w.Report(yamlFiles, err)
+
+	specs := append([]string{}, yamlFiles...)
+	for yamlFilePath := range gen.YAMLFilesProcessed {
+		specs = append(specs, yamlFilePath)
+	}
+	for _, yamlFilePath := range specs {
+		inputs, ok := gen.newFileGenerator(yamlFilePath).specInputs()
+		if !ok {
+			// the files which cannot be read yet keep the inputs of the last
+			// generation, the spec file itself is watched at least
+			inputs = map[string][]byte{yamlFilePath: nil}
+			for _, input := range w.inputs[yamlFilePath] {
+				inputs[input] = nil
+			}
+		}
+		w.inputs[yamlFilePath] = w.inputs[yamlFilePath][:0]
+		for input := range inputs {
+			w.inputs[yamlFilePath] = append(w.inputs[yamlFilePath], input)
+			if _, ok := w.states[input]; !ok {
+				w.states[input] = statFile(input)
+			}
+		}
+	}
+}
+
+func statFile(fileName string) fileState {
+	info, err := os.Stat(fileName)
+	if err != nil {
+		return fileState{}
+	}
+
+	return fileState{exists: true, size: info.Size(), modTime: info.ModTime()}
+}
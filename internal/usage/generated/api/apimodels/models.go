@@ -3,27 +3,191 @@
 package apimodels
 
 import (
+	"encoding/json"
+	"net/netip"
+	"net/url"
 	"time"
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/jolfzverb/codegen/internal/usage/generated/def/defmodels"
 )
 
+type CreatePathParamsSuffix string
+
+const (
+	CreatePathParamsSuffixE  CreatePathParamsSuffix = "e"
+	CreatePathParamsSuffixEs CreatePathParamsSuffix = "es"
+)
+
+func (v CreatePathParamsSuffix) IsValid() bool {
+	switch v {
+	case CreatePathParamsSuffixE, CreatePathParamsSuffixEs:
+		return true
+	}
+	return false
+}
+func AllCreatePathParamsSuffixValues() []CreatePathParamsSuffix {
+	return []CreatePathParamsSuffix{CreatePathParamsSuffixE, CreatePathParamsSuffixEs}
+}
+
 type CreatePathParams struct {
-	Suffix string `json:"suffix" validate:"required,oneof=e es"`
-	Param  string `json:"param" validate:"required"`
+	Suffix CreatePathParamsSuffix `json:"suffix" validate:"required,oneof=e es"`
+	Param  string                 `json:"param" validate:"required"`
 }
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+func ParseDate(value string) (Date, error) {
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{Year: parsed.Year(), Month: parsed.Month(), Day: parsed.Day()}, nil
+}
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+func (d Date) String() string {
+	return d.Time().Format(time.DateOnly)
+}
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+func (d *Date) UnmarshalText(data []byte) error {
+	parsed, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
 type CreateQueryParams struct {
 	Count string `json:"count" validate:"required"`
+	// Constraints: format uuid.
+	RequestID *uuid.UUID `json:"request-id,omitempty" validate:"omitempty"`
+	// Constraints: format date.
+	Day *Date `json:"day,omitempty" validate:"omitempty"`
 }
 type CreateHeaders struct {
-	IdempotencyKey string     `json:"Idempotency-Key" validate:"required,min=1,max=100"`
+	// Constraints: min length 1, max length 100.
+	IdempotencyKey string `json:"Idempotency-Key" validate:"required,min=1,max=100"`
+	// Constraints: format date-time.
 	OptionalHeader *time.Time `json:"Optional-Header,omitempty" validate:"omitempty"`
 }
 type CreateCookies struct {
-	CookieParam         *string `json:"cookie-param,omitempty" validate:"omitempty,min=10,max=15"`
-	RequiredCookieParam string  `json:"required-cookie-param" validate:"required,min=10,max=15"`
+	// Constraints: min length 10, max length 15.
+	CookieParam *string `json:"cookie-param,omitempty" validate:"omitempty,min=10,max=15"`
+	// Constraints: min length 10, max length 15.
+	RequiredCookieParam string `json:"required-cookie-param" validate:"required,min=10,max=15"`
 }
 type CreateRequestBodyArrayField []string
+type Nullable[T any] struct {
+	Value T
+	Set   bool
+	Null  bool
+}
+
+func NewNullable[T any](value T) Nullable[T] {
+	return Nullable[T]{Value: value, Set: true}
+}
+func NewNull[T any]() Nullable[T] {
+	return Nullable[T]{Set: true, Null: true}
+}
+func (n Nullable[T]) Get() (T, bool) {
+	return n.Value, n.Set && !n.Null
+}
+func (n Nullable[T]) IsZero() bool {
+	return !n.Set
+}
+func (n Nullable[T]) ValidationValue() any {
+	if !n.Set || n.Null {
+		return (*T)(nil)
+	}
+	return n.Value
+}
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.Set || n.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	n.Null = string(data) == "null"
+	if n.Null {
+		var zero T
+		n.Value = zero
+		return nil
+	}
+	return json.Unmarshal(data, &n.Value)
+}
+
+type CreateRequestBodyContact struct {
+	// Constraints: min length 3.
+	Nickname Nullable[string] `json:"nickname" validate:"omitnil,min=3"`
+}
+type CreateRequestBodyEnumInt int
+
+const (
+	CreateRequestBodyEnumInt1 CreateRequestBodyEnumInt = 1
+	CreateRequestBodyEnumInt2 CreateRequestBodyEnumInt = 2
+	CreateRequestBodyEnumInt3 CreateRequestBodyEnumInt = 3
+)
+
+func (v CreateRequestBodyEnumInt) IsValid() bool {
+	switch v {
+	case CreateRequestBodyEnumInt1, CreateRequestBodyEnumInt2, CreateRequestBodyEnumInt3:
+		return true
+	}
+	return false
+}
+func AllCreateRequestBodyEnumIntValues() []CreateRequestBodyEnumInt {
+	return []CreateRequestBodyEnumInt{CreateRequestBodyEnumInt1, CreateRequestBodyEnumInt2, CreateRequestBodyEnumInt3}
+}
+
+type CreateRequestBodyEnumNumber float64
+
+const (
+	CreateRequestBodyEnumNumber1Dot1 CreateRequestBodyEnumNumber = 1.1
+	CreateRequestBodyEnumNumber2Dot2 CreateRequestBodyEnumNumber = 2.2
+	CreateRequestBodyEnumNumber3Dot3 CreateRequestBodyEnumNumber = 3.3
+)
+
+func (v CreateRequestBodyEnumNumber) IsValid() bool {
+	switch v {
+	case CreateRequestBodyEnumNumber1Dot1, CreateRequestBodyEnumNumber2Dot2, CreateRequestBodyEnumNumber3Dot3:
+		return true
+	}
+	return false
+}
+func AllCreateRequestBodyEnumNumberValues() []CreateRequestBodyEnumNumber {
+	return []CreateRequestBodyEnumNumber{CreateRequestBodyEnumNumber1Dot1, CreateRequestBodyEnumNumber2Dot2, CreateRequestBodyEnumNumber3Dot3}
+}
+
+type CreateRequestBodyEnumVal string
+
+const (
+	CreateRequestBodyEnumValValue1 CreateRequestBodyEnumVal = "value1"
+	CreateRequestBodyEnumValValue2 CreateRequestBodyEnumVal = "value2"
+	CreateRequestBodyEnumValValue3 CreateRequestBodyEnumVal = "value3"
+)
+
+func (v CreateRequestBodyEnumVal) IsValid() bool {
+	switch v {
+	case CreateRequestBodyEnumValValue1, CreateRequestBodyEnumValValue2, CreateRequestBodyEnumValValue3:
+		return true
+	}
+	return false
+}
+func AllCreateRequestBodyEnumValValues() []CreateRequestBodyEnumVal {
+	return []CreateRequestBodyEnumVal{CreateRequestBodyEnumValValue1, CreateRequestBodyEnumValValue2, CreateRequestBodyEnumValValue3}
+}
+
 type CreateRequestBodyObjectArrayItem struct {
 	Subfield1 *string `json:"subfield1,omitempty" validate:"omitempty"`
 	Subfield2 *int    `json:"subfield2,omitempty" validate:"omitempty"`
@@ -37,21 +201,71 @@ type CreateRequestBodyObjectField struct {
 	Field1 *string                             `json:"field1,omitempty" validate:"omitempty"`
 	Field2 *CreateRequestBodyObjectFieldField2 `json:"field2,omitempty" validate:"omitempty"`
 }
+type URL struct {
+	url.URL
+}
+
+func ParseURL(value string) (URL, error) {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return URL{}, err
+	}
+	if !parsed.IsAbs() {
+		return URL{}, errors.New("uri must be absolute")
+	}
+	return URL{URL: *parsed}, nil
+}
+func (u URL) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+func (u *URL) UnmarshalText(data []byte) error {
+	parsed, err := ParseURL(string(data))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
 type CreateRequestBody struct {
-	ArrayField          *CreateRequestBodyArrayField  `json:"array-field,omitempty" validate:"omitempty,dive"`
-	CodeForResponse     *int                          `json:"code_for_response,omitempty" validate:"omitempty,min=100,max=999"`
-	Date                *time.Time                    `json:"date,omitempty" validate:"omitempty"`
-	DecimalField        *decimal.Decimal              `json:"decimal-field,omitempty" validate:"omitempty"`
-	Description         *string                       `json:"description,omitempty" validate:"omitempty,min=1,max=10"`
-	EnumInt             *int                          `json:"enum-int,omitempty" validate:"omitempty,oneof=1 2 3"`
-	EnumNumber          *float64                      `json:"enum-number,omitempty" validate:"omitempty,oneof=1.1 2.2 3.3"`
-	EnumVal             *string                       `json:"enum-val,omitempty" validate:"omitempty,oneof=value1 value2 value3"`
-	ExternalRef         *defmodels.ExternalRef        `json:"external-ref,omitempty" validate:"omitempty"`
-	ExternalRef2        *defmodels.ExternalObject     `json:"external-ref2,omitempty" validate:"omitempty"`
-	FieldToValidateDive *ComplexObjectForDive         `json:"field_to_validate_dive,omitempty" validate:"omitempty"`
-	Name                string                        `json:"name"`
-	ObjectArray         *CreateRequestBodyObjectArray `json:"object-array,omitempty" validate:"omitempty,dive"`
-	ObjectField         *CreateRequestBodyObjectField `json:"object-field,omitempty" validate:"omitempty"`
+	ArrayField *CreateRequestBodyArrayField `json:"array-field,omitempty" validate:"omitempty,dive"`
+	// Constraints: format byte.
+	ByteField *[]byte `json:"byte-field,omitempty" validate:"omitempty"`
+	// Constraints: minimum 100, maximum 999.
+	CodeForResponse *int                      `json:"code_for_response,omitempty" validate:"omitempty,min=100,max=999"`
+	Contact         *CreateRequestBodyContact `json:"contact,omitempty" validate:"omitempty"`
+	// Constraints: format date-time.
+	Date *time.Time `json:"date,omitempty" validate:"omitempty"`
+	// Constraints: format decimal.
+	DecimalField *decimal.Decimal `json:"decimal-field,omitempty" validate:"omitempty"`
+	// Constraints: min length 1, max length 10.
+	Description *string `json:"description,omitempty" validate:"omitempty,min=1,max=10"`
+	// Constraints: format duration.
+	DurationField *string                      `json:"duration-field,omitempty" validate:"omitempty,pattern=^P(\\d+W0x7C(\\d+Y)?(\\d+M)?(\\d+D)?(T(\\d+H)?(\\d+M)?(\\d+(\\.\\d+)?S)?)?)$"`
+	EnumInt       *CreateRequestBodyEnumInt    `json:"enum-int,omitempty" validate:"omitempty,oneof=1 2 3"`
+	EnumNumber    *CreateRequestBodyEnumNumber `json:"enum-number,omitempty" validate:"omitempty,oneof=1.1 2.2 3.3"`
+	EnumVal       *CreateRequestBodyEnumVal    `json:"enum-val,omitempty" validate:"omitempty,oneof=value1 value2 value3"`
+	// Constraints: exclusive minimum 0, exclusive maximum 1.
+	ExclusiveField      *float64                  `json:"exclusive-field,omitempty" validate:"omitempty,gt=0,lt=1"`
+	ExternalRef         *defmodels.ExternalRef    `json:"external-ref,omitempty" validate:"omitempty"`
+	ExternalRef2        *defmodels.ExternalObject `json:"external-ref2,omitempty" validate:"omitempty"`
+	FieldToValidateDive *ComplexObjectForDive     `json:"field_to_validate_dive,omitempty" validate:"omitempty"`
+	// Constraints: format hostname.
+	HostnameField *string    `json:"hostname-field,omitempty" validate:"omitempty,hostname_rfc1123"`
+	Inventory     *Inventory `json:"inventory,omitempty" validate:"omitempty"`
+	// Constraints: multiple of 5.
+	MultipleOfField *int                          `json:"multiple-of-field,omitempty" validate:"omitempty,multipleOf=5"`
+	Name            string                        `json:"name"`
+	ObjectArray     *CreateRequestBodyObjectArray `json:"object-array,omitempty" validate:"omitempty,dive"`
+	ObjectField     *CreateRequestBodyObjectField `json:"object-field,omitempty" validate:"omitempty"`
+	// Constraints: pattern ^[a-z]+(,[a-z]+)*$.
+	PatternField *string `json:"pattern-field,omitempty" validate:"omitempty,pattern=^[a-z]+(0x2C[a-z]+)*$"`
+	// Read-only: rejected in requests.
+	ResourceID *string     `json:"resource-id,omitempty" validate:"omitempty"`
+	ServerAddr *netip.Addr `json:"server-addr,omitempty" validate:"omitempty"`
+	Tree       *TreeNode   `json:"tree,omitempty" validate:"omitempty"`
+	// Constraints: format uri.
+	URIField *URL `json:"uri-field,omitempty" validate:"omitempty"`
 }
 type CreateRequest struct {
 	Path    CreatePathParams
@@ -61,6 +275,7 @@ type CreateRequest struct {
 	Body    CreateRequestBody
 }
 type CreateResponse200Headers struct {
+	// Idempotency key from request
 	IdempotencyKey *string `json:"Idempotency-Key,omitempty" validate:"omitempty"`
 }
 type CreateResponse200 struct {
@@ -78,43 +293,102 @@ type CreateResponse struct {
 	Response404 *CreateResponse404
 }
 type ComplexObjectForDiveArrayObjectsOptionalItem struct {
+	// Constraints: min length 5.
 	Field1 string `json:"field1" validate:"min=5"`
-	Field2 *int   `json:"field2,omitempty" validate:"omitempty,min=10"`
+	// Constraints: minimum 10.
+	Field2 *int `json:"field2,omitempty" validate:"omitempty,min=10"`
 }
+
+// Constraints: min items 1, max items 2.
 type ComplexObjectForDiveArrayObjectsOptional []ComplexObjectForDiveArrayObjectsOptionalItem
 type ComplexObjectForDiveArrayObjectsRequiredItem struct {
+	// Constraints: min length 5.
 	Field1 string `json:"field1" validate:"min=5"`
-	Field2 *int   `json:"field2,omitempty" validate:"omitempty,min=10"`
+	// Constraints: minimum 10.
+	Field2 *int `json:"field2,omitempty" validate:"omitempty,min=10"`
 }
+
+// Constraints: min items 1, max items 2.
 type ComplexObjectForDiveArrayObjectsRequired []ComplexObjectForDiveArrayObjectsRequiredItem
+
+// Constraints: min items 1, max items 2.
 type ComplexObjectForDiveArrayStringsOptional []string
+
+// Constraints: min items 1, max items 2.
 type ComplexObjectForDiveArrayStringsRequired []string
+
+// Constraints: min items 1, max items 2.
 type ComplexObjectForDiveArraysOfArraysItem []string
+
+// Constraints: min items 1, max items 2.
 type ComplexObjectForDiveArraysOfArrays []ComplexObjectForDiveArraysOfArraysItem
 type ComplexObjectForDiveObjectFieldOptional struct {
+	// Constraints: min length 5.
 	Field1 string `json:"field1" validate:"min=5"`
-	Field2 *int   `json:"field2,omitempty" validate:"omitempty,min=10"`
+	// Constraints: minimum 10.
+	Field2 *int `json:"field2,omitempty" validate:"omitempty,min=10"`
 }
 type ComplexObjectForDiveObjectFieldRequired struct {
+	// Constraints: min length 5.
 	Field1 string `json:"field1" validate:"min=5"`
-	Field2 *int   `json:"field2,omitempty" validate:"omitempty,min=10"`
+	// Constraints: minimum 10.
+	Field2 *int `json:"field2,omitempty" validate:"omitempty,min=10"`
 }
 type ComplexObjectForDive struct {
+	// Constraints: min items 1, max items 2.
 	ArrayObjectsOptional *ComplexObjectForDiveArrayObjectsOptional `json:"array_objects_optional,omitempty" validate:"omitempty,min=1,max=2,dive"`
-	ArrayObjectsRequired ComplexObjectForDiveArrayObjectsRequired  `json:"array_objects_required" validate:"min=1,max=2,dive"`
+	// Constraints: min items 1, max items 2.
+	ArrayObjectsRequired ComplexObjectForDiveArrayObjectsRequired `json:"array_objects_required" validate:"min=1,max=2,dive"`
+	// Constraints: min items 1, max items 2.
 	ArrayStringsOptional *ComplexObjectForDiveArrayStringsOptional `json:"array_strings_optional,omitempty" validate:"omitempty,min=1,max=2,dive,min=5"`
-	ArrayStringsRequired ComplexObjectForDiveArrayStringsRequired  `json:"array_strings_required" validate:"min=1,max=2,dive,min=5"`
-	ArraysOfArrays       *ComplexObjectForDiveArraysOfArrays       `json:"arrays_of_arrays,omitempty" validate:"omitempty,min=1,max=2,dive,min=1,max=2,dive,min=5"`
-	ObjectFieldOptional  *ComplexObjectForDiveObjectFieldOptional  `json:"object_field_optional,omitempty" validate:"omitempty"`
-	ObjectFieldRequired  ComplexObjectForDiveObjectFieldRequired   `json:"object_field_required"`
+	// Constraints: min items 1, max items 2.
+	ArrayStringsRequired ComplexObjectForDiveArrayStringsRequired `json:"array_strings_required" validate:"min=1,max=2,dive,min=5"`
+	// Constraints: min items 1, max items 2.
+	ArraysOfArrays      *ComplexObjectForDiveArraysOfArrays      `json:"arrays_of_arrays,omitempty" validate:"omitempty,min=1,max=2,dive,min=1,max=2,dive,min=5"`
+	ObjectFieldOptional *ComplexObjectForDiveObjectFieldOptional `json:"object_field_optional,omitempty" validate:"omitempty"`
+	ObjectFieldRequired ComplexObjectForDiveObjectFieldRequired  `json:"object_field_required"`
+}
+type InventoryLabels map[string]string
+type InventoryScores []*int
+type InventoryStockValue []Named
+type InventoryStock map[string]InventoryStockValue
+type Inventory struct {
+	Labels *InventoryLabels `json:"labels,omitempty" validate:"omitempty,max=2,dive,max=10"`
+	// Constraints: min length 1.
+	Name   string           `json:"name" validate:"min=1"`
+	Scores *InventoryScores `json:"scores,omitempty" validate:"omitempty,dive,omitnil,min=0"`
+	Stock  InventoryStock   `json:"stock" validate:"dive,dive"`
+}
+type Named struct {
+	// Constraints: min length 1.
+	Name string `json:"name" validate:"min=1"`
 }
 type NewResourseResponse struct {
-	Count        string           `json:"count"`
-	Date         *time.Time       `json:"date,omitempty" validate:"omitempty"`
-	Date2        *time.Time       `json:"date2,omitempty" validate:"omitempty"`
+	// Constraints: format byte.
+	ByteField *[]byte `json:"byte-field,omitempty" validate:"omitempty"`
+	Count     string  `json:"count"`
+	// Constraints: format date-time.
+	Date *time.Time `json:"date,omitempty" validate:"omitempty"`
+	// Constraints: format date-time.
+	Date2 *time.Time `json:"date2,omitempty" validate:"omitempty"`
+	// Constraints: format date.
+	Day *Date `json:"day,omitempty" validate:"omitempty"`
+	// Constraints: format decimal.
 	DecimalField *decimal.Decimal `json:"decimal-field,omitempty" validate:"omitempty"`
 	Description  *string          `json:"description,omitempty" validate:"omitempty"`
 	EnumVal      *string          `json:"enum-val,omitempty" validate:"omitempty"`
 	Name         string           `json:"name"`
 	Param        string           `json:"param"`
+	// Constraints: format uuid.
+	RequestID *uuid.UUID `json:"request-id,omitempty" validate:"omitempty"`
+	// Read-only: rejected in requests.
+	ResourceID *string `json:"resource-id,omitempty" validate:"omitempty"`
+	// Constraints: format uri.
+	URIField *URL `json:"uri-field,omitempty" validate:"omitempty"`
+}
+type TreeNodeChildren []TreeNode
+type TreeNode struct {
+	Children *TreeNodeChildren `json:"children,omitempty" validate:"omitempty,dive"`
+	// Constraints: min length 1.
+	Name string `json:"name" validate:"min=1"`
 }
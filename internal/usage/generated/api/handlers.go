@@ -6,26 +6,42 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"reflect"
+	"regexp"
 	"strconv"
+	"sync"
 	"time"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-faster/errors"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"github.com/jolfzverb/codegen/internal/usage/generated/api/apimodels"
 	"github.com/jolfzverb/codegen/internal/usage/generated/def"
 )
 
+// CreateHandler handles POST /path/to/{param}/resours{suffix}.
 type CreateHandler interface {
+	// Create new resource
 	HandleCreate(ctx context.Context, r apimodels.CreateRequest) (*apimodels.CreateResponse, error)
 }
+
+const maxValidationDepth = 64
+
+var patternsCache sync.Map
+
 type Handler struct {
 	validator *validator.Validate
 	create    CreateHandler
 }
 
 func NewHandler(create CreateHandler) *Handler {
-	return &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), create: create}
+	v := validator.New(validator.WithRequiredStructEnabled())
+	_ = v.RegisterValidation("pattern", validatePattern)
+	_ = v.RegisterValidation("multipleOf", validateMultipleOf)
+	v.RegisterCustomTypeFunc(nullableValue, apimodels.Nullable[string]{})
+	return &Handler{validator: v, create: create}
 }
 func (h *Handler) AddRoutes(router chi.Router) {
 	router.Post("/path/to/{param}/resours{suffix}", h.handleCreate)
@@ -36,7 +52,7 @@ func (h *Handler) parseCreatePathParams(r *http.Request) (*apimodels.CreatePathP
 	if suffix == "" {
 		return nil, errors.New("suffix path param is required")
 	}
-	pathParams.Suffix = suffix
+	pathParams.Suffix = apimodels.CreatePathParamsSuffix(suffix)
 	param := chi.URLParam(r, "param")
 	if param == "" {
 		return nil, errors.New("param path param is required")
@@ -55,6 +71,22 @@ func (h *Handler) parseCreateQueryParams(r *http.Request) (*apimodels.CreateQuer
 		return nil, errors.New("count query param is required")
 	}
 	queryParams.Count = count
+	requestID := r.URL.Query().Get("request-id")
+	if requestID != "" {
+		parsedRequestID, err := uuid.Parse(requestID)
+		if err != nil {
+			return nil, errors.Wrap(err, "RequestID is not a valid uuid format")
+		}
+		queryParams.RequestID = &parsedRequestID
+	}
+	day := r.URL.Query().Get("day")
+	if day != "" {
+		parsedDay, err := apimodels.ParseDate(day)
+		if err != nil {
+			return nil, errors.Wrap(err, "Day is not a valid date format")
+		}
+		queryParams.Day = &parsedDay
+	}
 	err := h.validator.Struct(queryParams)
 	if err != nil {
 		return nil, err
@@ -104,37 +136,80 @@ func (h *Handler) parseCreateCookies(r *http.Request) (*apimodels.CreateCookies,
 	}
 	return &cookies, nil
 }
-func ValidateCreateRequestBodyObjectArrayItemJSON(_ json.RawMessage) error {
+func containsNull(data json.RawMessage) bool {
+	var temp any
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return false
+	}
+	return temp == nil
+}
+func ValidateCreateRequestBodyContactJSON(jsonData json.RawMessage) error {
+	return validateCreateRequestBodyContactJSON(jsonData, 0, false)
+}
+func validateCreateRequestBodyContactJSON(jsonData json.RawMessage, _ int, _ bool) error {
+	requiredFields := map[string]bool{"nickname": true}
+	nullableFields := map[string]bool{"nickname": true}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	return nil
+}
+func ValidateCreateRequestBodyObjectArrayItemJSON(jsonData json.RawMessage) error {
+	return validateCreateRequestBodyObjectArrayItemJSON(jsonData, 0, false)
+}
+func validateCreateRequestBodyObjectArrayItemJSON(_ json.RawMessage, _ int, _ bool) error {
 	return nil
 }
 func ValidateCreateRequestBodyObjectArrayJSON(jsonData json.RawMessage) error {
+	return validateCreateRequestBodyObjectArrayJSON(jsonData, 0, false)
+}
+func validateCreateRequestBodyObjectArrayJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
 	var arr []json.RawMessage
 	err := json.Unmarshal(jsonData, &arr)
 	if err != nil {
 		return err
 	}
 	for index, obj := range arr {
-		if !containsNull(obj) {
-			err = ValidateCreateRequestBodyObjectArrayItemJSON(obj)
-			if err != nil {
-				return errors.Wrapf(err, "error validating object at index %d", index)
-			}
+		if containsNull(obj) {
+			return errors.Errorf("item at index %d cannot be null", index)
+		}
+		err = validateCreateRequestBodyObjectArrayItemJSON(obj, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating object at index %d", index)
 		}
 	}
 	return nil
 }
-func ValidateCreateRequestBodyObjectFieldField2JSON(_ json.RawMessage) error {
-	return nil
+func ValidateCreateRequestBodyObjectFieldField2JSON(jsonData json.RawMessage) error {
+	return validateCreateRequestBodyObjectFieldField2JSON(jsonData, 0, false)
 }
-func containsNull(data json.RawMessage) bool {
-	var temp any
-	err := json.Unmarshal(data, &temp)
-	if err != nil {
-		return false
-	}
-	return temp == nil
+func validateCreateRequestBodyObjectFieldField2JSON(_ json.RawMessage, _ int, _ bool) error {
+	return nil
 }
 func ValidateCreateRequestBodyObjectFieldJSON(jsonData json.RawMessage) error {
+	return validateCreateRequestBodyObjectFieldJSON(jsonData, 0, false)
+}
+func validateCreateRequestBodyObjectFieldJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
 	var obj map[string]json.RawMessage
 	err := json.Unmarshal(jsonData, &obj)
 	if err != nil {
@@ -144,7 +219,7 @@ func ValidateCreateRequestBodyObjectFieldJSON(jsonData json.RawMessage) error {
 	var exists bool
 	val, exists = obj["field2"]
 	if exists && !containsNull(val) {
-		err = ValidateCreateRequestBodyObjectFieldField2JSON(val)
+		err = validateCreateRequestBodyObjectFieldField2JSON(val, depth+1, request)
 		if err != nil {
 			return errors.Wrap(err, "field field2 is not valid")
 		}
@@ -152,6 +227,12 @@ func ValidateCreateRequestBodyObjectFieldJSON(jsonData json.RawMessage) error {
 	return nil
 }
 func ValidateCreateRequestBodyJSON(jsonData json.RawMessage) error {
+	return validateCreateRequestBodyJSON(jsonData, 0, false)
+}
+func validateCreateRequestBodyJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
 	requiredFields := map[string]bool{"name": true}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
@@ -170,6 +251,20 @@ func ValidateCreateRequestBodyJSON(jsonData json.RawMessage) error {
 			return errors.New("field " + field + " cannot be null")
 		}
 	}
+	if request {
+		for _, field := range []string{"resource-id"} {
+			if _, exists = obj[field]; exists {
+				return errors.New("field " + field + " is read only")
+			}
+		}
+	}
+	val, exists = obj["contact"]
+	if exists && !containsNull(val) {
+		err = validateCreateRequestBodyContactJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field contact is not valid")
+		}
+	}
 	val, exists = obj["external-ref2"]
 	if exists && !containsNull(val) {
 		err = def.ValidateExternalObjectJSON(val)
@@ -179,25 +274,39 @@ func ValidateCreateRequestBodyJSON(jsonData json.RawMessage) error {
 	}
 	val, exists = obj["field_to_validate_dive"]
 	if exists && !containsNull(val) {
-		err = ValidateComplexObjectForDiveJSON(val)
+		err = validateComplexObjectForDiveJSON(val, depth+1, request)
 		if err != nil {
 			return errors.Wrap(err, "field field_to_validate_dive is not valid")
 		}
 	}
+	val, exists = obj["inventory"]
+	if exists && !containsNull(val) {
+		err = validateInventoryJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field inventory is not valid")
+		}
+	}
 	val, exists = obj["object-array"]
 	if exists && !containsNull(val) {
-		err = ValidateCreateRequestBodyObjectArrayJSON(val)
+		err = validateCreateRequestBodyObjectArrayJSON(val, depth+1, request)
 		if err != nil {
 			return errors.Wrap(err, "field object-array is not valid")
 		}
 	}
 	val, exists = obj["object-field"]
 	if exists && !containsNull(val) {
-		err = ValidateCreateRequestBodyObjectFieldJSON(val)
+		err = validateCreateRequestBodyObjectFieldJSON(val, depth+1, request)
 		if err != nil {
 			return errors.Wrap(err, "field object-field is not valid")
 		}
 	}
+	val, exists = obj["tree"]
+	if exists && !containsNull(val) {
+		err = validateTreeNodeJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field tree is not valid")
+		}
+	}
 	return nil
 }
 func (h *Handler) parseCreateRequestBody(r *http.Request) (*apimodels.CreateRequestBody, error) {
@@ -206,7 +315,7 @@ func (h *Handler) parseCreateRequestBody(r *http.Request) (*apimodels.CreateRequ
 	if err != nil {
 		return nil, err
 	}
-	err = ValidateCreateRequestBodyJSON(bodyJSON)
+	err = validateCreateRequestBodyJSON(bodyJSON, 0, true)
 	if err != nil {
 		return nil, err
 	}
@@ -341,6 +450,9 @@ func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
 	}
 }
 func ValidateComplexObjectForDiveArrayObjectsOptionalItemJSON(jsonData json.RawMessage) error {
+	return validateComplexObjectForDiveArrayObjectsOptionalItemJSON(jsonData, 0, false)
+}
+func validateComplexObjectForDiveArrayObjectsOptionalItemJSON(jsonData json.RawMessage, _ int, _ bool) error {
 	requiredFields := map[string]bool{"field1": true}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
@@ -362,22 +474,32 @@ func ValidateComplexObjectForDiveArrayObjectsOptionalItemJSON(jsonData json.RawM
 	return nil
 }
 func ValidateComplexObjectForDiveArrayObjectsOptionalJSON(jsonData json.RawMessage) error {
+	return validateComplexObjectForDiveArrayObjectsOptionalJSON(jsonData, 0, false)
+}
+func validateComplexObjectForDiveArrayObjectsOptionalJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
 	var arr []json.RawMessage
 	err := json.Unmarshal(jsonData, &arr)
 	if err != nil {
 		return err
 	}
 	for index, obj := range arr {
-		if !containsNull(obj) {
-			err = ValidateComplexObjectForDiveArrayObjectsOptionalItemJSON(obj)
-			if err != nil {
-				return errors.Wrapf(err, "error validating object at index %d", index)
-			}
+		if containsNull(obj) {
+			return errors.Errorf("item at index %d cannot be null", index)
+		}
+		err = validateComplexObjectForDiveArrayObjectsOptionalItemJSON(obj, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating object at index %d", index)
 		}
 	}
 	return nil
 }
 func ValidateComplexObjectForDiveArrayObjectsRequiredItemJSON(jsonData json.RawMessage) error {
+	return validateComplexObjectForDiveArrayObjectsRequiredItemJSON(jsonData, 0, false)
+}
+func validateComplexObjectForDiveArrayObjectsRequiredItemJSON(jsonData json.RawMessage, _ int, _ bool) error {
 	requiredFields := map[string]bool{"field1": true}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
@@ -399,22 +521,32 @@ func ValidateComplexObjectForDiveArrayObjectsRequiredItemJSON(jsonData json.RawM
 	return nil
 }
 func ValidateComplexObjectForDiveArrayObjectsRequiredJSON(jsonData json.RawMessage) error {
+	return validateComplexObjectForDiveArrayObjectsRequiredJSON(jsonData, 0, false)
+}
+func validateComplexObjectForDiveArrayObjectsRequiredJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
 	var arr []json.RawMessage
 	err := json.Unmarshal(jsonData, &arr)
 	if err != nil {
 		return err
 	}
 	for index, obj := range arr {
-		if !containsNull(obj) {
-			err = ValidateComplexObjectForDiveArrayObjectsRequiredItemJSON(obj)
-			if err != nil {
-				return errors.Wrapf(err, "error validating object at index %d", index)
-			}
+		if containsNull(obj) {
+			return errors.Errorf("item at index %d cannot be null", index)
+		}
+		err = validateComplexObjectForDiveArrayObjectsRequiredItemJSON(obj, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating object at index %d", index)
 		}
 	}
 	return nil
 }
 func ValidateComplexObjectForDiveObjectFieldOptionalJSON(jsonData json.RawMessage) error {
+	return validateComplexObjectForDiveObjectFieldOptionalJSON(jsonData, 0, false)
+}
+func validateComplexObjectForDiveObjectFieldOptionalJSON(jsonData json.RawMessage, _ int, _ bool) error {
 	requiredFields := map[string]bool{"field1": true}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
@@ -436,6 +568,9 @@ func ValidateComplexObjectForDiveObjectFieldOptionalJSON(jsonData json.RawMessag
 	return nil
 }
 func ValidateComplexObjectForDiveObjectFieldRequiredJSON(jsonData json.RawMessage) error {
+	return validateComplexObjectForDiveObjectFieldRequiredJSON(jsonData, 0, false)
+}
+func validateComplexObjectForDiveObjectFieldRequiredJSON(jsonData json.RawMessage, _ int, _ bool) error {
 	requiredFields := map[string]bool{"field1": true}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
@@ -457,6 +592,12 @@ func ValidateComplexObjectForDiveObjectFieldRequiredJSON(jsonData json.RawMessag
 	return nil
 }
 func ValidateComplexObjectForDiveJSON(jsonData json.RawMessage) error {
+	return validateComplexObjectForDiveJSON(jsonData, 0, false)
+}
+func validateComplexObjectForDiveJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
 	requiredFields := map[string]bool{"array_objects_required": true, "array_strings_required": true, "object_field_required": true}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
@@ -477,35 +618,168 @@ func ValidateComplexObjectForDiveJSON(jsonData json.RawMessage) error {
 	}
 	val, exists = obj["array_objects_optional"]
 	if exists && !containsNull(val) {
-		err = ValidateComplexObjectForDiveArrayObjectsOptionalJSON(val)
+		err = validateComplexObjectForDiveArrayObjectsOptionalJSON(val, depth+1, request)
 		if err != nil {
 			return errors.Wrap(err, "field array_objects_optional is not valid")
 		}
 	}
 	val, exists = obj["array_objects_required"]
 	if exists && !containsNull(val) {
-		err = ValidateComplexObjectForDiveArrayObjectsRequiredJSON(val)
+		err = validateComplexObjectForDiveArrayObjectsRequiredJSON(val, depth+1, request)
 		if err != nil {
 			return errors.Wrap(err, "field array_objects_required is not valid")
 		}
 	}
 	val, exists = obj["object_field_optional"]
 	if exists && !containsNull(val) {
-		err = ValidateComplexObjectForDiveObjectFieldOptionalJSON(val)
+		err = validateComplexObjectForDiveObjectFieldOptionalJSON(val, depth+1, request)
 		if err != nil {
 			return errors.Wrap(err, "field object_field_optional is not valid")
 		}
 	}
 	val, exists = obj["object_field_required"]
 	if exists && !containsNull(val) {
-		err = ValidateComplexObjectForDiveObjectFieldRequiredJSON(val)
+		err = validateComplexObjectForDiveObjectFieldRequiredJSON(val, depth+1, request)
 		if err != nil {
 			return errors.Wrap(err, "field object_field_required is not valid")
 		}
 	}
 	return nil
 }
+func ValidateInventoryLabelsJSON(jsonData json.RawMessage) error {
+	return validateInventoryLabelsJSON(jsonData, 0, false)
+}
+func validateInventoryLabelsJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	for key, val := range obj {
+		if containsNull(val) {
+			return errors.Errorf("value of key %s cannot be null", key)
+		}
+	}
+	return nil
+}
+func ValidateInventoryStockValueJSON(jsonData json.RawMessage) error {
+	return validateInventoryStockValueJSON(jsonData, 0, false)
+}
+func validateInventoryStockValueJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var arr []json.RawMessage
+	err := json.Unmarshal(jsonData, &arr)
+	if err != nil {
+		return err
+	}
+	for index, obj := range arr {
+		if containsNull(obj) {
+			return errors.Errorf("item at index %d cannot be null", index)
+		}
+		err = validateNamedJSON(obj, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating object at index %d", index)
+		}
+	}
+	return nil
+}
+func ValidateInventoryStockJSON(jsonData json.RawMessage) error {
+	return validateInventoryStockJSON(jsonData, 0, false)
+}
+func validateInventoryStockJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	for key, val := range obj {
+		if containsNull(val) {
+			return errors.Errorf("value of key %s cannot be null", key)
+		}
+		err = validateInventoryStockValueJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating value of key %s", key)
+		}
+	}
+	return nil
+}
+func ValidateInventoryJSON(jsonData json.RawMessage) error {
+	return validateInventoryJSON(jsonData, 0, false)
+}
+func validateInventoryJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	requiredFields := map[string]bool{"name": true, "stock": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	val, exists = obj["labels"]
+	if exists && !containsNull(val) {
+		err = validateInventoryLabelsJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field labels is not valid")
+		}
+	}
+	val, exists = obj["stock"]
+	if exists && !containsNull(val) {
+		err = validateInventoryStockJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field stock is not valid")
+		}
+	}
+	return nil
+}
+func ValidateNamedJSON(jsonData json.RawMessage) error {
+	return validateNamedJSON(jsonData, 0, false)
+}
+func validateNamedJSON(jsonData json.RawMessage, _ int, _ bool) error {
+	requiredFields := map[string]bool{"name": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	return nil
+}
 func ValidateNewResourseResponseJSON(jsonData json.RawMessage) error {
+	return validateNewResourseResponseJSON(jsonData, 0, false)
+}
+func validateNewResourseResponseJSON(jsonData json.RawMessage, _ int, request bool) error {
 	requiredFields := map[string]bool{"count": true, "name": true, "param": true}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
@@ -524,5 +798,110 @@ func ValidateNewResourseResponseJSON(jsonData json.RawMessage) error {
 			return errors.New("field " + field + " cannot be null")
 		}
 	}
+	if request {
+		for _, field := range []string{"resource-id"} {
+			if _, exists = obj[field]; exists {
+				return errors.New("field " + field + " is read only")
+			}
+		}
+	}
+	return nil
+}
+func ValidateTreeNodeChildrenJSON(jsonData json.RawMessage) error {
+	return validateTreeNodeChildrenJSON(jsonData, 0, false)
+}
+func validateTreeNodeChildrenJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	var arr []json.RawMessage
+	err := json.Unmarshal(jsonData, &arr)
+	if err != nil {
+		return err
+	}
+	for index, obj := range arr {
+		if containsNull(obj) {
+			return errors.Errorf("item at index %d cannot be null", index)
+		}
+		err = validateTreeNodeJSON(obj, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating object at index %d", index)
+		}
+	}
+	return nil
+}
+func ValidateTreeNodeJSON(jsonData json.RawMessage) error {
+	return validateTreeNodeJSON(jsonData, 0, false)
+}
+func validateTreeNodeJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
+	requiredFields := map[string]bool{"name": true}
+	nullableFields := map[string]bool{}
+	var obj map[string]json.RawMessage
+	err := json.Unmarshal(jsonData, &obj)
+	if err != nil {
+		return err
+	}
+	var val json.RawMessage
+	var exists bool
+	for field := range requiredFields {
+		val, exists = obj[field]
+		if !exists {
+			return errors.New("field " + field + " is required")
+		}
+		if !nullableFields[field] && containsNull(val) {
+			return errors.New("field " + field + " cannot be null")
+		}
+	}
+	val, exists = obj["children"]
+	if exists && !containsNull(val) {
+		err = validateTreeNodeChildrenJSON(val, depth+1, request)
+		if err != nil {
+			return errors.Wrap(err, "field children is not valid")
+		}
+	}
+	return nil
+}
+func validatePattern(fl validator.FieldLevel) bool {
+	pattern := fl.Param()
+	cached, ok := patternsCache.Load(pattern)
+	if !ok {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return false
+		}
+		cached, _ = patternsCache.LoadOrStore(pattern, compiled)
+	}
+	re, ok := cached.(*regexp.Regexp)
+	return ok && re.MatchString(fl.Field().String())
+}
+func validateMultipleOf(fl validator.FieldLevel) bool {
+	divisor, err := strconv.ParseFloat(fl.Param(), 64)
+	if err != nil || divisor == 0 {
+		return false
+	}
+	field := fl.Field()
+	var value float64
+	switch {
+	case field.CanInt():
+		value = float64(field.Int())
+	case field.CanUint():
+		value = float64(field.Uint())
+	case field.CanFloat():
+		value = field.Float()
+	default:
+		return false
+	}
+	quotient := value / divisor
+	return math.Abs(quotient-math.Round(quotient)) < 1e-9
+}
+func nullableValue(field reflect.Value) any {
+	if value, ok := field.Interface().(interface {
+		ValidationValue() any
+	}); ok {
+		return value.ValidationValue()
+	}
 	return nil
 }
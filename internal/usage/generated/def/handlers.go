@@ -9,6 +9,8 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
+const maxValidationDepth = 64
+
 type Handler struct {
 	validator *validator.Validate
 }
@@ -27,6 +29,12 @@ func containsNull(data json.RawMessage) bool {
 	return temp == nil
 }
 func ValidateExternalObjectJSON(jsonData json.RawMessage) error {
+	return validateExternalObjectJSON(jsonData, 0, false)
+}
+func validateExternalObjectJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
 	var obj map[string]json.RawMessage
 	err := json.Unmarshal(jsonData, &obj)
 	if err != nil {
@@ -36,13 +44,16 @@ func ValidateExternalObjectJSON(jsonData json.RawMessage) error {
 	var exists bool
 	val, exists = obj["field2"]
 	if exists && !containsNull(val) {
-		err = ValidateExternalRef2JSON(val)
+		err = validateExternalRef2JSON(val, depth+1, request)
 		if err != nil {
 			return errors.Wrap(err, "field field2 is not valid")
 		}
 	}
 	return nil
 }
-func ValidateExternalRef2JSON(_ json.RawMessage) error {
+func ValidateExternalRef2JSON(jsonData json.RawMessage) error {
+	return validateExternalRef2JSON(jsonData, 0, false)
+}
+func validateExternalRef2JSON(_ json.RawMessage, _ int, _ bool) error {
 	return nil
 }
@@ -1,3 +1,3 @@
 package usage
 
-//go:generate go run ../../cmd/generate.go -d ./ -p github.com/jolfzverb/codegen/internal/usage a_pi.yaml def.yml
+//go:generate go run ../../cmd/generate.go -nullable-type
@@ -0,0 +1,56 @@
+// Package plugin is the interface of generator plugins, which emit extra Go
+// files from the specs loaded by the generator.
+package plugin
+
+import (
+	"go/ast"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Plugin generates extra files for every spec file. The files are formatted
+// and get the generated code header like the handlers and the models.
+// Generate is called concurrently for the spec files of a run.
+type Plugin interface {
+	// Name identifies the plugin in errors.
+	Name() string
+	Generate(spec *Spec) ([]File, error)
+}
+
+// Spec is a spec file loaded by the generator and the names of its generated
+// code.
+type Spec struct {
+	File     string
+	Document *openapi3.T
+
+	Package        string
+	ModelsPackage  string
+	HandlersDir    string
+	ModelsDir      string
+	HandlersImport string
+	ModelsImport   string
+
+	// Models are the Go type names of the component schemas, keyed by the
+	// schema name.
+	Models map[string]string
+	// Operations are in the order of the routes.
+	Operations []Operation
+}
+
+// Operation is an operation of a spec file, the generated handler interface is
+// <BaseName>Handler with the method Handle<BaseName>.
+type Operation struct {
+	// Method is in upper case, like GET.
+	Method    string
+	Path      string
+	BaseName  string
+	Operation *openapi3.Operation
+}
+
+// File is a Go file generated by a plugin.
+type File struct {
+	// Path is the path of the file, like the directories of Spec it includes
+	// the output directory.
+	Path string
+	File *ast.File
+}
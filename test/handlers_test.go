@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -36,6 +37,7 @@ func (m *mockHandler) HandleCreate(ctx context.Context, r apimodels.CreateReques
 		date2 = new(time.Time)
 		*date2 = r.Headers.OptionalHeader.UTC()
 	}
+	resourceID := "resource"
 	return api.Create200Response(
 		apimodels.NewResourseResponse{
 			Count:        r.Query.Count,
@@ -44,8 +46,13 @@ func (m *mockHandler) HandleCreate(ctx context.Context, r apimodels.CreateReques
 			Param:        r.Path.Param,
 			Date:         date,
 			Date2:        date2,
-			EnumVal:      r.Body.EnumVal,
+			EnumVal:      (*string)(r.Body.EnumVal),
 			DecimalField: r.Body.DecimalField,
+			RequestID:    r.Query.RequestID,
+			Day:          r.Query.Day,
+			URIField:     r.Body.URIField,
+			ByteField:    r.Body.ByteField,
+			ResourceID:   &resourceID,
 		},
 		apimodels.CreateResponse200Headers{
 			IdempotencyKey: &r.Headers.IdempotencyKey,
@@ -128,6 +135,80 @@ func TestHandler(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
+	for _, tc := range []struct {
+		name         string
+		field        string
+		expectedCode int
+	}{
+		{name: "200 pattern", field: `"pattern-field": "abc,def"`, expectedCode: http.StatusOK},
+		{name: "400 pattern", field: `"pattern-field": "abc,"`, expectedCode: http.StatusBadRequest},
+		{name: "200 multipleOf", field: `"multiple-of-field": 15`, expectedCode: http.StatusOK},
+		{name: "400 multipleOf", field: `"multiple-of-field": 12`, expectedCode: http.StatusBadRequest},
+		{name: "200 exclusive bounds", field: `"exclusive-field": 0.5`, expectedCode: http.StatusOK},
+		{name: "400 exclusive minimum", field: `"exclusive-field": 0`, expectedCode: http.StatusBadRequest},
+		{name: "400 exclusive maximum", field: `"exclusive-field": 1`, expectedCode: http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody := `{"name": "value", ` + tc.field + `}`
+			request, err := http.NewRequest(http.MethodPost, server.URL+"/path/to/param/resourse?count=3", bytes.NewBufferString(requestBody))
+			assert.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Idempotency-Key", "unique-idempotency-key")
+			request.Header.Set("Cookie", "required-cookie-param=required-value")
+			resp, err := http.DefaultClient.Do(request)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+		})
+	}
+	t.Run("200 string formats", func(t *testing.T) {
+		requestBody := `{"name": "value", "uri-field": "https://example.com/a?b=c", "byte-field": "aGVsbG8=", "hostname-field": "api.example.com", "duration-field": "P1DT2H", "server-addr": "10.0.0.1"}`
+		request, err := http.NewRequest(http.MethodPost,
+			server.URL+"/path/to/param/resourse?count=3&request-id=6ba7b810-9dad-11d1-80b4-00c04fd430c8&day=2024-02-29",
+			bytes.NewBufferString(requestBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Idempotency-Key", "unique-idempotency-key")
+		request.Header.Set("Cookie", "required-cookie-param=required-value")
+		resp, err := http.DefaultClient.Do(request)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		defer resp.Body.Close()
+		var responseBody map[string]any
+		err = json.NewDecoder(resp.Body).Decode(&responseBody)
+		assert.NoError(t, err)
+		assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", responseBody["request-id"])
+		assert.Equal(t, "2024-02-29", responseBody["day"])
+		assert.Equal(t, "https://example.com/a?b=c", responseBody["uri-field"])
+		assert.Equal(t, "aGVsbG8=", responseBody["byte-field"])
+	})
+	for _, tc := range []struct {
+		name  string
+		query string
+		field string
+	}{
+		{name: "400 invalid uuid", query: "&request-id=not-a-uuid", field: `"description": "descr"`},
+		{name: "400 invalid date", query: "&day=2024-02-30", field: `"description": "descr"`},
+		{name: "400 relative uri", query: "", field: `"uri-field": "/relative"`},
+		{name: "400 invalid byte", query: "", field: `"byte-field": "not base64!"`},
+		{name: "400 invalid hostname", query: "", field: `"hostname-field": "bad host"`},
+		{name: "400 invalid duration", query: "", field: `"duration-field": "1 day"`},
+		{name: "400 invalid x-go-type", query: "", field: `"server-addr": "not-an-ip"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody := `{"name": "value", ` + tc.field + `}`
+			request, err := http.NewRequest(http.MethodPost, server.URL+"/path/to/param/resourse?count=3"+tc.query, bytes.NewBufferString(requestBody))
+			assert.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Idempotency-Key", "unique-idempotency-key")
+			request.Header.Set("Cookie", "required-cookie-param=required-value")
+			resp, err := http.DefaultClient.Do(request)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
 	t.Run("400 required cookie", func(t *testing.T) {
 		requestBody := `{"name": "value"}`
 		request, err := http.NewRequest(http.MethodPost, server.URL+"/path/to/param/resourse?count=3", bytes.NewBufferString(requestBody))
@@ -187,6 +268,124 @@ func TestHandler(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 	})
+	for _, tc := range []struct {
+		name   string
+		tree   string
+		status int
+	}{
+		{name: "200 recursive tree", tree: `{"name": "root", "children": [{"name": "leaf", "children": []}]}`, status: http.StatusOK},
+		{name: "400 invalid nested tree node", tree: `{"name": "root", "children": [{"children": []}]}`, status: http.StatusBadRequest},
+		{
+			name:   "400 tree nested too deep",
+			tree:   strings.Repeat(`{"name": "node", "children": [`, 100) + `{"name": "leaf"}` + strings.Repeat(`]}`, 100),
+			status: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody := `{"name": "value", "description": "descr", "date": "2023-10-01T00:00:00+03:00", "code_for_response": 200, "enum-val": "value1", "decimal-field": "13.42", "tree": ` +
+				tc.tree + `}`
+			request, err := http.NewRequest(http.MethodPost, server.URL+"/path/to/param/resourse?count=3", bytes.NewBufferString(requestBody))
+			assert.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Idempotency-Key", "unique-idempotency-key")
+			request.Header.Set("Cookie", "required-cookie-param=required-value")
+			resp, err := http.DefaultClient.Do(request)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.status, resp.StatusCode)
+			resp.Body.Close()
+		})
+	}
+	for _, tc := range []struct {
+		name      string
+		inventory string
+		status    int
+	}{
+		{
+			name:      "200 maps and nullable items",
+			inventory: `{"name": "main", "stock": {"a": [{"name": "x"}]}, "labels": {"k": "v"}, "scores": [1, null]}`,
+			status:    http.StatusOK,
+		},
+		{name: "400 allOf required field", inventory: `{"stock": {}}`, status: http.StatusBadRequest},
+		{name: "400 null array item in map", inventory: `{"name": "main", "stock": {"a": [null]}}`, status: http.StatusBadRequest},
+		{name: "400 invalid object in map", inventory: `{"name": "main", "stock": {"a": [{"name": ""}]}}`, status: http.StatusBadRequest},
+		{name: "400 null map value", inventory: `{"name": "main", "stock": {"a": null}}`, status: http.StatusBadRequest},
+		{name: "400 map value too long", inventory: `{"name": "main", "stock": {}, "labels": {"k": "very long label"}}`, status: http.StatusBadRequest},
+		{name: "400 too many map keys", inventory: `{"name": "main", "stock": {}, "labels": {"a": "1", "b": "2", "c": "3"}}`, status: http.StatusBadRequest},
+		{name: "400 invalid nullable item", inventory: `{"name": "main", "stock": {}, "scores": [-1]}`, status: http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody := `{"name": "value", "description": "descr", "date": "2023-10-01T00:00:00+03:00", "code_for_response": 200, "enum-val": "value1", "decimal-field": "13.42", "inventory": ` +
+				tc.inventory + `}`
+			request, err := http.NewRequest(http.MethodPost, server.URL+"/path/to/param/resourse?count=3", bytes.NewBufferString(requestBody))
+			assert.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Idempotency-Key", "unique-idempotency-key")
+			request.Header.Set("Cookie", "required-cookie-param=required-value")
+			resp, err := http.DefaultClient.Do(request)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.status, resp.StatusCode)
+			resp.Body.Close()
+		})
+	}
+	for _, tc := range []struct {
+		name    string
+		contact string
+		status  int
+	}{
+		{name: "200 required nullable field is null", contact: `{"nickname": null}`, status: http.StatusOK},
+		{name: "200 required nullable field is valid", contact: `{"nickname": "nick"}`, status: http.StatusOK},
+		{name: "400 required nullable field is too short", contact: `{"nickname": "ni"}`, status: http.StatusBadRequest},
+		{name: "400 required nullable field is absent", contact: `{}`, status: http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBody := `{"name": "value", "description": "descr", "date": "2023-10-01T00:00:00+03:00", "code_for_response": 200, "enum-val": "value1", "decimal-field": "13.42", "contact": ` +
+				tc.contact + `}`
+			request, err := http.NewRequest(http.MethodPost, server.URL+"/path/to/param/resourse?count=3", bytes.NewBufferString(requestBody))
+			assert.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Idempotency-Key", "unique-idempotency-key")
+			request.Header.Set("Cookie", "required-cookie-param=required-value")
+			resp, err := http.DefaultClient.Do(request)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.status, resp.StatusCode)
+			resp.Body.Close()
+		})
+	}
+	t.Run("400 read-only field in request", func(t *testing.T) {
+		requestBody := `{"name": "value", "description": "descr", "date": "2023-10-01T00:00:00+03:00", "code_for_response": 200, "enum-val": "value1", "decimal-field": "13.42", "resource-id": "id"}`
+		request, err := http.NewRequest(http.MethodPost, server.URL+"/path/to/param/resourse?count=3", bytes.NewBufferString(requestBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Idempotency-Key", "unique-idempotency-key")
+		request.Header.Set("Cookie", "required-cookie-param=required-value")
+		resp, err := http.DefaultClient.Do(request)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		defer resp.Body.Close()
+		var responseBody map[string]any
+		err = json.NewDecoder(resp.Body).Decode(&responseBody)
+		assert.NoError(t, err)
+		assert.Equal(t, "field resource-id is read only", responseBody["error"])
+	})
+	t.Run("200 response with read-only field", func(t *testing.T) {
+		requestBody := `{"name": "value", "description": "descr", "date": "2023-10-01T00:00:00+03:00", "code_for_response": 200, "enum-val": "value1", "decimal-field": "13.42"}`
+		request, err := http.NewRequest(http.MethodPost, server.URL+"/path/to/param/resourse?count=3", bytes.NewBufferString(requestBody))
+		assert.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Idempotency-Key", "unique-idempotency-key")
+		request.Header.Set("Cookie", "required-cookie-param=required-value")
+		resp, err := http.DefaultClient.Do(request)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		defer resp.Body.Close()
+		var responseBody json.RawMessage
+		err = json.NewDecoder(resp.Body).Decode(&responseBody)
+		assert.NoError(t, err)
+		assert.Contains(t, string(responseBody), `"resource-id":"resource"`)
+		assert.NoError(t, api.ValidateNewResourseResponseJSON(responseBody))
+	})
 	t.Run("200 on dive 1", func(t *testing.T) {
 		requestBody := `{"name": "value", "description": "descr", "date": "2023-10-01T00:00:00+03:00", "code_for_response": 200, "enum-val": "value1", "decimal-field": "13.42",
 		"field_to_validate_dive": {
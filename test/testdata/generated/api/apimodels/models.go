@@ -11,14 +11,75 @@ type CreateQueryParams struct {
 	Count string `json:"count" validate:"required"`
 }
 type CreateHeaders struct {
-	IdempotencyKey string     `json:"Idempotency-Key" validate:"required,min=1,max=100"`
+	// Constraints: min length 1, max length 100.
+	IdempotencyKey string `json:"Idempotency-Key" validate:"required,min=1,max=100"`
+	// Constraints: format date-time.
 	OptionalHeader *time.Time `json:"Optional-Header,omitempty" validate:"omitempty"`
 }
 type CreateCookies struct {
-	CookieParam         *string `json:"cookie-param,omitempty" validate:"omitempty,min=10,max=15"`
-	RequiredCookieParam string  `json:"required-cookie-param" validate:"required,min=10,max=15"`
+	// Constraints: min length 10, max length 15.
+	CookieParam *string `json:"cookie-param,omitempty" validate:"omitempty,min=10,max=15"`
+	// Constraints: min length 10, max length 15.
+	RequiredCookieParam string `json:"required-cookie-param" validate:"required,min=10,max=15"`
 }
 type CreateRequestBodyArrayField []string
+type CreateRequestBodyEnumInt int
+
+const (
+	CreateRequestBodyEnumInt1 CreateRequestBodyEnumInt = 1
+	CreateRequestBodyEnumInt2 CreateRequestBodyEnumInt = 2
+	CreateRequestBodyEnumInt3 CreateRequestBodyEnumInt = 3
+)
+
+func (v CreateRequestBodyEnumInt) IsValid() bool {
+	switch v {
+	case CreateRequestBodyEnumInt1, CreateRequestBodyEnumInt2, CreateRequestBodyEnumInt3:
+		return true
+	}
+	return false
+}
+func AllCreateRequestBodyEnumIntValues() []CreateRequestBodyEnumInt {
+	return []CreateRequestBodyEnumInt{CreateRequestBodyEnumInt1, CreateRequestBodyEnumInt2, CreateRequestBodyEnumInt3}
+}
+
+type CreateRequestBodyEnumNumber float64
+
+const (
+	CreateRequestBodyEnumNumber1Dot1 CreateRequestBodyEnumNumber = 1.1
+	CreateRequestBodyEnumNumber2Dot2 CreateRequestBodyEnumNumber = 2.2
+	CreateRequestBodyEnumNumber3Dot3 CreateRequestBodyEnumNumber = 3.3
+)
+
+func (v CreateRequestBodyEnumNumber) IsValid() bool {
+	switch v {
+	case CreateRequestBodyEnumNumber1Dot1, CreateRequestBodyEnumNumber2Dot2, CreateRequestBodyEnumNumber3Dot3:
+		return true
+	}
+	return false
+}
+func AllCreateRequestBodyEnumNumberValues() []CreateRequestBodyEnumNumber {
+	return []CreateRequestBodyEnumNumber{CreateRequestBodyEnumNumber1Dot1, CreateRequestBodyEnumNumber2Dot2, CreateRequestBodyEnumNumber3Dot3}
+}
+
+type CreateRequestBodyEnumVal string
+
+const (
+	CreateRequestBodyEnumValValue1 CreateRequestBodyEnumVal = "value1"
+	CreateRequestBodyEnumValValue2 CreateRequestBodyEnumVal = "value2"
+	CreateRequestBodyEnumValValue3 CreateRequestBodyEnumVal = "value3"
+)
+
+func (v CreateRequestBodyEnumVal) IsValid() bool {
+	switch v {
+	case CreateRequestBodyEnumValValue1, CreateRequestBodyEnumValValue2, CreateRequestBodyEnumValValue3:
+		return true
+	}
+	return false
+}
+func AllCreateRequestBodyEnumValValues() []CreateRequestBodyEnumVal {
+	return []CreateRequestBodyEnumVal{CreateRequestBodyEnumValValue1, CreateRequestBodyEnumValValue2, CreateRequestBodyEnumValValue3}
+}
+
 type CreateRequestBodyObjectArrayItem struct {
 	Subfield1 *string `json:"subfield1,omitempty" validate:"omitempty"`
 	Subfield2 *int    `json:"subfield2,omitempty" validate:"omitempty"`
@@ -33,16 +94,19 @@ type CreateRequestBodyObjectField struct {
 	Field2 *CreateRequestBodyObjectFieldField2 `json:"field2,omitempty" validate:"omitempty"`
 }
 type CreateRequestBody struct {
-	ArrayField      *CreateRequestBodyArrayField  `json:"array-field,omitempty" validate:"omitempty,dive"`
-	CodeForResponse *int                          `json:"code_for_response,omitempty" validate:"omitempty,min=100,max=999"`
-	Date            *time.Time                    `json:"date,omitempty" validate:"omitempty"`
-	Description     *string                       `json:"description,omitempty" validate:"omitempty,min=1,max=10"`
-	EnumInt         *int                          `json:"enum-int,omitempty" validate:"omitempty,oneof=1 2 3"`
-	EnumNumber      *float64                      `json:"enum-number,omitempty" validate:"omitempty,oneof=1.1 2.2 3.3"`
-	EnumVal         *string                       `json:"enum-val,omitempty" validate:"omitempty,oneof=value1 value2 value3"`
-	Name            string                        `json:"name"`
-	ObjectArray     *CreateRequestBodyObjectArray `json:"object-array,omitempty" validate:"omitempty,dive"`
-	ObjectField     *CreateRequestBodyObjectField `json:"object-field,omitempty" validate:"omitempty"`
+	ArrayField *CreateRequestBodyArrayField `json:"array-field,omitempty" validate:"omitempty,dive"`
+	// Constraints: minimum 100, maximum 999.
+	CodeForResponse *int `json:"code_for_response,omitempty" validate:"omitempty,min=100,max=999"`
+	// Constraints: format date-time.
+	Date *time.Time `json:"date,omitempty" validate:"omitempty"`
+	// Constraints: min length 1, max length 10.
+	Description *string                       `json:"description,omitempty" validate:"omitempty,min=1,max=10"`
+	EnumInt     *CreateRequestBodyEnumInt     `json:"enum-int,omitempty" validate:"omitempty,oneof=1 2 3"`
+	EnumNumber  *CreateRequestBodyEnumNumber  `json:"enum-number,omitempty" validate:"omitempty,oneof=1.1 2.2 3.3"`
+	EnumVal     *CreateRequestBodyEnumVal     `json:"enum-val,omitempty" validate:"omitempty,oneof=value1 value2 value3"`
+	Name        string                        `json:"name"`
+	ObjectArray *CreateRequestBodyObjectArray `json:"object-array,omitempty" validate:"omitempty,dive"`
+	ObjectField *CreateRequestBodyObjectField `json:"object-field,omitempty" validate:"omitempty"`
 }
 type CreateRequest struct {
 	Path    CreatePathParams
@@ -52,6 +116,7 @@ type CreateRequest struct {
 	Body    CreateRequestBody
 }
 type CreateResponse200Headers struct {
+	// Idempotency key from request
 	IdempotencyKey *string `json:"Idempotency-Key,omitempty" validate:"omitempty"`
 }
 type CreateResponse200 struct {
@@ -69,8 +134,10 @@ type CreateResponse struct {
 	Response404 *CreateResponse404
 }
 type NewResourseResponse struct {
-	Count       string     `json:"count"`
-	Date        *time.Time `json:"date,omitempty" validate:"omitempty"`
+	Count string `json:"count"`
+	// Constraints: format date-time.
+	Date *time.Time `json:"date,omitempty" validate:"omitempty"`
+	// Constraints: format date-time.
 	Date2       *time.Time `json:"date2,omitempty" validate:"omitempty"`
 	Description *string    `json:"description,omitempty" validate:"omitempty"`
 	EnumVal     *string    `json:"enum-val,omitempty" validate:"omitempty"`
@@ -15,9 +15,14 @@ import (
 	"github.com/jolfzverb/codegen/test/testdata/generated/api/apimodels"
 )
 
+// CreateHandler handles POST /path/to/{param}/resourse.
 type CreateHandler interface {
+	// Create new resource
 	HandleCreate(ctx context.Context, r apimodels.CreateRequest) (*apimodels.CreateResponse, error)
 }
+
+const maxValidationDepth = 64
+
 type Handler struct {
 	validator *validator.Validate
 	create    CreateHandler
@@ -98,37 +103,56 @@ func (h *Handler) parseCreateCookies(r *http.Request) (*apimodels.CreateCookies,
 	}
 	return &cookies, nil
 }
-func ValidateCreateRequestBodyObjectArrayItemJSON(_ json.RawMessage) error {
+func ValidateCreateRequestBodyObjectArrayItemJSON(jsonData json.RawMessage) error {
+	return validateCreateRequestBodyObjectArrayItemJSON(jsonData, 0, false)
+}
+func validateCreateRequestBodyObjectArrayItemJSON(_ json.RawMessage, _ int, _ bool) error {
 	return nil
 }
+func containsNull(data json.RawMessage) bool {
+	var temp any
+	err := json.Unmarshal(data, &temp)
+	if err != nil {
+		return false
+	}
+	return temp == nil
+}
 func ValidateCreateRequestBodyObjectArrayJSON(jsonData json.RawMessage) error {
+	return validateCreateRequestBodyObjectArrayJSON(jsonData, 0, false)
+}
+func validateCreateRequestBodyObjectArrayJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
 	var arr []json.RawMessage
 	err := json.Unmarshal(jsonData, &arr)
 	if err != nil {
 		return err
 	}
 	for index, obj := range arr {
-		if !containsNull(obj) {
-			err = ValidateCreateRequestBodyObjectArrayItemJSON(obj)
-			if err != nil {
-				return errors.Wrapf(err, "error validating object at index %d", index)
-			}
+		if containsNull(obj) {
+			return errors.Errorf("item at index %d cannot be null", index)
+		}
+		err = validateCreateRequestBodyObjectArrayItemJSON(obj, depth+1, request)
+		if err != nil {
+			return errors.Wrapf(err, "error validating object at index %d", index)
 		}
 	}
 	return nil
 }
-func ValidateCreateRequestBodyObjectFieldField2JSON(_ json.RawMessage) error {
-	return nil
+func ValidateCreateRequestBodyObjectFieldField2JSON(jsonData json.RawMessage) error {
+	return validateCreateRequestBodyObjectFieldField2JSON(jsonData, 0, false)
 }
-func containsNull(data json.RawMessage) bool {
-	var temp any
-	err := json.Unmarshal(data, &temp)
-	if err != nil {
-		return false
-	}
-	return temp == nil
+func validateCreateRequestBodyObjectFieldField2JSON(_ json.RawMessage, _ int, _ bool) error {
+	return nil
 }
 func ValidateCreateRequestBodyObjectFieldJSON(jsonData json.RawMessage) error {
+	return validateCreateRequestBodyObjectFieldJSON(jsonData, 0, false)
+}
+func validateCreateRequestBodyObjectFieldJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
 	var obj map[string]json.RawMessage
 	err := json.Unmarshal(jsonData, &obj)
 	if err != nil {
@@ -138,7 +162,7 @@ func ValidateCreateRequestBodyObjectFieldJSON(jsonData json.RawMessage) error {
 	var exists bool
 	val, exists = obj["field2"]
 	if exists && !containsNull(val) {
-		err = ValidateCreateRequestBodyObjectFieldField2JSON(val)
+		err = validateCreateRequestBodyObjectFieldField2JSON(val, depth+1, request)
 		if err != nil {
 			return errors.Wrap(err, "field field2 is not valid")
 		}
@@ -146,6 +170,12 @@ func ValidateCreateRequestBodyObjectFieldJSON(jsonData json.RawMessage) error {
 	return nil
 }
 func ValidateCreateRequestBodyJSON(jsonData json.RawMessage) error {
+	return validateCreateRequestBodyJSON(jsonData, 0, false)
+}
+func validateCreateRequestBodyJSON(jsonData json.RawMessage, depth int, request bool) error {
+	if depth > maxValidationDepth {
+		return errors.New("maximum nesting depth exceeded")
+	}
 	requiredFields := map[string]bool{"name": true}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
@@ -166,14 +196,14 @@ func ValidateCreateRequestBodyJSON(jsonData json.RawMessage) error {
 	}
 	val, exists = obj["object-array"]
 	if exists && !containsNull(val) {
-		err = ValidateCreateRequestBodyObjectArrayJSON(val)
+		err = validateCreateRequestBodyObjectArrayJSON(val, depth+1, request)
 		if err != nil {
 			return errors.Wrap(err, "field object-array is not valid")
 		}
 	}
 	val, exists = obj["object-field"]
 	if exists && !containsNull(val) {
-		err = ValidateCreateRequestBodyObjectFieldJSON(val)
+		err = validateCreateRequestBodyObjectFieldJSON(val, depth+1, request)
 		if err != nil {
 			return errors.Wrap(err, "field object-field is not valid")
 		}
@@ -186,7 +216,7 @@ func (h *Handler) parseCreateRequestBody(r *http.Request) (*apimodels.CreateRequ
 	if err != nil {
 		return nil, err
 	}
-	err = ValidateCreateRequestBodyJSON(bodyJSON)
+	err = validateCreateRequestBodyJSON(bodyJSON, 0, true)
 	if err != nil {
 		return nil, err
 	}
@@ -321,6 +351,9 @@ func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
 	}
 }
 func ValidateNewResourseResponseJSON(jsonData json.RawMessage) error {
+	return validateNewResourseResponseJSON(jsonData, 0, false)
+}
+func validateNewResourseResponseJSON(jsonData json.RawMessage, _ int, _ bool) error {
 	requiredFields := map[string]bool{"count": true, "name": true, "param": true}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
@@ -6,24 +6,35 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"regexp"
 	"strconv"
+	"sync"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 	"github.com/jolfzverb/codegen/test/testdata/generated/api3/api3models"
 	"github.com/jolfzverb/codegen/test/testdata/generated/def/defmodels"
 )
 
+// CreateHandler handles GET /path/to/resourse.
 type CreateHandler interface {
+	// Create new resource
 	HandleCreate(ctx context.Context, r api3models.CreateRequest) (*api3models.CreateResponse, error)
 }
+
+var patternsCache sync.Map
+
 type Handler struct {
 	validator *validator.Validate
 	create    CreateHandler
 }
 
 func NewHandler(create CreateHandler) *Handler {
-	return &Handler{validator: validator.New(validator.WithRequiredStructEnabled()), create: create}
+	v := validator.New(validator.WithRequiredStructEnabled())
+	_ = v.RegisterValidation("pattern", validatePattern)
+	_ = v.RegisterValidation("multipleOf", validateMultipleOf)
+	return &Handler{validator: v, create: create}
 }
 func (h *Handler) AddRoutes(router chi.Router) {
 	router.Get("/path/to/resourse", h.handleCreate)
@@ -84,3 +95,36 @@ func (h *Handler) handleCreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+func validatePattern(fl validator.FieldLevel) bool {
+	pattern := fl.Param()
+	cached, ok := patternsCache.Load(pattern)
+	if !ok {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return false
+		}
+		cached, _ = patternsCache.LoadOrStore(pattern, compiled)
+	}
+	re, ok := cached.(*regexp.Regexp)
+	return ok && re.MatchString(fl.Field().String())
+}
+func validateMultipleOf(fl validator.FieldLevel) bool {
+	divisor, err := strconv.ParseFloat(fl.Param(), 64)
+	if err != nil || divisor == 0 {
+		return false
+	}
+	field := fl.Field()
+	var value float64
+	switch {
+	case field.CanInt():
+		value = float64(field.Int())
+	case field.CanUint():
+		value = float64(field.Uint())
+	case field.CanFloat():
+		value = field.Float()
+	default:
+		return false
+	}
+	quotient := value / divisor
+	return math.Abs(quotient-math.Round(quotient)) < 1e-9
+}
@@ -9,8 +9,10 @@ type NewResourseRequest struct {
 	Name        string  `json:"name"`
 }
 type NewResourseResponse struct {
-	Count       string     `json:"count"`
-	Date        *time.Time `json:"date,omitempty" validate:"omitempty"`
+	Count string `json:"count"`
+	// Constraints: format date-time.
+	Date *time.Time `json:"date,omitempty" validate:"omitempty"`
+	// Constraints: format date-time.
 	Date2       *time.Time `json:"date2,omitempty" validate:"omitempty"`
 	Description *string    `json:"description,omitempty" validate:"omitempty"`
 	EnumVal     *string    `json:"enum-val,omitempty" validate:"omitempty"`
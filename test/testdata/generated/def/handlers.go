@@ -27,6 +27,9 @@ func containsNull(data json.RawMessage) bool {
 	return temp == nil
 }
 func ValidateNewResourseRequestJSON(jsonData json.RawMessage) error {
+	return validateNewResourseRequestJSON(jsonData, 0, false)
+}
+func validateNewResourseRequestJSON(jsonData json.RawMessage, _ int, _ bool) error {
 	requiredFields := map[string]bool{"name": true}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage
@@ -48,6 +51,9 @@ func ValidateNewResourseRequestJSON(jsonData json.RawMessage) error {
 	return nil
 }
 func ValidateNewResourseResponseJSON(jsonData json.RawMessage) error {
+	return validateNewResourseResponseJSON(jsonData, 0, false)
+}
+func validateNewResourseResponseJSON(jsonData json.RawMessage, _ int, _ bool) error {
 	requiredFields := map[string]bool{"count": true, "name": true, "param": true}
 	nullableFields := map[string]bool{}
 	var obj map[string]json.RawMessage